@@ -11,7 +11,11 @@ package config
 import (
 	"github.com/spf13/viper"
 	"log"
+	"net"
+	"strings"
 	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
 )
 
 // App is the application config using in main package
@@ -34,6 +38,9 @@ type appConfig struct {
 	// slackChatCnl represent slack channel ID to send chat
 	slackChatCnl *string
 
+	// slackSigningSecret represent secret used for verifying request sent from slack
+	slackSigningSecret *string
+
 	// awsAccountID represent aws account ID
 	awsAccountID *string
 
@@ -48,6 +55,57 @@ type appConfig struct {
 
 	// version represent version of sms health check(this application)
 	version *string
+
+	// apiTokens represent bearer token credentials, used for authenticating HTTP API request
+	apiTokens []domain.Credential
+
+	// hmacKeys represent HMAC key credentials, used for verifying signature of HTTP API request
+	hmacKeys []domain.Credential
+
+	// authAnonymousRead represent if read-only endpoint is accessible without credential
+	authAnonymousRead *bool
+
+	// timezone represent IANA timezone used for current time of every package (Ex, alarm time, profile path, schedule)
+	timezone *time.Location
+
+	// maintenanceTimezone represent timezone used for parsing time of maintenance window
+	maintenanceTimezone *time.Location
+
+	// grpcPort represent port number which gRPC server listen on
+	grpcPort *int
+
+	// grpcAdvertiseAddress represent address of this host registered in consul with gRPC service
+	grpcAdvertiseAddress *string
+
+	// grpcServiceName represent service name of gRPC server registered in consul
+	grpcServiceName *string
+
+	// grpcConsulRegister represent if gRPC server is registered in consul
+	grpcConsulRegister *bool
+
+	// notifySlackEnabled represent if alarm is sent to slack notifier backend
+	notifySlackEnabled *bool
+
+	// notifySlackKibanaURL represent URL template of kibana deep link rendered in slack alarm message
+	notifySlackKibanaURL *string
+
+	// notifyWebhookURL represent URL of generic JSON webhook notifier backend
+	notifyWebhookURL *string
+
+	// notifyDiscordURL represent URL of discord webhook notifier backend
+	notifyDiscordURL *string
+
+	// notifySMTPAddress represent address of SMTP server used in email notifier backend
+	notifySMTPAddress *string
+
+	// notifySMTPFrom represent email address of sender in email notifier backend
+	notifySMTPFrom *string
+
+	// notifySMTPTo represent email address list of recipients in email notifier backend
+	notifySMTPTo []string
+
+	// notifyPolicy represent notification policy deciding if alarm is sent or suppressed (dedup, rate limit, flap)
+	notifyPolicy *domain.NotifyPolicy
 }
 
 // return elasticsearch address get from environment variable
@@ -120,6 +178,20 @@ func (ac *appConfig) SlackChatChannel() string {
 	return *ac.slackChatCnl
 }
 
+// SlackSigningSecret return slack signing secret get from environment variable
+// if not set, every slash command request is rejected
+func (ac *appConfig) SlackSigningSecret() string {
+	if ac.slackSigningSecret != nil {
+		return *ac.slackSigningSecret
+	}
+
+	ac.slackSigningSecret = _string(viper.GetString("SLACK_SIGNING_SECRET"))
+	if *ac.slackSigningSecret == "" {
+		log.Println("SLACK_SIGNING_SECRET is not set in environment variable, so every slash command will be rejected")
+	}
+	return *ac.slackSigningSecret
+}
+
 // Version return version from environment variable
 func (ac *appConfig) Version() string {
 	if ac.version != nil {
@@ -134,6 +206,372 @@ func (ac *appConfig) Version() string {
 	return *ac.version
 }
 
+// APITokens return bearer token credentials from environment variable API_TOKENS (format: name:role:token,...)
+// OPERATOR_TOKENS (format: name:token,name:token) is deprecated, but is still read as operator role tokens for compatibility
+// if both are not set, every request which needs authentication is rejected except read request allowed to anonymous
+func (ac *appConfig) APITokens() []domain.Credential {
+	if ac.apiTokens != nil {
+		return ac.apiTokens
+	}
+
+	ac.apiTokens = []domain.Credential{}
+	if !viper.IsSet("OPERATOR_TOKENS") && !viper.IsSet("API_TOKENS") {
+		log.Println("OPERATOR_TOKENS, API_TOKENS is not set in environment variable, so every authenticated request will be rejected")
+		return ac.apiTokens
+	}
+
+	if viper.GetString("OPERATOR_TOKENS") != "" {
+		log.Println("OPERATOR_TOKENS is deprecated, please move tokens into API_TOKENS with operator role (format: name:operator:token)")
+	}
+	for _, pair := range splitCredentials(viper.GetString("OPERATOR_TOKENS")) {
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			log.Fatalf("invalid format of OPERATOR_TOKENS, pair: %s, please set with format name:token,name:token", pair)
+		}
+		ac.apiTokens = append(ac.apiTokens, domain.Credential{Name: kv[0], Role: domain.RoleOperator, Secret: kv[1]})
+	}
+	ac.apiTokens = append(ac.apiTokens, parseCredentials("API_TOKENS")...)
+	return ac.apiTokens
+}
+
+// HMACKeys return HMAC key credentials used for verifying signed request from environment variable
+// HMAC_KEYS is set with format name:role:key,name:role:key, and name is sent with signature in Authorization header
+func (ac *appConfig) HMACKeys() []domain.Credential {
+	if ac.hmacKeys != nil {
+		return ac.hmacKeys
+	}
+
+	ac.hmacKeys = parseCredentials("HMAC_KEYS")
+	return ac.hmacKeys
+}
+
+// AuthAnonymousRead return if read-only endpoint is accessible without credential from config file (default: false)
+func (ac *appConfig) AuthAnonymousRead() bool {
+	if ac.authAnonymousRead != nil {
+		return *ac.authAnonymousRead
+	}
+
+	var key = "auth.anonymousRead"
+	anonymousRead := defaultAuthAnonymousRead
+	if viper.IsSet(key) {
+		anonymousRead = viper.GetBool(key)
+	}
+	ac.authAnonymousRead = &anonymousRead
+	return *ac.authAnonymousRead
+}
+
+// parseCredentials parse credentials in environment variable with format name:role:secret,name:role:secret
+func parseCredentials(env string) (credentials []domain.Credential) {
+	credentials = []domain.Credential{}
+	for _, triple := range splitCredentials(viper.GetString(env)) {
+		v := strings.SplitN(triple, ":", 3)
+		if len(v) != 3 || v[0] == "" || v[2] == "" {
+			log.Fatalf("invalid format of %s, value: %s, please set with format name:role:secret,name:role:secret", env, triple)
+		}
+		switch v[1] {
+		case domain.RoleRead, domain.RoleTrigger, domain.RoleOperator:
+		default:
+			log.Fatalf("invalid role in %s, role: %s, please set one of read, trigger, operator", env, v[1])
+		}
+		credentials = append(credentials, domain.Credential{Name: v[0], Role: v[1], Secret: v[2]})
+	}
+	return
+}
+
+// splitCredentials split credentials with comma & return not empty credential with space trimmed
+func splitCredentials(value string) (split []string) {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			split = append(split, v)
+		}
+	}
+	return
+}
+
+// Timezone return IANA timezone used for current time of every package from config file (default: Asia/Seoul)
+func (ac *appConfig) Timezone() *time.Location {
+	if ac.timezone != nil {
+		return ac.timezone
+	}
+
+	var key = "timezone"
+	name := defaultTimezone
+	if viper.IsSet(key) {
+		name = viper.GetString(key)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Fatalf("invalid timezone in %s, timezone: %s, err: %v", key, name, err)
+	}
+	ac.timezone = loc
+	return ac.timezone
+}
+
+// MaintenanceTimezone return timezone of maintenance window from config file (default: Timezone)
+func (ac *appConfig) MaintenanceTimezone() *time.Location {
+	if ac.maintenanceTimezone != nil {
+		return ac.maintenanceTimezone
+	}
+
+	var key = "maintenance.timezone"
+	if !viper.IsSet(key) {
+		ac.maintenanceTimezone = ac.Timezone()
+		return ac.maintenanceTimezone
+	}
+
+	name := viper.GetString(key)
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Fatalf("invalid timezone in %s, timezone: %s, err: %v", key, name, err)
+	}
+	ac.maintenanceTimezone = loc
+	return ac.maintenanceTimezone
+}
+
+// MaintenanceWindows return maintenance windows declared in config file, operator of windows is set to config
+func (ac *appConfig) MaintenanceWindows() (windows []domain.MaintenanceWindow) {
+	var key = "maintenance.windows"
+	if err := viper.UnmarshalKey(key, &windows); err != nil {
+		log.Fatalf("invalid maintenance windows in %s, err: %v", key, err)
+	}
+
+	for i := range windows {
+		windows[i].Operator = "config"
+	}
+	return
+}
+
+// GRPCPort return port number of gRPC server from config file (default: 8889)
+func (ac *appConfig) GRPCPort() int {
+	if ac.grpcPort != nil {
+		return *ac.grpcPort
+	}
+
+	var key = "grpc.port"
+	port := defaultGRPCPort
+	if viper.IsSet(key) {
+		port = viper.GetInt(key)
+	}
+	ac.grpcPort = &port
+	return *ac.grpcPort
+}
+
+// GRPCAdvertiseAddress return address of this host registered in consul from environment variable
+// if not set, first non-loopback IPv4 address of network interfaces is used
+func (ac *appConfig) GRPCAdvertiseAddress() string {
+	if ac.grpcAdvertiseAddress != nil {
+		return *ac.grpcAdvertiseAddress
+	}
+
+	if addr := viper.GetString("GRPC_ADVERTISE_ADDRESS"); addr != "" {
+		ac.grpcAdvertiseAddress = _string(addr)
+		return *ac.grpcAdvertiseAddress
+	}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		log.Fatalf("unable to get interface address, please set GRPC_ADVERTISE_ADDRESS in environment variable, err: %v", err)
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.IP.To4() != nil {
+			ac.grpcAdvertiseAddress = _string(ipNet.IP.String())
+			return *ac.grpcAdvertiseAddress
+		}
+	}
+
+	log.Fatal("unable to find interface address, please set GRPC_ADVERTISE_ADDRESS in environment variable")
+	return ""
+}
+
+// GRPCServiceName return service name of gRPC server registered in consul from config file
+func (ac *appConfig) GRPCServiceName() string {
+	if ac.grpcServiceName != nil {
+		return *ac.grpcServiceName
+	}
+
+	var key = "grpc.consul.serviceName"
+	if !viper.IsSet(key) {
+		viper.Set(key, defaultGRPCServiceName)
+	}
+	ac.grpcServiceName = _string(viper.GetString(key))
+	return *ac.grpcServiceName
+}
+
+// GRPCConsulRegister return if gRPC server is registered in consul from config file (default: true)
+func (ac *appConfig) GRPCConsulRegister() bool {
+	if ac.grpcConsulRegister != nil {
+		return *ac.grpcConsulRegister
+	}
+
+	var key = "grpc.consul.register"
+	register := defaultGRPCConsulRegister
+	if viper.IsSet(key) {
+		register = viper.GetBool(key)
+	}
+	ac.grpcConsulRegister = &register
+	return *ac.grpcConsulRegister
+}
+
+// NotifySlackEnabled return if alarm is sent to slack notifier backend from config file (default: true)
+func (ac *appConfig) NotifySlackEnabled() bool {
+	if ac.notifySlackEnabled != nil {
+		return *ac.notifySlackEnabled
+	}
+
+	var key = "notify.slack.enabled"
+	enabled := defaultNotifySlackEnabled
+	if viper.IsSet(key) {
+		enabled = viper.GetBool(key)
+	}
+	ac.notifySlackEnabled = &enabled
+	return *ac.notifySlackEnabled
+}
+
+// NotifySlackKibanaURL return URL template of kibana deep link rendered in slack alarm message from config file
+// {domain}, {type}, {level} and {uuid} in template is replaced with value of alarm, link is not rendered if not set
+func (ac *appConfig) NotifySlackKibanaURL() string {
+	if ac.notifySlackKibanaURL != nil {
+		return *ac.notifySlackKibanaURL
+	}
+
+	ac.notifySlackKibanaURL = _string(viper.GetString("notify.slack.kibanaURL"))
+	return *ac.notifySlackKibanaURL
+}
+
+// NotifyWebhookURL return URL of generic JSON webhook notifier backend from environment variable
+// webhook notifier backend is disabled if NOTIFY_WEBHOOK_URL is not set
+func (ac *appConfig) NotifyWebhookURL() string {
+	if ac.notifyWebhookURL != nil {
+		return *ac.notifyWebhookURL
+	}
+
+	ac.notifyWebhookURL = _string(viper.GetString("NOTIFY_WEBHOOK_URL"))
+	return *ac.notifyWebhookURL
+}
+
+// NotifyDiscordURL return URL of discord webhook notifier backend from environment variable
+// discord notifier backend is disabled if DISCORD_WEBHOOK_URL is not set
+func (ac *appConfig) NotifyDiscordURL() string {
+	if ac.notifyDiscordURL != nil {
+		return *ac.notifyDiscordURL
+	}
+
+	ac.notifyDiscordURL = _string(viper.GetString("DISCORD_WEBHOOK_URL"))
+	return *ac.notifyDiscordURL
+}
+
+// NotifySMTPAddress return address of SMTP server with port from config file (Ex, smtp.gmail.com:587)
+// email notifier backend is disabled if notify.smtp.address is not set
+func (ac *appConfig) NotifySMTPAddress() string {
+	if ac.notifySMTPAddress != nil {
+		return *ac.notifySMTPAddress
+	}
+
+	ac.notifySMTPAddress = _string(viper.GetString("notify.smtp.address"))
+	return *ac.notifySMTPAddress
+}
+
+// NotifySMTPUsername return username used for authenticating to SMTP server from environment variable
+func (ac *appConfig) NotifySMTPUsername() string {
+	return viper.GetString("SMTP_USERNAME")
+}
+
+// NotifySMTPPassword return password used for authenticating to SMTP server from environment variable
+func (ac *appConfig) NotifySMTPPassword() string {
+	return viper.GetString("SMTP_PASSWORD")
+}
+
+// NotifySMTPFrom return email address of sender in email notifier backend from config file
+func (ac *appConfig) NotifySMTPFrom() string {
+	if ac.notifySMTPFrom != nil {
+		return *ac.notifySMTPFrom
+	}
+
+	var key = "notify.smtp.from"
+	if !viper.IsSet(key) && ac.NotifySMTPAddress() != "" {
+		log.Fatalf("please set %s in config file to send alarm as email", key)
+	}
+	ac.notifySMTPFrom = _string(viper.GetString(key))
+	return *ac.notifySMTPFrom
+}
+
+// NotifySMTPTo return email address list of recipients in email notifier backend from config file
+func (ac *appConfig) NotifySMTPTo() []string {
+	if ac.notifySMTPTo != nil {
+		return ac.notifySMTPTo
+	}
+
+	var key = "notify.smtp.to"
+	ac.notifySMTPTo = viper.GetStringSlice(key)
+	if len(ac.notifySMTPTo) == 0 && ac.NotifySMTPAddress() != "" {
+		log.Fatalf("please set %s in config file to send alarm as email", key)
+	}
+	return ac.notifySMTPTo
+}
+
+// NotifyFilter return filter of notifier backend received from parameter (Ex, slack, smtp) from config file
+// every alarm is sent to backend if filter is not set
+func (ac *appConfig) NotifyFilter(backend string) (filter domain.NotifyFilter) {
+	var key = "notify." + backend + ".filter"
+	if err := viper.UnmarshalKey(key, &filter); err != nil {
+		log.Fatalf("invalid notifier filter in %s, err: %v", key, err)
+	}
+	return
+}
+
+// NotifyRoutes return routing rules sending alarm matched with filter to channel of backend from config file
+// alarm is sent to every matched route in addition to backends whose filter is matched, no route if not set
+func (ac *appConfig) NotifyRoutes() (routes []domain.NotifyRoute) {
+	var key = "notify.routes"
+	if err := viper.UnmarshalKey(key, &routes); err != nil {
+		log.Fatalf("invalid notifier routes in %s, err: %v", key, err)
+	}
+	return
+}
+
+// NotifyPolicy return notification policy applied per check before alarm is fanned out from config file
+// each rule is disabled if duration or count of that is set to zero (Ex, rateLimit: 0)
+func (ac *appConfig) NotifyPolicy() domain.NotifyPolicy {
+	if ac.notifyPolicy != nil {
+		return *ac.notifyPolicy
+	}
+
+	ac.notifyPolicy = &domain.NotifyPolicy{
+		DedupWindow:     getDuration("notify.policy.dedupWindow", defaultNotifyDedupWindow),
+		RateLimit:       getInt("notify.policy.rateLimit", defaultNotifyRateLimit),
+		RateLimitWindow: getDuration("notify.policy.rateLimitWindow", defaultNotifyRateLimitWindow),
+		FlapThreshold:   getInt("notify.policy.flapThreshold", defaultNotifyFlapThreshold),
+		FlapWindow:      getDuration("notify.policy.flapWindow", defaultNotifyFlapWindow),
+		ExemptLevels:    defaultNotifyExemptLevels,
+	}
+	if viper.IsSet("notify.policy.exemptLevels") {
+		ac.notifyPolicy.ExemptLevels = viper.GetStringSlice("notify.policy.exemptLevels")
+	}
+	return *ac.notifyPolicy
+}
+
+// getDuration return duration of key from config file, or default value received from parameter if not set
+func getDuration(key string, _default time.Duration) time.Duration {
+	if !viper.IsSet(key) {
+		return _default
+	}
+
+	d, err := time.ParseDuration(viper.GetString(key))
+	if err != nil {
+		log.Fatalf("invalid duration in %s, value: %s, err: %v", key, viper.GetString(key), err)
+	}
+	return d
+}
+
+// getInt return int of key from config file, or default value received from parameter if not set
+func getInt(key string, _default int) int {
+	if !viper.IsSet(key) {
+		return _default
+	}
+	return viper.GetInt(key)
+}
+
 // return docker client version as literal
 func (ac *appConfig) DockerCliVer() string {
 	return "1.40"
@@ -204,6 +642,32 @@ func (ac *appConfig) AWSRegion() string {
 	return *ac.awsRegion
 }
 
+// default const value used for timezone config
+const defaultTimezone = "Asia/Seoul"
+
+// default const value used for auth config
+const defaultAuthAnonymousRead = false
+
+// default const value used for gRPC server config
+const (
+	defaultGRPCPort           = 8889                              // default const int for grpcPort
+	defaultGRPCServiceName    = "DMS.SMS.v1.service.health-check" // default const string for grpcServiceName
+	defaultGRPCConsulRegister = true                              // default const bool for grpcConsulRegister
+)
+
+// default const value used for notifier backend config
+const (
+	defaultNotifySlackEnabled    = true             // default const bool for notifySlackEnabled
+	defaultNotifyDedupWindow     = time.Minute * 10 // default const duration for DedupWindow of notifyPolicy
+	defaultNotifyRateLimit       = 20               // default const int for RateLimit of notifyPolicy
+	defaultNotifyRateLimitWindow = time.Hour        // default const duration for RateLimitWindow of notifyPolicy
+	defaultNotifyFlapThreshold   = 5                // default const int for FlapThreshold of notifyPolicy
+	defaultNotifyFlapWindow      = time.Minute * 30 // default const duration for FlapWindow of notifyPolicy
+)
+
+// default value about ExemptLevels field of notifyPolicy, which is process level of alarm operator should know anytime
+var defaultNotifyExemptLevels = []string{"UNHEALTHY", "ESCALATED", "RECOVERED"}
+
 func init() {
 	App = &appConfig{}
 }
@@ -0,0 +1,130 @@
+// Create file in v.1.1.0
+// validate.go is file that define method of appConfig validating config value from environment variable or config file
+// unlike getter of appConfig, process is not exited with first invalid value but every problem is reported with key path
+
+package config
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"strings"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// requiredEnvs is list of environment variable which must be set to start process
+var requiredEnvs = []string{
+	"ES_ADDRESS", "CONSUL_ADDRESS", "CONFIG_FILE", "SLACK_API_TOKEN", "SLACK_CHAT_CHANNEL", "VERSION",
+	"SMS_AWS_ID", "SMS_AWS_KEY", "SMS_AWS_REGION", "SMS_AWS_BUCKET",
+}
+
+// Validate method validate every config value used in app(main) package in v and return all of problems at once
+// cross-field condition is also validated (Ex, backend of notifier route must be enabled), value not set is valid
+func (ac *appConfig) Validate(v *viper.Viper) (errs domain.ConfigErrors) {
+	fail := func(key, format string, args ...interface{}) {
+		errs = append(errs, domain.ConfigError{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, env := range requiredEnvs {
+		if !v.IsSet(env) {
+			fail(env, "must be set in environment variable")
+		}
+	}
+
+	// OPERATOR_TOKENS is deprecated, but is validated while it is still read as operator role tokens
+	for _, pair := range splitCredentials(v.GetString("OPERATOR_TOKENS")) {
+		if kv := strings.SplitN(pair, ":", 2); len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			fail("OPERATOR_TOKENS", "must be set with format name:token,name:token, pair: %s", pair)
+		}
+	}
+	for _, env := range []string{"API_TOKENS", "HMAC_KEYS"} {
+		for _, triple := range splitCredentials(v.GetString(env)) {
+			switch s := strings.SplitN(triple, ":", 3); {
+			case len(s) != 3 || s[0] == "" || s[2] == "":
+				fail(env, "must be set with format name:role:secret,name:role:secret, value: %s", triple)
+			case s[1] != domain.RoleRead && s[1] != domain.RoleTrigger && s[1] != domain.RoleOperator:
+				fail(env, "role must be one of read, trigger, operator, role: %s", s[1])
+			}
+		}
+	}
+
+	for _, key := range []string{"auth.anonymousRead", "grpc.consul.register", "notify.slack.enabled", "dryRun"} {
+		if _, ok := v.Get(key).(bool); v.IsSet(key) && !ok {
+			fail(key, "must be true or false, value: %v", v.Get(key))
+		}
+	}
+
+	for _, key := range []string{"timezone", "maintenance.timezone"} {
+		if !v.IsSet(key) {
+			continue
+		}
+		if _, err := time.LoadLocation(v.GetString(key)); err != nil {
+			fail(key, "must be IANA timezone (Ex, Asia/Seoul), value: %s", v.GetString(key))
+		}
+	}
+
+	if err := v.UnmarshalKey("maintenance.windows", &[]domain.MaintenanceWindow{}); err != nil {
+		fail("maintenance.windows", "invalid format, %s", err.Error())
+	}
+
+	if port, ok := v.Get("grpc.port").(int); v.IsSet("grpc.port") && (!ok || port <= 0 || port > 65535) {
+		fail("grpc.port", "must be port number between 1 and 65535, value: %v", v.Get("grpc.port"))
+	}
+
+	if v.GetString("notify.smtp.address") != "" {
+		if !v.IsSet("notify.smtp.from") {
+			fail("notify.smtp.from", "must be set to send alarm as email if notify.smtp.address is set")
+		}
+		if len(v.GetStringSlice("notify.smtp.to")) == 0 {
+			fail("notify.smtp.to", "must be set to send alarm as email if notify.smtp.address is set")
+		}
+	}
+
+	enabled := map[string]bool{
+		"slack":   !v.IsSet("notify.slack.enabled") || v.GetBool("notify.slack.enabled"),
+		"webhook": v.GetString("NOTIFY_WEBHOOK_URL") != "",
+		"discord": v.GetString("DISCORD_WEBHOOK_URL") != "",
+		"smtp":    v.GetString("notify.smtp.address") != "",
+	}
+	for _, backend := range []string{"slack", "webhook", "discord", "smtp"} {
+		key := "notify." + backend + ".filter"
+		if err := v.UnmarshalKey(key, &domain.NotifyFilter{}); err != nil {
+			fail(key, "invalid format, %s", err.Error())
+		}
+	}
+
+	var routes []domain.NotifyRoute
+	if err := v.UnmarshalKey("notify.routes", &routes); err != nil {
+		fail("notify.routes", "invalid format, %s", err.Error())
+	}
+	for i, route := range routes {
+		if route.Backend == "" {
+			route.Backend = "slack"
+		}
+		if !enabled[route.Backend] {
+			fail(fmt.Sprintf("notify.routes[%d].backend", i), "must be enabled notifier backend, backend: %s", route.Backend)
+		}
+	}
+
+	for _, key := range []string{"notify.policy.dedupWindow", "notify.policy.rateLimitWindow", "notify.policy.flapWindow"} {
+		if !v.IsSet(key) {
+			continue
+		}
+		if d, err := time.ParseDuration(v.GetString(key)); err != nil || d < 0 {
+			fail(key, "must be duration (Ex, 10m) not less than zero, value: %v", v.Get(key))
+		}
+	}
+	for _, key := range []string{"notify.policy.rateLimit", "notify.policy.flapThreshold"} {
+		if i, ok := v.Get(key).(int); v.IsSet(key) && (!ok || i < 0) {
+			fail(key, "must be integer not less than zero, value: %v", v.Get(key))
+		}
+	}
+	if key := "notify.policy.exemptLevels"; v.IsSet(key) {
+		if _, ok := v.Get(key).([]interface{}); !ok {
+			fail(key, "must be list of process level (Ex, [UNHEALTHY, RECOVERED]), value: %v", v.Get(key))
+		}
+	}
+
+	return
+}
@@ -6,12 +6,16 @@ package main
 import (
 	// import Go SDK package
 	"context"
+	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
 	"time"
+	_ "time/tzdata" // embed IANA timezone database, because it isn't in alpine image
 
 	// import external package
 	"github.com/aws/aws-sdk-go/aws"
@@ -20,18 +24,32 @@ import (
 	"github.com/docker/docker/client"
 	es "github.com/elastic/go-elasticsearch/v7"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/hashicorp/consul/api"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 
 	// import app config & various agent package
 	"github.com/DMS-SMS/v1-health-check/app/config"
+	"github.com/DMS-SMS/v1-health-check/auth"
+	"github.com/DMS-SMS/v1-health-check/broker"
+	"github.com/DMS-SMS/v1-health-check/clock"
 	"github.com/DMS-SMS/v1-health-check/consul"
 	"github.com/DMS-SMS/v1-health-check/docker"
+	"github.com/DMS-SMS/v1-health-check/domain"
 	"github.com/DMS-SMS/v1-health-check/elasticsearch"
+	"github.com/DMS-SMS/v1-health-check/esindex"
+	"github.com/DMS-SMS/v1-health-check/executor"
 	"github.com/DMS-SMS/v1-health-check/grpc"
 	"github.com/DMS-SMS/v1-health-check/json"
+	"github.com/DMS-SMS/v1-health-check/maintenance"
+	"github.com/DMS-SMS/v1-health-check/notify"
 	"github.com/DMS-SMS/v1-health-check/profiler"
+	"github.com/DMS-SMS/v1-health-check/prometheus"
+	"github.com/DMS-SMS/v1-health-check/scheduler"
 	"github.com/DMS-SMS/v1-health-check/slack"
 	"github.com/DMS-SMS/v1-health-check/system"
 
@@ -42,12 +60,29 @@ import (
 	_syscheckRepo "github.com/DMS-SMS/v1-health-check/syscheck/repository/elasticsearch"
 	_syscheckUcase "github.com/DMS-SMS/v1-health-check/syscheck/usecase"
 
+	// import control package about every check usecase
+	_controlGrpcDelivery "github.com/DMS-SMS/v1-health-check/control/delivery/grpc"
+	_controlHttpDelivery "github.com/DMS-SMS/v1-health-check/control/delivery/http"
+
 	// import service check domain package
 	_srvcheckConfig "github.com/DMS-SMS/v1-health-check/srvcheck/config"
 	_srvcheckChanDelivery "github.com/DMS-SMS/v1-health-check/srvcheck/delivery/channel"
 	_srvcheckHttpDelivery "github.com/DMS-SMS/v1-health-check/srvcheck/delivery/http"
 	_srvcheckRepo "github.com/DMS-SMS/v1-health-check/srvcheck/repository/elasticsearch"
 	_srvcheckUcase "github.com/DMS-SMS/v1-health-check/srvcheck/usecase"
+
+	// import report domain package about check history of every domain
+	_reportConfig "github.com/DMS-SMS/v1-health-check/report/config"
+	_reportChanDelivery "github.com/DMS-SMS/v1-health-check/report/delivery/channel"
+	_reportHttpDelivery "github.com/DMS-SMS/v1-health-check/report/delivery/http"
+	_reportRepo "github.com/DMS-SMS/v1-health-check/report/repository/elasticsearch"
+	_reportUcase "github.com/DMS-SMS/v1-health-check/report/usecase"
+
+	// import incident domain package about incident opened in check usecase of every domain
+	_incidentConfig "github.com/DMS-SMS/v1-health-check/incident/config"
+	_incidentHttpDelivery "github.com/DMS-SMS/v1-health-check/incident/delivery/http"
+	_incidentRepo "github.com/DMS-SMS/v1-health-check/incident/repository/elasticsearch"
+	_incidentUcase "github.com/DMS-SMS/v1-health-check/incident/usecase"
 )
 
 func init() {
@@ -63,6 +98,16 @@ func init() {
 }
 
 func main() {
+	// validate-config command only validate config with same checks as below and exit without starting process
+	if len(os.Args) > 1 && os.Args[1] == validateConfigCommand {
+		runValidateConfig()
+	}
+
+	// refuse to start if any of config value is invalid, instead of falling back to default value silently
+	if errs := validateConfig(); len(errs) > 0 {
+		log.Fatal(errors.Wrap(errs, "failed to validate config, please fix every problem below"))
+	}
+
 	// add elasticsearch API connection
 	esCli, err := es.NewClient(es.Config{
 		Addresses: []string{config.App.ESAddress()},
@@ -101,8 +146,11 @@ func main() {
 	wg := &sync.WaitGroup{}
 	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), "WaitGroup", wg))
 
+	// add clock returning current time in configured timezone, which is injected to every package using current time
+	_clk := clock.New(config.App.Timezone())
+
 	// start profiling with goroutine until stop process
-	prof := profiler.New(awsSess, wg, config.App)
+	prof := profiler.New(awsSess, wg, config.App, _clk)
 	go func(profileFunc func()) {
 		for {
 			if ctx.Err() == context.Canceled {
@@ -112,55 +160,207 @@ func main() {
 		}
 	}(prof.StartProfiling)
 
-	// add docker, system, slack, elasticsearch agent
+	// add docker, system, slack, elasticsearch, consul, gRPC, prometheus, broker, auth, maintenance, scheduler, notify agent
 	_dkr := docker.NewAgent(dkrCli)
 	_sys := system.NewAgent(dkrCli)
-	_slk := slack.NewAgent(config.App.SlackAPIToken(), config.App.SlackChatChannel())
+	_slk := slack.NewAgent(config.App.SlackAPIToken(), config.App.SlackChatChannel(), config.App.SlackSigningSecret(), config.App.NotifySlackKibanaURL(), _clk)
 	_es := elasticsearch.NewAgent(esCli)
 	_csl := consul.NewAgent(cslCli)
 	_rpc := grpc.NewGRPCAgent()
+	_prom := prometheus.NewAgent()
+	_brk := broker.NewAgent(_prom)
+	_auth := auth.NewAgent(config.App.APITokens(), config.App.HMACKeys(), config.App.AuthAnonymousRead())
+	_mnt := maintenance.NewAgent(clock.New(config.App.MaintenanceTimezone()))
+	_sch := scheduler.NewAgent(_clk)
+	_ntf := notify.NewAgent(config.App.NotifyPolicy(), _clk)
 
-	// about syscheck domain
-	// syscheck domain repository
-	sdr := _syscheckRepo.NewESDiskCheckHistoryRepository(_syscheckConfig.App, esCli, json.MapWriter())
-	scr := _syscheckRepo.NewESCPUCheckHistoryRepository(_syscheckConfig.App, esCli, json.MapWriter())
-	smr := _syscheckRepo.NewESMemoryCheckHistoryRepository(_syscheckConfig.App, esCli, json.MapWriter())
+	// add notifier backends enabled in config, alarm of check process is fanned out to every backend matched with filter
+	if config.App.NotifySlackEnabled() {
+		_ntf.AddBackend("slack", config.App.NotifyFilter("slack"), _slk)
+	}
+	if url := config.App.NotifyWebhookURL(); url != "" {
+		_ntf.AddBackend("webhook", config.App.NotifyFilter("webhook"), notify.NewWebhookSender(url, _clk))
+	}
+	if url := config.App.NotifyDiscordURL(); url != "" {
+		_ntf.AddBackend("discord", config.App.NotifyFilter("discord"), notify.NewDiscordSender(url, _clk))
+	}
+	if addr := config.App.NotifySMTPAddress(); addr != "" {
+		sender := notify.NewSMTPSender(addr, config.App.NotifySMTPUsername(), config.App.NotifySMTPPassword(), config.App.NotifySMTPFrom(), config.App.NotifySMTPTo(), _clk)
+		_ntf.AddBackend("smtp", config.App.NotifyFilter("smtp"), sender)
+	}
+	for _, route := range config.App.NotifyRoutes() {
+		if err := _ntf.AddRoute(route); err != nil {
+			log.Fatal(errors.Wrap(err, "failed to add notifier route declared in config file"))
+		}
+	}
+
+	// add maintenance windows declared in config file
+	for _, window := range config.App.MaintenanceWindows() {
+		if _, err := _mnt.AddWindow(window); err != nil {
+			log.Fatal(errors.Wrap(err, "failed to add maintenance window declared in config file"))
+		}
+	}
 
-	// syscheck domain usecase
-	sdu := _syscheckUcase.NewDiskCheckUsecase(_syscheckConfig.App, sdr, _slk, _sys)
-	scu := _syscheckUcase.NewCPUCheckUsecase(_syscheckConfig.App, scr, _slk, _sys, _dkr)
-	smu := _syscheckUcase.NewMemoryCheckUsecase(_syscheckConfig.App, smr, _slk, _sys, _dkr)
+	// about incident domain
+	// incident domain repository is injected to check usecase of every domain, which open & close incident
+	ir := _incidentRepo.NewESIncidentRepository(_incidentConfig.App, esCli)
+	iu := _incidentUcase.NewIncidentUsecase(_incidentConfig.App, ir)
 
-	// syscheck domain delivery
+	// controllers is every check usecase of syscheck, srvcheck domain, which is injected to control delivery
+	var controllers []domain.CheckController
+
+	// about syscheck domain
+	// every check instance declared in config file has own repository, usecase & scheduler, and disabled one is skipped
+	var (
+		sdus []domain.DiskCheckUseCase
+		scus []domain.CPUCheckUseCase
+		smus []domain.MemoryCheckUseCase
+	)
 	_syscheckChanDelivery.SetGlobalContext(ctx)
-	_syscheckChanDelivery.NewDiskCheckHandler(time.Tick(_syscheckConfig.App.DiskCheckDeliveryPingCycle()), sdu)
-	_syscheckChanDelivery.NewCPUCheckHandler(time.Tick(_syscheckConfig.App.CPUCheckDeliveryPingCycle()), scu)
-	_syscheckChanDelivery.NewMemoryCheckHandler(time.Tick(_syscheckConfig.App.MemoryCheckDeliveryPingCycle()), smu)
+	sim := esindex.New(_syscheckConfig.App, esCli, json.MapWriter())
+	for _, ci := range _syscheckConfig.App.CheckInstances() {
+		if !ci.Enabled() {
+			log.Printf("CHECK INSTANCE IS DISABLED IN CONFIG FILE, DOMAIN: syscheck, NAME: %s", ci.Name())
+			continue
+		}
 
-	// about srvcheck domain
-	// srvcheck domain repository
-	ser := _srvcheckRepo.NewESElasticsearchCheckHistoryRepository(_srvcheckConfig.App, esCli, json.MapWriter())
-	ssr := _srvcheckRepo.NewESSwarmpitCheckHistoryRepository(_srvcheckConfig.App, esCli, json.MapWriter())
-	scsr := _srvcheckRepo.NewESConsulCheckHistoryRepository(_srvcheckConfig.App, esCli, json.MapWriter())
+		// syscheck domain delivery, check instance is rescheduled if delivery schedule of that is changed by reload
+		sc := mustSchedule(_sch.NewScheduler(ctx, "syscheck", ci.CheckType(), ci.DeliverySchedule(), _syscheckConfig.App.DeliveryInitialRun()))
+		addScheduledCheck("syscheck", ci.CheckType(), ci.DeliverySchedule)
 
-	// srvcheck domain usecase
-	seu := _srvcheckUcase.NewElasticsearchCheckUsecase(_srvcheckConfig.App, ser, _slk, _es)
-	ssu := _srvcheckUcase.NewSwarmpitCheckUsecase(_srvcheckConfig.App, ssr, _slk, _dkr)
-	scsu := _srvcheckUcase.NewConsulCheckUsecase(_srvcheckConfig.App, scsr, _slk, _csl, _rpc, _dkr)
+		// syscheck domain repository & usecase, check process of usecase is run in own executor with execution config
+		ex := executor.New(ci)
+		switch ci.Kind() {
+		case "disk":
+			sdr := _syscheckRepo.NewESDiskCheckHistoryRepository(sim, esCli, json.MapWriter())
+			sdu := _syscheckUcase.NewDiskCheckUsecase(ci, sdr, ir, _brk, _mnt, _ntf, _clk, ex, _sys)
+			_syscheckChanDelivery.NewDiskCheckHandler(sc, sdu)
+			sdus, controllers = append(sdus, sdu), append(controllers, sdu)
+		case "cpu":
+			scr := _syscheckRepo.NewESCPUCheckHistoryRepository(sim, esCli, json.MapWriter())
+			scu := _syscheckUcase.NewCPUCheckUsecase(ci, scr, ir, _brk, _mnt, _ntf, _clk, ex, _sys, _dkr)
+			_syscheckChanDelivery.NewCPUCheckHandler(sc, scu)
+			scus, controllers = append(scus, scu), append(controllers, scu)
+		case "memory":
+			smr := _syscheckRepo.NewESMemoryCheckHistoryRepository(sim, esCli, json.MapWriter())
+			smu := _syscheckUcase.NewMemoryCheckUsecase(ci, smr, ir, _brk, _mnt, _ntf, _clk, ex, _sys, _dkr)
+			_syscheckChanDelivery.NewMemoryCheckHandler(sc, smu)
+			smus, controllers = append(smus, smu), append(controllers, smu)
+		}
+	}
 
-	// srvcheck domain delivery
+	// about srvcheck domain
+	// every check instance declared in config file has own repository, usecase & scheduler, and disabled one is skipped
+	var (
+		seus  []domain.ElasticsearchCheckUseCase
+		ssus  []domain.SwarmpitCheckUseCase
+		scsus []domain.ConsulCheckUseCase
+	)
 	_srvcheckChanDelivery.SetGlobalContext(ctx)
-	_srvcheckChanDelivery.NewElasticsearchCheckHandler(time.Tick(_srvcheckConfig.App.ESCheckDeliveryPingCycle()), seu)
-	_srvcheckChanDelivery.NewSwarmpitCheckHandler(time.Tick(_srvcheckConfig.App.SwarmpitCheckDeliveryPingCycle()), ssu)
-	_srvcheckChanDelivery.NewConsulCheckHandler(time.Tick(_srvcheckConfig.App.ConsulCheckDeliveryPingCycle()), scsu)
+	ssm := esindex.New(_srvcheckConfig.App, esCli, json.MapWriter())
+	for _, ci := range _srvcheckConfig.App.CheckInstances() {
+		if !ci.Enabled() {
+			log.Printf("CHECK INSTANCE IS DISABLED IN CONFIG FILE, DOMAIN: srvcheck, NAME: %s", ci.Name())
+			continue
+		}
+
+		// srvcheck domain delivery, check instance is rescheduled if delivery schedule of that is changed by reload
+		sc := mustSchedule(_sch.NewScheduler(ctx, "srvcheck", ci.CheckType(), ci.DeliverySchedule(), _srvcheckConfig.App.DeliveryInitialRun()))
+		addScheduledCheck("srvcheck", ci.CheckType(), ci.DeliverySchedule)
+
+		// srvcheck domain repository & usecase, check process of usecase is run in own executor with execution config
+		ex := executor.New(ci)
+		switch ci.Kind() {
+		case "elasticsearch":
+			// elasticsearch check instance having own address check another cluster from cluster storing history
+			esAgent := _es
+			if addr := ci.ESAddress(); addr != "" {
+				cli, err := es.NewClient(es.Config{Addresses: []string{addr}})
+				if err != nil {
+					log.Fatal(errors.Wrapf(err, "failed to create elasticsearch client of check instance, name: %s", ci.Name()))
+				}
+				esAgent = elasticsearch.NewAgent(cli)
+			}
+			ser := _srvcheckRepo.NewESElasticsearchCheckHistoryRepository(ssm, esCli, json.MapWriter())
+			seu := _srvcheckUcase.NewElasticsearchCheckUsecase(ci, ser, ir, _brk, _mnt, _ntf, _clk, ex, esAgent)
+			_srvcheckChanDelivery.NewElasticsearchCheckHandler(sc, seu)
+			seus, controllers = append(seus, seu), append(controllers, seu)
+		case "swarmpit":
+			ssr := _srvcheckRepo.NewESSwarmpitCheckHistoryRepository(ssm, esCli, json.MapWriter())
+			ssu := _srvcheckUcase.NewSwarmpitCheckUsecase(ci, ssr, ir, _brk, _mnt, _ntf, _clk, ex, _dkr)
+			_srvcheckChanDelivery.NewSwarmpitCheckHandler(sc, ssu)
+			ssus, controllers = append(ssus, ssu), append(controllers, ssu)
+		case "consul":
+			scsr := _srvcheckRepo.NewESConsulCheckHistoryRepository(ssm, esCli, json.MapWriter())
+			scsu := _srvcheckUcase.NewConsulCheckUsecase(ci, scsr, ir, _brk, _mnt, _ntf, _clk, ex, _csl, _rpc, _dkr)
+			_srvcheckChanDelivery.NewConsulCheckHandler(sc, scsu)
+			scsus, controllers = append(scsus, scsu), append(controllers, scsu)
+		}
+	}
+
+	// index of syscheck & srvcheck domain is maintained out of storing history, after being migrated in repository
+	go sim.Maintain(ctx)
+	go ssm.Maintain(ctx)
+
+	// about report domain
+	// report domain repository & usecase
+	rr := _reportRepo.NewESReportRepository(_reportConfig.App, esCli)
+	ru := _reportUcase.NewReportUsecase(_reportConfig.App, rr, _slk, _clk)
+
+	// report domain delivery
+	rdc := mustSchedule(_sch.NewScheduler(ctx, "report", "DailyReport", _reportConfig.App.DailyDeliverySchedule(), false))
+	rwc := mustSchedule(_sch.NewScheduler(ctx, "report", "WeeklyReport", _reportConfig.App.WeeklyDeliverySchedule(), false))
+	_reportChanDelivery.SetGlobalContext(ctx)
+	_reportChanDelivery.NewReportHandler(rdc, rwc, ru)
+
+	// reload threshold, target list & delivery schedule of check domain whenever config file is changed
+	watchConfig(ctx, _sch, _slk)
 
 	// expose usecase method to HTTP API
 	r := gin.Default()
-	_syscheckHttpDelivery.NewSyscheckHandler(r, sdu, scu, smu)
-	_srvcheckHttpDelivery.NewSrvcheckHandler(r, scsu, seu, ssu)
+	_syscheckHttpDelivery.NewSyscheckHandler(r, _auth, sdus, scus, smus)
+	_srvcheckHttpDelivery.NewSrvcheckHandler(r, _auth, scsus, seus, ssus)
+	_reportHttpDelivery.NewReportHandler(r, _auth, ru)
+	_incidentHttpDelivery.NewIncidentHandler(r, _auth, iu)
+	_controlHttpDelivery.NewStatusHandler(r, _auth, _sch, statusReporters(controllers)...)
+	_controlHttpDelivery.NewControlHandler(r, _auth, controllers...)
+	_controlHttpDelivery.NewMaintenanceHandler(r, _auth, _mnt)
+	_controlHttpDelivery.NewScheduleHandler(r, _auth, _sch)
+	_controlHttpDelivery.NewStreamHandler(r, _auth, _brk)
+	_controlHttpDelivery.NewSlackCommandHandler(ctx, r, _slk, _mnt, sdus, scus, smus, seus, ssus, scsus)
+
+	// expose metrics recorded from check history to prometheus
+	r.GET("metrics", _auth.AuthenticateReader(), gin.WrapH(_prom.Handler()))
 
 	gin.SetMode(gin.ReleaseMode)
-	go func() { _ = r.Run(":8888") }()
+	// derive request context from global context so that shutdown cancels checks triggered by http request
+	srv := &http.Server{Addr: ":8888", Handler: r, BaseContext: func(net.Listener) context.Context { return ctx }}
+	go func() { _ = srv.ListenAndServe() }()
+
+	// expose usecase method to gRPC API with health server used in consul gRPC health check
+	// every method is authenticated with bearer token in metadata & role declared in gRPC delivery, same as HTTP API
+	gs := gogrpc.NewServer(
+		gogrpc.UnaryInterceptor(_auth.UnaryInterceptor(_controlGrpcDelivery.MethodRoles)),
+		gogrpc.StreamInterceptor(_auth.StreamInterceptor(_controlGrpcDelivery.MethodRoles)),
+	)
+	_controlGrpcDelivery.NewHealthCheckHandler(gs, _sch, _brk, sdus, scus, smus, seus, ssus, scsus)
+	grpc_health_v1.RegisterHealthServer(gs, health.NewServer())
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.App.GRPCPort()))
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to listen gRPC port"))
+	}
+	go func() { _ = gs.Serve(lis) }()
+
+	// register gRPC server in consul so that other services can find HealthCheck service
+	grpcID := fmt.Sprintf("%s-%s", config.App.GRPCServiceName(), uuid.New().String())
+	if config.App.GRPCConsulRegister() {
+		regCtx, regCancel := context.WithTimeout(ctx, time.Second*5)
+		err := _csl.RegisterInstance(regCtx, grpcID, config.App.GRPCServiceName(), config.App.GRPCAdvertiseAddress(), config.App.GRPCPort())
+		if err != nil {
+			log.Println(errors.Wrap(err, "failed to register gRPC server in consul, gRPC API is served without registration"))
+		}
+		regCancel()
+	}
 
 	// handle signal to graceful shutdown
 	sigs := make(chan os.Signal, 1)
@@ -171,9 +371,37 @@ func main() {
 	cancel()
 	log.Println("CANCEL DELIVERY CONTEXT & WAIT TO ALL HANDLING GROUP DONE!")
 
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer shutdownCancel()
+	_ = srv.Shutdown(shutdownCtx)
+	log.Println("SHUTDOWN HTTP SERVER & CANCEL ALL CHECKS TRIGGERED BY HTTP REQUEST!")
+
+	if config.App.GRPCConsulRegister() {
+		_ = _csl.DeregisterInstance(shutdownCtx, grpcID)
+	}
+	gs.Stop()
+	log.Println("DEREGISTER & STOP GRPC SERVER, CANCEL ALL STREAMS & CHECKS TRIGGERED BY GRPC REQUEST!")
+
 	prof.StopProfiling(s)
 	log.Println("STOP PROFILING & SAVE PROFILE RESULT TO AWS S3!")
 
 	wg.Wait()
 	log.Println("ALL HANDLING GROUP WAS DONE! SUCCEED TO GRACEFUL SHUTDOWN.")
 }
+
+// mustSchedule return scheduler received from parameter, or exit process if error occurs while creating scheduler
+func mustSchedule(s interface{ C() <-chan time.Time }, err error) interface{ C() <-chan time.Time } {
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to create check scheduler"))
+	}
+	return s
+}
+
+// statusReporters return controllers received from parameter as slice of domain.CheckStatusReporter
+func statusReporters(controllers []domain.CheckController) []domain.CheckStatusReporter {
+	reporters := make([]domain.CheckStatusReporter, len(controllers))
+	for i, cc := range controllers {
+		reporters[i] = cc
+	}
+	return reporters
+}
@@ -0,0 +1,140 @@
+// Create file in v.1.1.0
+// reload.go is file that define function reloading config of check domain whenever config file is changed
+// execution, threshold, target list, delivery schedule & params of check instance are reloaded without restart, and every
+// change is logged & announced to slack, change of check instance list is also announced but applied after restart
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	goslack "github.com/slack-go/slack"
+	"github.com/spf13/viper"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+	_srvcheckConfig "github.com/DMS-SMS/v1-health-check/srvcheck/config"
+	_syscheckConfig "github.com/DMS-SMS/v1-health-check/syscheck/config"
+)
+
+// configScheduler is interface that validate & apply schedule spec reloaded from config file to scheduler of check
+// you can see implementation in scheduler package
+type configScheduler interface {
+	// ValidateSpec method return error if schedule spec is invalid
+	ValidateSpec(spec string) error
+
+	// Reschedule method change schedule of check with domain & type to spec, return changed schedule
+	Reschedule(_domain, _type, spec string) (domain.CheckSchedule, error)
+}
+
+// messageSender is interface that send message announcing result of reload to slack
+// you can see implementation in slack package
+type messageSender interface {
+	// SendMessage method send message with text & emoji and return send time & text & error
+	SendMessage(ctx context.Context, emoji, text, uuid string, opts ...goslack.MsgOption) (time.Time, string, error)
+}
+
+// scheduledCheck is struct having domain & type of check and getter of delivery schedule reloaded at runtime
+type scheduledCheck struct {
+	// domain, _type specifies domain & check type of scheduled check
+	domain, _type string
+
+	// schedule is getter of delivery schedule which check is scheduled with (Ex, DeliverySchedule method of check instance)
+	schedule func() string
+
+	// spec specifies delivery schedule spec which check is scheduled with now
+	spec string
+}
+
+// scheduledChecks is list of every scheduled check, which is rescheduled if delivery schedule is changed by reload
+// it is filled with addScheduledCheck function while check instances are created in main function
+var scheduledChecks []*scheduledCheck
+
+// addScheduledCheck add domain & type of check scheduled with delivery schedule returned from schedule getter
+func addScheduledCheck(_domain, _type string, schedule func() string) {
+	scheduledChecks = append(scheduledChecks, &scheduledCheck{domain: _domain, _type: _type, schedule: schedule, spec: schedule()})
+}
+
+// watchConfig start watching config file & reload config of check domain whenever that is changed
+func watchConfig(ctx context.Context, cs configScheduler, ms messageSender) {
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		if ctx.Err() != nil {
+			return
+		}
+		reloadConfig(ctx, e.Name, cs, ms)
+	})
+	viper.WatchConfig()
+}
+
+// reloadConfig read config file again & reload config of syscheck, srvcheck domain and schedule of changed check
+// every value is validated before any of them is applied, so reload is rejected with old values kept if one is invalid
+func reloadConfig(ctx context.Context, file string, cs configScheduler, ms messageSender) {
+	// read config file with new viper instance, so that default value set in global viper doesn't shadow changed value
+	v := viper.New()
+	v.AutomaticEnv()
+	v.SetConfigFile(file)
+
+	var sysCommit, srvCommit func() []domain.ConfigChange
+	err := v.ReadInConfig()
+	if err == nil {
+		sysCommit, err = _syscheckConfig.App.Reload(v, cs)
+	}
+	if err == nil {
+		srvCommit, err = _srvcheckConfig.App.Reload(v, cs)
+	}
+	if err != nil {
+		err = errors.Wrap(err, "failed to reload config file, old config values are kept")
+		log.Println(err)
+		announceReload(ctx, ms, "warning", err.Error())
+		return
+	}
+
+	changes := append(sysCommit(), srvCommit()...)
+	if len(changes) == 0 {
+		log.Printf("config file is reloaded, but any of reloadable config value is not changed, file: %s", file)
+		return
+	}
+
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		log.Printf("config value is changed by reloading config file, key: %s, old: %s, new: %s, restart required: %t", change.Key, change.Old, change.New, change.RestartRequired)
+		line := fmt.Sprintf("• `%s`: %s -> %s", change.Key, change.Old, change.New)
+		if change.RestartRequired {
+			line += " (restart required)"
+		}
+		lines = append(lines, line)
+	}
+
+	// schedule of check is read again after commit, because check instance follow own schedule or schedule of kind
+	for _, check := range scheduledChecks {
+		spec := check.schedule()
+		if spec == check.spec {
+			continue
+		}
+
+		if _, err := cs.Reschedule(check.domain, check._type, spec); err != nil {
+			err = errors.Wrapf(err, "failed to reschedule check with reloaded schedule, domain: %s, type: %s", check.domain, check._type)
+			log.Println(err)
+			lines = append(lines, fmt.Sprintf("• %s", err.Error()))
+			continue
+		}
+		check.spec = spec
+	}
+
+	announceReload(ctx, ms, "gear", fmt.Sprintf("config file is reloaded, %d value(s) changed\n%s", len(changes), strings.Join(lines, "\n")))
+}
+
+// announceReload send message about result of reload to slack, log if error occurs while sending
+func announceReload(ctx context.Context, ms messageSender, emoji, text string) {
+	sendCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	if _, _, err := ms.SendMessage(sendCtx, emoji, text, uuid.New().String()); err != nil {
+		log.Println(errors.Wrap(err, "failed to announce result of reloading config file to slack"))
+	}
+}
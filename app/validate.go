@@ -0,0 +1,44 @@
+// Create file in v.1.1.0
+// validate.go is file that define function validating config value of app, syscheck & srvcheck before process is started
+// same validation is run with validate-config command, which only report problems of config without starting process
+
+package main
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"os"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/app/config"
+	"github.com/DMS-SMS/v1-health-check/clock"
+	"github.com/DMS-SMS/v1-health-check/domain"
+	"github.com/DMS-SMS/v1-health-check/scheduler"
+	_srvcheckConfig "github.com/DMS-SMS/v1-health-check/srvcheck/config"
+	_syscheckConfig "github.com/DMS-SMS/v1-health-check/syscheck/config"
+)
+
+// validateConfigCommand is name of command validating config & exiting without starting process
+const validateConfigCommand = "validate-config"
+
+// validateConfig validate config value of app, syscheck & srvcheck domain read in viper and return every problem at once
+func validateConfig() (errs domain.ConfigErrors) {
+	// schedule spec is validated regardless of timezone, so scheduler agent with UTC clock is enough to validate spec
+	sv := scheduler.NewAgent(clock.New(time.UTC))
+
+	errs = append(errs, config.App.Validate(viper.GetViper())...)
+	errs = append(errs, _syscheckConfig.App.Validate(viper.GetViper(), sv)...)
+	errs = append(errs, _srvcheckConfig.App.Validate(viper.GetViper(), sv)...)
+	return
+}
+
+// runValidateConfig print result of validateConfig and exit process with status 1 if any of config value is invalid
+func runValidateConfig() {
+	if errs := validateConfig(); len(errs) > 0 {
+		fmt.Fprintln(os.Stderr, errs.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("every config value is valid, file: %s\n", viper.ConfigFileUsed())
+	os.Exit(0)
+}
@@ -0,0 +1,48 @@
+// Create package in v.1.1.0
+// auth package define struct which is implement various interface about authentication using in delivery layer
+// there are kind of authentication such as bearer token, HMAC signed request, etc.
+
+// in agent.go file, define struct type of auth agent & initializer that are not method.
+// Also if exist, custom type or variable used in common in each of method will declared in this file.
+
+package auth
+
+import (
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// operatorKey is key of gin context to set name of authenticated credential owner
+const operatorKey = "auth.operator"
+
+// roleLevel represent level of each role, credential can access endpoint requiring role whose level is lower or same
+var roleLevel = map[string]int{
+	domain.RoleRead:     1,
+	domain.RoleTrigger:  2,
+	domain.RoleOperator: 3,
+}
+
+// authAgent is struct that agent authentication of HTTP request with bearer token or HMAC signature
+type authAgent struct {
+	// tokens is bearer token credentials, injected from outside package
+	tokens []domain.Credential
+
+	// hmacKeys is HMAC key credential per name, injected from outside package
+	hmacKeys map[string]domain.Credential
+
+	// anonymousRead represent if read-only endpoint is accessible without credential, which is disabled by default
+	anonymousRead bool
+}
+
+// NewAgent return new initialized instance of authAgent pointer type with bearer token & HMAC key credentials
+func NewAgent(tokens, hmacKeys []domain.Credential, anonymousRead bool) *authAgent {
+	aa := &authAgent{
+		tokens:        tokens,
+		hmacKeys:      map[string]domain.Credential{},
+		anonymousRead: anonymousRead,
+	}
+
+	for _, key := range hmacKeys {
+		aa.hmacKeys[key.Name] = key
+	}
+	return aa
+}
@@ -0,0 +1,156 @@
+// Create file in v.1.1.0
+// agent_authenticate.go file define method of authAgent about authentication of HTTP request
+// implement authenticator interface defined in http delivery of each domain & control
+
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// const value used for authenticating HMAC signed request
+const (
+	hmacScheme       = "HMAC "         // scheme of Authorization header in HMAC signed request (Ex, HMAC name:signature)
+	bearerScheme     = "Bearer "       // scheme of Authorization header in bearer token request
+	timestampHeader  = "X-Timestamp"   // header having unix time when request was signed
+	maxTimestampSkew = time.Minute * 5 // request signed before or after this duration is rejected (prevent replay)
+	maxSignedBody    = 1 << 20         // max bytes of body read for verifying signature, larger request is rejected
+	anonymousName    = "anonymous"     // name of operator set if read request is allowed to anonymous
+)
+
+// errNoCredential is error returned from authenticate method if credential is not in request
+var errNoCredential = errors.New("credential is not in request")
+
+// AuthenticateReader return gin middleware which authenticate request to read-only endpoint
+// request without credential is allowed if anonymous read is enabled
+func (aa *authAgent) AuthenticateReader() gin.HandlerFunc {
+	return aa.requireRole(domain.RoleRead)
+}
+
+// AuthenticateTrigger return gin middleware which authenticate request to endpoint triggering check process
+func (aa *authAgent) AuthenticateTrigger() gin.HandlerFunc {
+	return aa.requireRole(domain.RoleTrigger)
+}
+
+// AuthenticateOperator return gin middleware which authenticate request to endpoint controlling check usecase
+func (aa *authAgent) AuthenticateOperator() gin.HandlerFunc {
+	return aa.requireRole(domain.RoleOperator)
+}
+
+// Operator return name of credential owner authenticated in middleware returned from method of authAgent
+func (aa *authAgent) Operator(c *gin.Context) string {
+	return c.GetString(operatorKey)
+}
+
+// requireRole return gin middleware which abort request with 401 status code if credential is invalid,
+// or with 403 status code if role of credential is lower than role received from parameter
+func (aa *authAgent) requireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credential, err := aa.authenticate(c.Writer, c.Request)
+		switch {
+		case err == errNoCredential && role == domain.RoleRead && aa.anonymousRead:
+			credential = domain.Credential{Name: anonymousName, Role: domain.RoleRead}
+		case err != nil:
+			deny(c, http.StatusUnauthorized, role, err.Error())
+			return
+		case roleLevel[credential.Role] < roleLevel[role]:
+			deny(c, http.StatusForbidden, role, fmt.Sprintf("role of %s is %s", credential.Name, credential.Role))
+			return
+		}
+
+		c.Set(operatorKey, credential.Name)
+		c.Next()
+	}
+}
+
+// authenticate return credential matched with bearer token or HMAC signature in Authorization header of request
+func (aa *authAgent) authenticate(w http.ResponseWriter, r *http.Request) (domain.Credential, error) {
+	header := r.Header.Get("Authorization")
+	switch {
+	case strings.HasPrefix(header, bearerScheme):
+		token := strings.TrimSpace(strings.TrimPrefix(header, bearerScheme))
+		for _, credential := range aa.tokens {
+			if token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(credential.Secret)) == 1 {
+				return credential, nil
+			}
+		}
+		return domain.Credential{}, errors.New("bearer token is not matched with any credential")
+	case strings.HasPrefix(header, hmacScheme):
+		return aa.verifySignature(w, r, strings.TrimSpace(strings.TrimPrefix(header, hmacScheme)))
+	default:
+		return domain.Credential{}, errNoCredential
+	}
+}
+
+// verifySignature verify HMAC-SHA256 signature of request & restore body so that handler can read that again
+// signature is hex encoded HMAC of "timestamp\nmethod\nrequest uri\nbody" with key of name in Authorization header
+// body is read through http.MaxBytesReader, so request having body larger than maxSignedBody is rejected before hashing
+func (aa *authAgent) verifySignature(w http.ResponseWriter, r *http.Request, value string) (domain.Credential, error) {
+	nameSig := strings.SplitN(value, ":", 2)
+	if len(nameSig) != 2 {
+		return domain.Credential{}, errors.New("invalid format of HMAC authorization, please set with format HMAC name:signature")
+	}
+
+	credential, ok := aa.hmacKeys[nameSig[0]]
+	if !ok {
+		return domain.Credential{}, errors.Errorf("HMAC key is not exist, name: %s", nameSig[0])
+	}
+
+	timestamp := r.Header.Get(timestampHeader)
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return domain.Credential{}, errors.Errorf("invalid %s header, value: %s", timestampHeader, timestamp)
+	}
+	if skew := time.Since(time.Unix(unix, 0)); skew > maxTimestampSkew || skew < -maxTimestampSkew {
+		return domain.Credential{}, errors.Errorf("request is signed too long ago or later, skew: %s", skew)
+	}
+
+	var body []byte
+	if r.Body != nil {
+		if body, err = ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxSignedBody)); err != nil {
+			return domain.Credential{}, errors.Wrapf(err, "failed to read request body within %d bytes", maxSignedBody)
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	signature, err := hex.DecodeString(nameSig[1])
+	if err != nil {
+		return domain.Credential{}, errors.Wrap(err, "failed to decode signature as hex")
+	}
+	if !hmac.Equal(signature, sign(credential.Secret, timestamp, r.Method, r.URL.RequestURI(), body)) {
+		return domain.Credential{}, errors.Errorf("signature is not matched, name: %s", credential.Name)
+	}
+	return credential, nil
+}
+
+// sign return HMAC-SHA256 of "timestamp\nmethod\nrequest uri\nbody" with key, used for signing or verifying request
+func sign(key, timestamp, method, requestURI string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	_, _ = fmt.Fprintf(mac, "%s\n%s\n%s\n", timestamp, method, requestURI)
+	_, _ = mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// deny abort request with status code & log denied request with reason
+func deny(c *gin.Context, code int, role, reason string) {
+	log.Printf("request denied, status: %d, method: %s, path: %s, client: %s, required role: %s, reason: %s",
+		code, c.Request.Method, c.Request.URL.Path, c.ClientIP(), role, reason)
+	c.AbortWithStatusJSON(code, gin.H{
+		"status": code, "code": 0, "message": fmt.Sprintf("%s role is required to access this endpoint", role),
+	})
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// testSecret is secret of HMAC key credential named ci used in test
+const testSecret = "hmac-secret"
+
+func newTestAgent() *authAgent {
+	return NewAgent(
+		[]domain.Credential{{Name: "viewer", Secret: "read-token", Role: domain.RoleRead}},
+		[]domain.Credential{{Name: "ci", Secret: testSecret, Role: domain.RoleTrigger}},
+		false,
+	)
+}
+
+// newSignedRequest return request signed with HMAC key of name & secret at the time, body is signed as it is
+func newSignedRequest(name, secret string, signedAt time.Time, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/syscheck/cpu/run?dryRun=true", strings.NewReader(body))
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	signature := hex.EncodeToString(sign(secret, timestamp, r.Method, r.URL.RequestURI(), []byte(body)))
+	r.Header.Set(timestampHeader, timestamp)
+	r.Header.Set("Authorization", hmacScheme+name+":"+signature)
+	return r
+}
+
+func TestVerifySignature(t *testing.T) {
+	aa := newTestAgent()
+	r := newSignedRequest("ci", testSecret, time.Now(), `{"reason":"deploy"}`)
+
+	credential, err := aa.authenticate(httptest.NewRecorder(), r)
+	if err != nil {
+		t.Fatalf("unexpected error, err: %v", err)
+	}
+	if credential.Name != "ci" || credential.Role != domain.RoleTrigger {
+		t.Fatalf("credential name, role = %s, %s, want ci, %s", credential.Name, credential.Role, domain.RoleTrigger)
+	}
+
+	// body is restored after verifying signature, so that handler can read that again
+	if body, _ := ioutil.ReadAll(r.Body); string(body) != `{"reason":"deploy"}` {
+		t.Fatalf("body read in handler = %q, want original body", body)
+	}
+}
+
+func TestVerifySignatureRejected(t *testing.T) {
+	aa := newTestAgent()
+	for name, r := range map[string]*http.Request{
+		"wrong secret":    newSignedRequest("ci", "wrong-secret", time.Now(), ""),
+		"unknown key":     newSignedRequest("unknown", testSecret, time.Now(), ""),
+		"signed too long": newSignedRequest("ci", testSecret, time.Now().Add(-maxTimestampSkew-time.Minute), ""),
+		"signed later":    newSignedRequest("ci", testSecret, time.Now().Add(maxTimestampSkew+time.Minute), ""),
+		"tampered body": func() *http.Request {
+			r := newSignedRequest("ci", testSecret, time.Now(), `{"reason":"deploy"}`)
+			r.Body = ioutil.NopCloser(strings.NewReader(`{"reason":"tampered"}`))
+			return r
+		}(),
+		"tampered uri": func() *http.Request {
+			r := newSignedRequest("ci", testSecret, time.Now(), "")
+			r.URL.RawQuery = "dryRun=false"
+			return r
+		}(),
+		"invalid format": func() *http.Request {
+			r := newSignedRequest("ci", testSecret, time.Now(), "")
+			r.Header.Set("Authorization", hmacScheme+"ci")
+			return r
+		}(),
+		"invalid timestamp": func() *http.Request {
+			r := newSignedRequest("ci", testSecret, time.Now(), "")
+			r.Header.Set(timestampHeader, "yesterday")
+			return r
+		}(),
+		"too large body": newSignedRequest("ci", testSecret, time.Now(), string(bytes.Repeat([]byte("a"), maxSignedBody+1))),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if credential, err := aa.authenticate(httptest.NewRecorder(), r); err == nil {
+				t.Fatalf("request is authenticated as %s, want rejected", credential.Name)
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	aa := newTestAgent()
+	router := gin.New()
+	router.POST("/syscheck/cpu/run", aa.AuthenticateTrigger(), func(c *gin.Context) {
+		c.String(http.StatusOK, aa.Operator(c))
+	})
+
+	for name, tc := range map[string]struct {
+		request *http.Request
+		code    int
+	}{
+		"signed request":  {newSignedRequest("ci", testSecret, time.Now(), ""), http.StatusOK},
+		"wrong signature": {newSignedRequest("ci", "wrong-secret", time.Now(), ""), http.StatusUnauthorized},
+		"lower role": {func() *http.Request {
+			r := httptest.NewRequest(http.MethodPost, "/syscheck/cpu/run", nil)
+			r.Header.Set("Authorization", bearerScheme+"read-token")
+			return r
+		}(), http.StatusForbidden},
+		"no credential": {httptest.NewRequest(http.MethodPost, "/syscheck/cpu/run", nil), http.StatusUnauthorized},
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tc.request)
+			if w.Code != tc.code {
+				t.Fatalf("status code = %d, want %d", w.Code, tc.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Create file in v.1.1.0
+// agent_grpc.go file define method of authAgent about authentication of gRPC request with bearer token in metadata
+// role required for each gRPC method is received from gRPC delivery, and method not declared there is denied
+
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"log"
+	"strings"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// UnaryInterceptor return gRPC unary interceptor which authenticate request with role of method in methodRoles
+// method whose role is empty string is public (Ex, gRPC health service used in consul health check)
+func (aa *authAgent) UnaryInterceptor(methodRoles map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := aa.authorizeRPC(ctx, info.FullMethod, methodRoles); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor return gRPC stream interceptor which authenticate request with role of method in methodRoles
+// method whose role is empty string is public (Ex, gRPC health service used in consul health check)
+func (aa *authAgent) StreamInterceptor(methodRoles map[string]string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := aa.authorizeRPC(ss.Context(), info.FullMethod, methodRoles); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authorizeRPC return gRPC status error with Unauthenticated code if credential is invalid, or with PermissionDenied
+// code if role of credential is lower than role of method, method not declared in methodRoles is always denied
+func (aa *authAgent) authorizeRPC(ctx context.Context, method string, methodRoles map[string]string) error {
+	role, ok := methodRoles[method]
+	switch {
+	case !ok:
+		return denyRPC(ctx, codes.PermissionDenied, method, "", "role of method is not declared")
+	case role == "":
+		return nil
+	}
+
+	credential, err := aa.authenticateRPC(ctx)
+	switch {
+	case err == errNoCredential && role == domain.RoleRead && aa.anonymousRead:
+		return nil
+	case err != nil:
+		return denyRPC(ctx, codes.Unauthenticated, method, role, err.Error())
+	case roleLevel[credential.Role] < roleLevel[role]:
+		return denyRPC(ctx, codes.PermissionDenied, method, role, "role of "+credential.Name+" is "+credential.Role)
+	}
+	return nil
+}
+
+// authenticateRPC return credential matched with bearer token in authorization metadata of gRPC request
+// HMAC signature isn't supported in gRPC, because there is no request uri & body to sign
+func (aa *authAgent) authenticateRPC(ctx context.Context) (domain.Credential, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || !strings.HasPrefix(values[0], bearerScheme) {
+		return domain.Credential{}, errNoCredential
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(values[0], bearerScheme))
+	for _, credential := range aa.tokens {
+		if token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(credential.Secret)) == 1 {
+			return credential, nil
+		}
+	}
+	return domain.Credential{}, errors.New("bearer token is not matched with any credential")
+}
+
+// denyRPC log denied gRPC request with reason & return gRPC status error with code
+func denyRPC(ctx context.Context, code codes.Code, method, role, reason string) error {
+	client := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		client = p.Addr.String()
+	}
+	log.Printf("gRPC request denied, code: %s, method: %s, client: %s, required role: %s, reason: %s", code, method, client, role, reason)
+
+	if role == "" {
+		return status.Errorf(code, "method is not allowed, method: %s", method)
+	}
+	return status.Errorf(code, "%s role is required to call this method", role)
+}
@@ -0,0 +1,45 @@
+// Create package in v.1.1.0
+// broker package define struct which is implement various interface about check history publishing using in each of domain
+// there are kind of method in broker agency such as observe history, subscribe history, etc ...
+
+// in agent.go file, define struct type of broker agent & initializer that are not method.
+// Also if exist, custom type or variable used in common in each of method will declared in this file.
+
+package broker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// brokerAgent is struct that forward check history to observers & publish that to every subscriber
+type brokerAgent struct {
+	// observers is list of history observer which every check history is forwarded to (Ex, prometheus agent)
+	observers []historyObserver
+
+	// subscribers is channel per subscription ID which check history is published to
+	subscribers map[uint64]chan domain.CheckHistory
+
+	// nextID is subscription ID which is assigned to next subscriber
+	nextID uint64
+
+	// mutex is used for preventing race condition in subscribers
+	mutex sync.RWMutex
+}
+
+// historyObserver is interface that observe check history after check process is finished
+// you can see implementation in prometheus package
+type historyObserver interface {
+	// ObserveHistory observe check history with duration spent in check process
+	ObserveHistory(history domain.CheckHistory, duration time.Duration)
+}
+
+// NewAgent return new instance of brokerAgent pointer type initialized with observers received from parameter
+func NewAgent(observers ...historyObserver) *brokerAgent {
+	return &brokerAgent{
+		observers:   observers,
+		subscribers: map[uint64]chan domain.CheckHistory{},
+	}
+}
@@ -0,0 +1,48 @@
+// Create file in v.1.1.0
+// agent_history.go file define method of brokerAgent about check history
+// implement history observer interface defined in usecase of each domain & history subscriber in delivery
+
+package broker
+
+import (
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// ObserveHistory forward check history to every observer & publish that to every subscriber
+// history is dropped for subscriber whose buffer is full, so that slow subscriber doesn't block check process
+func (ba *brokerAgent) ObserveHistory(history domain.CheckHistory, duration time.Duration) {
+	for _, observer := range ba.observers {
+		observer.ObserveHistory(history, duration)
+	}
+
+	ba.mutex.RLock()
+	defer ba.mutex.RUnlock()
+
+	for _, subscriber := range ba.subscribers {
+		select {
+		case subscriber <- history:
+		default:
+		}
+	}
+}
+
+// Subscribe return channel receiving check history published after subscribing, with buffer size received from parameter
+// returned cancel function must be called to stop subscription if check history is not needed anymore
+func (ba *brokerAgent) Subscribe(buffer int) (histories <-chan domain.CheckHistory, cancel func()) {
+	ba.mutex.Lock()
+	defer ba.mutex.Unlock()
+
+	id := ba.nextID
+	ba.nextID++
+	subscriber := make(chan domain.CheckHistory, buffer)
+	ba.subscribers[id] = subscriber
+
+	cancel = func() {
+		ba.mutex.Lock()
+		defer ba.mutex.Unlock()
+		delete(ba.subscribers, id)
+	}
+	return subscriber, cancel
+}
@@ -0,0 +1,59 @@
+// Create package in v.1.1.0
+// clock package define struct which is implement various interface about current time using in each of package
+// there are kind of clock such as real clock using system time in configured timezone, fake clock advanced manually
+
+// in clock.go file, define struct type of real clock & initializer that are not method.
+// Also if exist, custom type or variable used in common in each of method will declared in this file.
+
+package clock
+
+import (
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// realClock is clock returning system time converted to IANA timezone received from outside (Ex, Asia/Seoul)
+type realClock struct {
+	// location is timezone which current time is converted to
+	location *time.Location
+}
+
+// New return new initialized instance of realClock pointer type with timezone
+func New(loc *time.Location) *realClock {
+	return &realClock{
+		location: loc,
+	}
+}
+
+// Now method returns current system time in timezone of clock
+func (rc *realClock) Now() time.Time {
+	return time.Now().In(rc.location)
+}
+
+// Location method returns timezone of clock, used for parsing time (Ex, cron expression, maintenance window)
+func (rc *realClock) Location() *time.Location {
+	return rc.location
+}
+
+// NewTimer method returns timer firing after duration d with system timer
+func (rc *realClock) NewTimer(d time.Duration) domain.Timer {
+	return realTimer{Timer: time.NewTimer(d)}
+}
+
+// AfterFunc method call f in its own goroutine after duration d with system timer, and returns timer stopping that
+// C method of returned timer returns nil channel, because the time isn't delivered to channel but f is called
+func (rc *realClock) AfterFunc(d time.Duration, f func()) domain.Timer {
+	return realTimer{Timer: time.AfterFunc(d, f)}
+}
+
+// realTimer is timer implementing domain.Timer by embedding system timer
+type realTimer struct {
+	// get Stop method from embedding time.Timer
+	*time.Timer
+}
+
+// C method returns channel of system timer receiving the time when timer is fired
+func (rt realTimer) C() <-chan time.Time {
+	return rt.Timer.C
+}
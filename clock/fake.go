@@ -0,0 +1,137 @@
+// Create file in v.1.1.0
+// fake.go file define fake clock whose current time is changed only manually with Set, Advance method
+// it is used for driving check cycle, maintenance window or schedule deterministically without waiting real time
+
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// fakeClock is clock returning the time set manually, timers created from this clock fire when time is advanced
+type fakeClock struct {
+	// now is current time of fake clock
+	now time.Time
+
+	// timers is list of timer not fired or stopped yet
+	timers []*fakeTimer
+
+	// mutex is used for preventing race condition in now, timers field
+	mutex sync.Mutex
+}
+
+// NewFake return new initialized instance of fakeClock pointer type starting at now, timezone of now is used as location
+func NewFake(now time.Time) *fakeClock {
+	return &fakeClock{
+		now:    now,
+		timers: []*fakeTimer{},
+	}
+}
+
+// Now method returns current time of fake clock
+func (fc *fakeClock) Now() time.Time {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	return fc.now
+}
+
+// Location method returns timezone of time which fake clock is started at
+func (fc *fakeClock) Location() *time.Location {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	return fc.now.Location()
+}
+
+// NewTimer method returns timer firing when fake clock is advanced by d, timer fires immediately if d is not positive
+func (fc *fakeClock) NewTimer(d time.Duration) domain.Timer {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	ft := &fakeTimer{clock: fc, deadline: fc.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		ft.c <- fc.now
+		return ft
+	}
+	fc.timers = append(fc.timers, ft)
+	return ft
+}
+
+// AfterFunc method returns timer calling f when fake clock is advanced by d, f is called in next Set, Advance method
+// f is called synchronously in Set or Advance method, so that effect of f can be checked right after advancing clock
+func (fc *fakeClock) AfterFunc(d time.Duration, f func()) domain.Timer {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	ft := &fakeTimer{clock: fc, deadline: fc.now.Add(d), c: make(chan time.Time, 1), f: f}
+	fc.timers = append(fc.timers, ft)
+	return ft
+}
+
+// Advance method advance current time of fake clock by d & fire every timer whose deadline is reached
+func (fc *fakeClock) Advance(d time.Duration) {
+	fc.Set(fc.Now().Add(d))
+}
+
+// Set method set current time of fake clock to t & fire every timer whose deadline is reached
+// function of timer created with AfterFunc is called in order of deadline after current time is set
+func (fc *fakeClock) Set(t time.Time) {
+	fc.mutex.Lock()
+	fc.now = t
+	var remained, fired []*fakeTimer
+	for _, ft := range fc.timers {
+		if ft.deadline.After(t) {
+			remained = append(remained, ft)
+			continue
+		}
+		fired = append(fired, ft)
+	}
+	fc.timers = remained
+	fc.mutex.Unlock()
+
+	sort.SliceStable(fired, func(i, j int) bool { return fired[i].deadline.Before(fired[j].deadline) })
+	for _, ft := range fired {
+		if ft.f != nil {
+			ft.f()
+			continue
+		}
+		ft.c <- t
+	}
+}
+
+// fakeTimer is timer created from fake clock, which fires when fake clock reach deadline
+type fakeTimer struct {
+	// clock is fake clock created this timer
+	clock *fakeClock
+
+	// deadline is the time when timer fires
+	deadline time.Time
+
+	// c is channel receiving the time when timer is fired
+	c chan time.Time
+
+	// f is function called when timer is fired instead of sending the time to c, which is set in AfterFunc method
+	f func()
+}
+
+// C method returns channel receiving the time when timer is fired
+func (ft *fakeTimer) C() <-chan time.Time {
+	return ft.c
+}
+
+// Stop method prevent timer from firing, return false if timer was already fired or stopped
+func (ft *fakeTimer) Stop() bool {
+	ft.clock.mutex.Lock()
+	defer ft.clock.mutex.Unlock()
+
+	for i, timer := range ft.clock.timers {
+		if timer == ft {
+			ft.clock.timers = append(ft.clock.timers[:i], ft.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,6 @@
 // Create package in v.1.0.0
 // consul package define struct which is implement various interface about consul agency using in each of domain
-// there are kind of method in consul agency such as get services, deregister service, etc ...
+// there are kind of method in consul agency such as get services, register & deregister service, etc ...
 
 // in agent.go file, define struct type of consul agent & initializer that are not method.
 // Also if exist, custom type or variable used in common in each of method will declared in this file.
@@ -8,7 +8,9 @@
 package consul
 
 import (
+	"context"
 	"github.com/hashicorp/consul/api"
+	"github.com/pkg/errors"
 )
 
 // consulAgent is struct that agent various command about consul including get services, deregister service, etc ...
@@ -23,3 +25,17 @@ func NewAgent(cc *api.Client) *consulAgent {
 		cslCli: cc,
 	}
 }
+
+// callWithContext call function in goroutine & return error returned from that, or ctx error if ctx is done first
+// agent API in consul client doesn't accept context, so call is not stopped but caller doesn't wait for that
+func callWithContext(ctx context.Context, call func() error) error {
+	errC := make(chan error, 1)
+	go func() { errC <- call() }()
+
+	select {
+	case err := <-errC:
+		return err
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "context is done while calling consul API")
+	}
+}
@@ -5,16 +5,22 @@
 package consul
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/consul/api"
 	"github.com/pkg/errors"
 )
 
 // GetServices method get services in consul & return services interface implement
-func (ca *consulAgent) GetServices(srv string) (interface {
+func (ca *consulAgent) GetServices(ctx context.Context, srv string) (interface {
 	HasNext() bool           // HasNext method return if srvIter has next element
 	Next() (id, addr string) // Next method return next service id, address
 }, error) {
-	srvs, err := ca.cslCli.Agent().ServicesWithFilter(fmt.Sprintf("Service==%s", srv))
+	var srvs map[string]*api.AgentService
+	err := callWithContext(ctx, func() (err error) {
+		srvs, err = ca.cslCli.Agent().ServicesWithFilter(fmt.Sprintf("Service==%s", srv))
+		return
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get all services in consul")
 	}
@@ -37,8 +43,9 @@ func (ca *consulAgent) GetServices(srv string) (interface {
 }
 
 // DeregisterInstance method deregister instance in consul with received id
-func (ca *consulAgent) DeregisterInstance(id string) (err error) {
-	return errors.Wrap(ca.cslCli.Agent().ServiceDeregister(id), "failed to deregister consul service")
+func (ca *consulAgent) DeregisterInstance(ctx context.Context, id string) (err error) {
+	err = callWithContext(ctx, func() error { return ca.cslCli.Agent().ServiceDeregister(id) })
+	return errors.Wrap(err, "failed to deregister consul service")
 }
 
 // services is map binding type having id list per services, and implement GetAllServices return type interface
@@ -59,3 +66,23 @@ func (si *srvIter) Next() (id, addr string) {
 	si.x++
 	return
 }
+
+// RegisterInstance method register instance in consul with received id, name, address & port
+// instance is checked by consul with gRPC health checking protocol, and deregistered if critical state lasts
+func (ca *consulAgent) RegisterInstance(ctx context.Context, id, name, address string, port int) (err error) {
+	registration := &api.AgentServiceRegistration{
+		ID:      id,
+		Name:    name,
+		Address: address,
+		Port:    port,
+		Check: &api.AgentServiceCheck{
+			GRPC:                           fmt.Sprintf("%s:%d", address, port),
+			Interval:                       "10s",
+			Timeout:                        "5s",
+			DeregisterCriticalServiceAfter: "1m",
+		},
+	}
+
+	err = callWithContext(ctx, func() error { return ca.cslCli.Agent().ServiceRegister(registration) })
+	return errors.Wrap(err, "failed to register consul service")
+}
@@ -0,0 +1,255 @@
+// Create package in v.1.1.0
+// grpc package is delivery layer to trigger & inspect every check usecase in syscheck, srvcheck domain with gRPC API
+// other DMS-SMS services can ask about platform health with HealthCheck service registered in consul
+
+// health_check_handler.go is file that define gRPC handler implementing HealthCheckServer declared in proto package
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+	"github.com/DMS-SMS/v1-health-check/proto"
+)
+
+// historyBufferSize is buffer size of channel receiving check history in each stream
+const historyBufferSize = 10
+
+// stateSeverity represent severity of each check state, used for deciding the worst state
+var stateSeverity = map[string]int{
+	domain.CheckStateHealthy:    0,
+	domain.CheckStateWarning:    1,
+	domain.CheckStateRecovering: 2,
+	domain.CheckStateUnhealthy:  3,
+}
+
+// componentKeys is key list of dotted map which is common in every check history, so is excluded from values
+var componentKeys = []string{
+	"version", "agent", "@timestamp", "domain", "type", "kind", "uuid", "process_level", "message", "error",
+	"alerted", "alarm_text", "alarm_time", "alarm_error", "operator", "operation_reason", "alarm_results",
+}
+
+// MethodRoles is role required to call each gRPC method served in process, which is used in auth interceptor
+// check process may run remediation such as removing container, so TriggerCheck needs trigger role
+// gRPC health service is public, because consul calls that in health check without credential
+var MethodRoles = map[string]string{
+	"/DMS.SMS.v1.healthCheck.HealthCheck/TriggerCheck":    domain.RoleTrigger,
+	"/DMS.SMS.v1.healthCheck.HealthCheck/GetStatus":       domain.RoleRead,
+	"/DMS.SMS.v1.healthCheck.HealthCheck/StreamHistories": domain.RoleRead,
+	"/grpc.health.v1.Health/Check":                        "",
+	"/grpc.health.v1.Health/Watch":                        "",
+}
+
+// healthCheckHandler represent the gRPC handler for HealthCheck service
+type healthCheckHandler struct {
+	scheduleReporter  scheduleReporter
+	historySubscriber historySubscriber
+	checks            []checkUsecase
+}
+
+// scheduleReporter is interface that report schedule & next run time of every check process
+// you can see implementation in scheduler package
+type scheduleReporter interface {
+	// Schedules return schedule & next run time of every check process
+	Schedules() []domain.CheckSchedule
+}
+
+// historySubscriber is interface that subscribe check history published after check process is finished
+// you can see implementation in broker package
+type historySubscriber interface {
+	// Subscribe return channel receiving check history & cancel function stopping subscription
+	Subscribe(buffer int) (histories <-chan domain.CheckHistory, cancel func())
+}
+
+// checkUsecase is struct binding status reporter of check usecase with method running check process of that
+type checkUsecase struct {
+	domain.CheckStatusReporter
+	check func(ctx context.Context) error
+}
+
+// NewHealthCheckHandler initialize the resources of every check usecase to HealthCheck service of gRPC server
+func NewHealthCheckHandler(
+	s *grpc.Server,
+	sr scheduleReporter,
+	hs historySubscriber,
+	dus []domain.DiskCheckUseCase,
+	cus []domain.CPUCheckUseCase,
+	mus []domain.MemoryCheckUseCase,
+	eus []domain.ElasticsearchCheckUseCase,
+	sus []domain.SwarmpitCheckUseCase,
+	csus []domain.ConsulCheckUseCase,
+) {
+	h := &healthCheckHandler{
+		scheduleReporter:  sr,
+		historySubscriber: hs,
+	}
+
+	// every check instance of usecase is bound with method running check process of that
+	for _, du := range dus {
+		h.checks = append(h.checks, checkUsecase{du, du.CheckDisk})
+	}
+	for _, cu := range cus {
+		h.checks = append(h.checks, checkUsecase{cu, cu.CheckCPU})
+	}
+	for _, mu := range mus {
+		h.checks = append(h.checks, checkUsecase{mu, mu.CheckMemory})
+	}
+	for _, eu := range eus {
+		h.checks = append(h.checks, checkUsecase{eu, eu.CheckElasticsearch})
+	}
+	for _, su := range sus {
+		h.checks = append(h.checks, checkUsecase{su, su.CheckSwarmpit})
+	}
+	for _, csu := range csus {
+		h.checks = append(h.checks, checkUsecase{csu, csu.CheckConsul})
+	}
+
+	proto.RegisterHealthCheckServer(s, h)
+}
+
+// TriggerCheck method deliver gRPC request to check method of check usecase specified with domain & type
+func (hh *healthCheckHandler) TriggerCheck(ctx context.Context, req *proto.TriggerCheckRequest) (*proto.TriggerCheckResponse, error) {
+	var found []checkUsecase
+	if req.Domain != "" && req.Type != "" {
+		found = hh.findChecks(req.Domain, req.Type)
+	}
+	if len(found) == 0 {
+		return nil, status.Errorf(codes.NotFound, "check is not exist, domain: %s, type: %s", req.Domain, req.Type)
+	}
+
+	if err := found[0].check(ctx); err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to check").Error())
+	}
+	return &proto.TriggerCheckResponse{Status: hh.statusToProto(found[0].Status())}, nil
+}
+
+// StreamHistories method send check history filtered with domain & type to stream until client cancel stream
+func (hh *healthCheckHandler) StreamHistories(req *proto.StreamHistoriesRequest, stream proto.HealthCheck_StreamHistoriesServer) error {
+	histories, cancel := hh.historySubscriber.Subscribe(historyBufferSize)
+	defer cancel()
+
+	for {
+		select {
+		case history := <-histories:
+			if !matchCheck(history.Domain(), history.Type(), req.Domain, req.Type) {
+				continue
+			}
+			if err := stream.Send(historyToProto(history)); err != nil {
+				return errors.Wrap(err, "failed to send check history to stream")
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// GetStatus method respond current status of check usecase filtered with domain & type, and the worst state of them
+func (hh *healthCheckHandler) GetStatus(_ context.Context, req *proto.GetStatusRequest) (*proto.GetStatusResponse, error) {
+	found := hh.findChecks(req.Domain, req.Type)
+	if len(found) == 0 {
+		return nil, status.Errorf(codes.NotFound, "check is not exist, domain: %s, type: %s", req.Domain, req.Type)
+	}
+
+	resp := &proto.GetStatusResponse{State: domain.CheckStateHealthy}
+	for _, check := range found {
+		s := check.Status()
+		if stateSeverity[s.State] > stateSeverity[resp.State] {
+			resp.State = s.State
+		}
+		resp.Checks = append(resp.Checks, hh.statusToProto(s))
+	}
+	return resp, nil
+}
+
+// findChecks method return check usecase matched with domain & type, empty domain or type is matched with every check
+func (hh *healthCheckHandler) findChecks(_domain, _type string) (found []checkUsecase) {
+	for _, check := range hh.checks {
+		s := check.Status()
+		if matchCheck(s.Domain, s.Type, _domain, _type) {
+			found = append(found, check)
+		}
+	}
+	return
+}
+
+// matchCheck return if check domain & type is matched with filter, empty filter is matched with every check
+// type filter is matched with check type without Check suffix ignoring case (Ex, consul -> ConsulCheck)
+func matchCheck(checkDomain, checkType, _domain, _type string) bool {
+	if _domain != "" && checkDomain != _domain {
+		return false
+	}
+	if _type != "" && !strings.EqualFold(strings.TrimSuffix(checkType, "Check"), strings.TrimSuffix(_type, "Check")) {
+		return false
+	}
+	return true
+}
+
+// statusToProto convert domain.CheckStatus to proto.CheckStatus with schedule of that check
+func (hh *healthCheckHandler) statusToProto(s domain.CheckStatus) *proto.CheckStatus {
+	cs := &proto.CheckStatus{
+		Domain:           s.Domain,
+		Type:             s.Type,
+		State:            s.State,
+		StateSince:       timeToProto(s.StateSince),
+		AcknowledgedBy:   s.AcknowledgedBy,
+		LastRunTime:      timeToProto(s.LastRunTime),
+		LastUuid:         s.LastUUID,
+		LastProcessLevel: s.LastProcessLevel,
+		LastValues:       map[string]string{},
+	}
+
+	for key, value := range s.LastValues {
+		cs.LastValues[key] = fmt.Sprint(value)
+	}
+	for _, schedule := range hh.scheduleReporter.Schedules() {
+		if schedule.Domain == s.Domain && schedule.Type == s.Type {
+			cs.Schedule = schedule.Spec
+			cs.NextRunTime = timeToProto(schedule.NextRunTime)
+		}
+	}
+	return cs
+}
+
+// historyToProto convert domain.CheckHistory to proto.CheckHistory, measured values are converted to string
+func historyToProto(history domain.CheckHistory) *proto.CheckHistory {
+	m := history.DottedMapWithPrefix("")
+	ch := &proto.CheckHistory{
+		Domain:    history.Domain(),
+		Type:      history.Type(),
+		Timestamp: timeToProto(history.Timestamp()),
+		Alerted:   history.IsAlerted(),
+		Values:    map[string]string{},
+	}
+
+	ch.Uuid, _ = m["uuid"].(string)
+	ch.ProcessLevel, _ = m["process_level"].(string)
+	ch.Message, _ = m["message"].(string)
+	if err := history.Err(); err != nil {
+		ch.Error = err.Error()
+	}
+
+	for _, key := range componentKeys {
+		delete(m, key)
+	}
+	for key, value := range m {
+		ch.Values[key] = fmt.Sprint(value)
+	}
+	return ch
+}
+
+// timeToProto convert time.Time to timestamppb.Timestamp, return nil if time is zero value
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,131 @@
+// Create file in v.1.1.0
+// control_handler.go is file that define http handler which let operator control check usecase manually
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"strings"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// controlHandler represent the http handler for controlling check usecase by operator
+type controlHandler struct {
+	authenticator operatorAuthenticator
+	controllers   []domain.CheckController
+}
+
+// readAuthenticator is interface that authenticate request to read-only endpoint
+// you can see implementation in auth package
+type readAuthenticator interface {
+	// AuthenticateReader return gin middleware which abort request if credential having read role is not authenticated
+	AuthenticateReader() gin.HandlerFunc
+}
+
+// operatorAuthenticator is interface that authenticate operator who send HTTP request
+// you can see implementation in auth package
+type operatorAuthenticator interface {
+	// get AuthenticateReader method from embedding readAuthenticator
+	readAuthenticator
+
+	// AuthenticateOperator return gin middleware which abort request if operator is not authenticated
+	AuthenticateOperator() gin.HandlerFunc
+
+	// Operator return name of operator authenticated in middleware returned from AuthenticateOperator
+	Operator(c *gin.Context) string
+}
+
+// operationRequest is binding struct of HTTP request body about operation by operator
+type operationRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// NewControlHandler initialize the resources of operation about check usecase to HTTP API endpoint
+// check usecase is specified with domain & type in path (Ex, checks/srvcheck/consul/reset)
+func NewControlHandler(r *gin.Engine, oa operatorAuthenticator, controllers ...domain.CheckController) {
+	h := &controlHandler{
+		authenticator: oa,
+		controllers:   controllers,
+	}
+
+	g := r.Group("checks/:domain/:type", oa.AuthenticateOperator())
+	g.POST("acknowledge", h.Acknowledge)
+	g.POST("reset", h.Reset)
+	g.POST("rerun", h.Rerun)
+}
+
+// Acknowledge method deliver HTTP request to Acknowledge method of domain.CheckController
+func (ch *controlHandler) Acknowledge(c *gin.Context) {
+	ch.operate(c, "acknowledge", domain.CheckController.Acknowledge)
+}
+
+// Reset method deliver HTTP request to Reset method of domain.CheckController
+func (ch *controlHandler) Reset(c *gin.Context) {
+	ch.operate(c, "reset", domain.CheckController.Reset)
+}
+
+// Rerun method deliver HTTP request to Rerun method of domain.CheckController
+func (ch *controlHandler) Rerun(c *gin.Context) {
+	ch.operate(c, "rerun", domain.CheckController.Rerun)
+}
+
+// operationFunc is function type of operation method declared in domain.CheckController
+type operationFunc func(cc domain.CheckController, ctx context.Context, operator, reason string) error
+
+// operate method find check controller matched with path & call operation function with operator, reason
+func (ch *controlHandler) operate(c *gin.Context, name string, operation operationFunc) {
+	controller, ok := ch.findController(c.Param("domain"), c.Param("type"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": http.StatusNotFound, "code": 0,
+			"message": fmt.Sprintf("check is not exist, domain: %s, type: %s", c.Param("domain"), c.Param("type")),
+		})
+		return
+	}
+
+	req := operationRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": http.StatusBadRequest, "code": 0,
+			"message": errors.Wrap(err, "reason of operation must be set in request body").Error(),
+		})
+		return
+	}
+
+	operator := ch.authenticator.Operator(c)
+	switch err := operation(controller, c.Request.Context(), operator, req.Reason); errors.Cause(err) {
+	case nil:
+		c.JSON(http.StatusOK, gin.H{
+			"status": http.StatusOK, "code": 0,
+			"message": fmt.Sprintf("finished to %s check by operator %s", name, operator),
+			"check":   statusToJSON(controller.Status()),
+		})
+	case domain.ErrCheckNotUnhealthy, domain.ErrCheckRecovering:
+		c.JSON(http.StatusConflict, gin.H{
+			"status": http.StatusConflict, "code": 0,
+			"message": errors.Wrapf(err, "unable to %s check", name).Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": http.StatusInternalServerError, "code": 0,
+			"message": errors.Wrapf(err, "failed to %s check", name).Error(),
+		})
+	}
+}
+
+// findController method return check controller whose domain & type is matched with parameter
+// _type is matched with check type without Check suffix ignoring case (Ex, consul -> ConsulCheck)
+func (ch *controlHandler) findController(_domain, _type string) (controller domain.CheckController, ok bool) {
+	for _, cc := range ch.controllers {
+		status := cc.Status()
+		if status.Domain == _domain && strings.EqualFold(strings.TrimSuffix(status.Type, "Check"), _type) {
+			return cc, true
+		}
+	}
+	return
+}
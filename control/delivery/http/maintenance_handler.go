@@ -0,0 +1,93 @@
+// Create file in v.1.1.0
+// maintenance_handler.go is file that define http handler which let operator manage maintenance window
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// maintenanceHandler represent the http handler for maintenance window
+type maintenanceHandler struct {
+	authenticator operatorAuthenticator
+	manager       maintenanceManager
+}
+
+// maintenanceManager is interface that manage maintenance window pausing check or suppressing remediation
+// you can see implementation in maintenance package
+type maintenanceManager interface {
+	// AddWindow parse & add maintenance window, return added window having generated ID
+	AddWindow(mw domain.MaintenanceWindow) (added domain.MaintenanceWindow, err error)
+
+	// RemoveWindow remove maintenance window with id, return error if not exist
+	RemoveWindow(id string) error
+
+	// Windows return every maintenance window which is not expired yet
+	Windows() []domain.MaintenanceWindow
+}
+
+// NewMaintenanceHandler initialize the resources of maintenance window to HTTP API endpoint
+func NewMaintenanceHandler(r *gin.Engine, oa operatorAuthenticator, mm maintenanceManager) {
+	h := &maintenanceHandler{
+		authenticator: oa,
+		manager:       mm,
+	}
+
+	r.GET("maintenance/windows", oa.AuthenticateReader(), h.GetWindows)
+	r.POST("maintenance/windows", oa.AuthenticateOperator(), h.AddWindow)
+	r.DELETE("maintenance/windows/:id", oa.AuthenticateOperator(), h.RemoveWindow)
+}
+
+// GetWindows method respond every maintenance window which is not expired yet
+func (mh *maintenanceHandler) GetWindows(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": http.StatusOK, "code": 0, "message": "succeed to get maintenance windows",
+		"windows": mh.manager.Windows(),
+	})
+}
+
+// AddWindow method add maintenance window received from request body with authenticated operator
+func (mh *maintenanceHandler) AddWindow(c *gin.Context) {
+	mw := domain.MaintenanceWindow{}
+	if err := c.ShouldBindJSON(&mw); err != nil || mw.Reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": http.StatusBadRequest, "code": 0, "message": "maintenance window with reason must be set in request body",
+		})
+		return
+	}
+	mw.ID = ""
+	mw.Operator = mh.authenticator.Operator(c)
+
+	added, err := mh.manager.AddWindow(mw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": http.StatusBadRequest, "code": 0,
+			"message": errors.Wrap(err, "failed to add maintenance window").Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": http.StatusCreated, "code": 0, "message": "succeed to add maintenance window",
+		"window": added,
+	})
+}
+
+// RemoveWindow method remove maintenance window with id in path
+func (mh *maintenanceHandler) RemoveWindow(c *gin.Context) {
+	if err := mh.manager.RemoveWindow(c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": http.StatusNotFound, "code": 0,
+			"message": errors.Wrap(err, "failed to remove maintenance window").Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": http.StatusOK, "code": 0, "message": "succeed to remove maintenance window",
+	})
+}
@@ -0,0 +1,99 @@
+// Create file in v.1.1.0
+// schedule_handler.go is file that define http handler which expose & change schedule of every check process
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// scheduleHandler represent the http handler for schedule of check process
+type scheduleHandler struct {
+	manager scheduleManager
+}
+
+// scheduleReporter is interface that report schedule & next run time of every check process
+// you can see implementation in scheduler package
+type scheduleReporter interface {
+	// Schedules return schedule & next run time of every check process
+	Schedules() []domain.CheckSchedule
+}
+
+// scheduleManager is interface that report & change schedule of check process
+// you can see implementation in scheduler package
+type scheduleManager interface {
+	// get Schedules method from embedding scheduleReporter
+	scheduleReporter
+
+	// Reschedule change schedule of check with domain & type to spec, return changed schedule
+	Reschedule(_domain, _type, spec string) (domain.CheckSchedule, error)
+}
+
+// rescheduleRequest is binding struct of HTTP request body about changing schedule
+type rescheduleRequest struct {
+	Spec string `json:"spec" binding:"required"`
+}
+
+// NewScheduleHandler initialize the resources of schedule about check process to HTTP API endpoint
+func NewScheduleHandler(r *gin.Engine, oa operatorAuthenticator, sm scheduleManager) {
+	h := &scheduleHandler{
+		manager: sm,
+	}
+
+	r.GET("schedules", oa.AuthenticateReader(), h.GetSchedules)
+	r.PUT("schedules/:domain/:type", oa.AuthenticateOperator(), h.Reschedule)
+}
+
+// GetSchedules method respond schedule & next run time of every check process
+func (sh *scheduleHandler) GetSchedules(c *gin.Context) {
+	schedules := sh.manager.Schedules()
+	resp := make([]gin.H, len(schedules))
+	for i, schedule := range schedules {
+		resp[i] = scheduleToJSON(schedule)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": http.StatusOK, "code": 0, "message": "succeed to get schedules of every check",
+		"schedules": resp,
+	})
+}
+
+// Reschedule method change schedule of check process with domain & type in path to spec in request body
+func (sh *scheduleHandler) Reschedule(c *gin.Context) {
+	req := rescheduleRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": http.StatusBadRequest, "code": 0,
+			"message": errors.Wrap(err, "schedule spec must be set in request body").Error(),
+		})
+		return
+	}
+
+	schedule, err := sh.manager.Reschedule(c.Param("domain"), c.Param("type"), req.Spec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": http.StatusBadRequest, "code": 0,
+			"message": errors.Wrap(err, "failed to change schedule").Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": http.StatusOK, "code": 0, "message": "succeed to change schedule",
+		"schedule": scheduleToJSON(schedule),
+	})
+}
+
+// scheduleToJSON convert domain.CheckSchedule to JSON object used in response of HTTP API
+func scheduleToJSON(schedule domain.CheckSchedule) gin.H {
+	return gin.H{
+		"domain":        schedule.Domain,
+		"type":          schedule.Type,
+		"spec":          schedule.Spec,
+		"next_run_time": schedule.NextRunTime,
+	}
+}
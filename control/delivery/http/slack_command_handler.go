@@ -0,0 +1,233 @@
+// Create file in v.1.1.0
+// slack_command_handler.go is file that define http handler which let on-call engineer run & inspect check with slack slash command
+// slash command text is parsed as sub command & arguments (Ex, /healthcheck run cpu, /healthcheck pause consul 30m)
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// slackCommandUsage is text responded if sub command is unknown or arguments are not enough
+const slackCommandUsage = "usage of slash command\n" +
+	"• `run <type>` run check process of check type (Ex, run cpu)\n" +
+	"• `status [type]` show current status of every check or check type\n" +
+	"• `pause <type|all> <duration> [reason]` pause check process during duration (Ex, pause consul 30m deploy)\n" +
+	"• `suppress <type|all> <duration> [reason]` suppress remediation of check during duration\n" +
+	"• `ack <type> <reason>` acknowledge unhealthy state of check\n" +
+	"• `reset <type> <reason>` reset state of check to healthy"
+
+// slackCommandHandler represent the http handler for slack slash command
+type slackCommandHandler struct {
+	// globalCtx is context used for running check in background, check is canceled if process is stopped
+	globalCtx context.Context
+
+	agency  slackCommandAgency
+	manager maintenanceManager
+	checks  []checkUsecase
+}
+
+// slackCommandAgency is interface that verify slash command request & send delayed response of that
+// you can see implementation in slack package
+type slackCommandAgency interface {
+	// AuthenticateCommand return gin middleware which abort request if signature of slack request is invalid
+	AuthenticateCommand() gin.HandlerFunc
+
+	// RespondCommand send delayed response of slash command to response url received in request
+	RespondCommand(ctx context.Context, responseURL, text string) error
+}
+
+// checkUsecase is struct binding check controller of check usecase with method running check process of that
+type checkUsecase struct {
+	domain.CheckController
+	check func(ctx context.Context) error
+}
+
+// NewSlackCommandHandler initialize the resources of slack slash command to HTTP API endpoint
+// check run by slash command is processed in background with global context & result is sent to response url
+func NewSlackCommandHandler(
+	ctx context.Context,
+	r *gin.Engine,
+	sca slackCommandAgency,
+	mm maintenanceManager,
+	dus []domain.DiskCheckUseCase,
+	cus []domain.CPUCheckUseCase,
+	mus []domain.MemoryCheckUseCase,
+	eus []domain.ElasticsearchCheckUseCase,
+	sus []domain.SwarmpitCheckUseCase,
+	csus []domain.ConsulCheckUseCase,
+) {
+	h := &slackCommandHandler{
+		globalCtx: ctx,
+		agency:    sca,
+		manager:   mm,
+	}
+
+	// every check instance of usecase is bound with method running check process of that
+	for _, du := range dus {
+		h.checks = append(h.checks, checkUsecase{du, du.CheckDisk})
+	}
+	for _, cu := range cus {
+		h.checks = append(h.checks, checkUsecase{cu, cu.CheckCPU})
+	}
+	for _, mu := range mus {
+		h.checks = append(h.checks, checkUsecase{mu, mu.CheckMemory})
+	}
+	for _, eu := range eus {
+		h.checks = append(h.checks, checkUsecase{eu, eu.CheckElasticsearch})
+	}
+	for _, su := range sus {
+		h.checks = append(h.checks, checkUsecase{su, su.CheckSwarmpit})
+	}
+	for _, csu := range csus {
+		h.checks = append(h.checks, checkUsecase{csu, csu.CheckConsul})
+	}
+
+	r.POST("slack/commands", sca.AuthenticateCommand(), h.HandleCommand)
+}
+
+// HandleCommand method dispatch slash command to check usecase or maintenance manager with sub command
+// response is always 200 status code because slack show only text of response to user
+func (sh *slackCommandHandler) HandleCommand(c *gin.Context) {
+	args := strings.Fields(c.PostForm("text"))
+	operator := fmt.Sprintf("slack:%s", c.PostForm("user_name"))
+	if len(args) == 0 {
+		respondSlackCommand(c, slackCommandUsage)
+		return
+	}
+
+	switch sub, args := strings.ToLower(args[0]), args[1:]; {
+	case sub == "run" && len(args) == 1:
+		sh.run(c, args[0], c.PostForm("response_url"), operator)
+	case sub == "status" && len(args) <= 1:
+		sh.status(c, args)
+	case (sub == domain.MaintenanceModePause || sub == domain.MaintenanceModeSuppress) && len(args) >= 2:
+		sh.addWindow(c, sub, args[0], args[1], strings.Join(args[2:], " "), operator)
+	case sub == "ack" && len(args) >= 2:
+		sh.operate(c, "acknowledge", domain.CheckController.Acknowledge, args[0], strings.Join(args[1:], " "), operator)
+	case sub == "reset" && len(args) >= 2:
+		sh.operate(c, "reset", domain.CheckController.Reset, args[0], strings.Join(args[1:], " "), operator)
+	default:
+		respondSlackCommand(c, slackCommandUsage)
+	}
+}
+
+// run method respond immediately & run check process in background, and send result of that to response url
+func (sh *slackCommandHandler) run(c *gin.Context, _type, responseURL, operator string) {
+	check, ok := sh.findCheck(_type)
+	if !ok {
+		respondSlackCommand(c, fmt.Sprintf("check is not exist, type: %s", _type))
+		return
+	}
+
+	s := check.Status()
+	respondSlackCommand(c, fmt.Sprintf("%s started to run %s/%s, result will be sent soon", operator, s.Domain, s.Type))
+
+	go func() {
+		var text string
+		if err := check.check(sh.globalCtx); err != nil {
+			text = errors.Wrapf(err, "failed to run %s/%s", s.Domain, s.Type).Error()
+		} else {
+			text = fmt.Sprintf("finished to run %s/%s\n%s", s.Domain, s.Type, statusToText(check.Status()))
+		}
+		if err := sh.agency.RespondCommand(sh.globalCtx, responseURL, text); err != nil {
+			log.Println(errors.Wrap(err, "failed to respond result of slash command"))
+		}
+	}()
+}
+
+// status method respond current status of every check or check type received from parameter
+func (sh *slackCommandHandler) status(c *gin.Context, args []string) {
+	lines := make([]string, 0, len(sh.checks))
+	for _, check := range sh.checks {
+		s := check.Status()
+		if len(args) == 0 || matchCheck(s.Domain, s.Type, "", args[0]) {
+			lines = append(lines, statusToText(s))
+		}
+	}
+
+	if len(lines) == 0 {
+		respondSlackCommand(c, fmt.Sprintf("check is not exist, type: %s", args[0]))
+		return
+	}
+	respondSlackCommand(c, strings.Join(lines, "\n"))
+}
+
+// addWindow method add one-off maintenance window of mode during duration, window is applied to every check if type is all
+func (sh *slackCommandHandler) addWindow(c *gin.Context, mode, _type, duration, reason, operator string) {
+	mw := domain.MaintenanceWindow{Mode: mode, Duration: duration, Reason: reason, Operator: operator}
+	if mw.Reason == "" {
+		mw.Reason = fmt.Sprintf("%s by slash command", mode)
+	}
+
+	target := "every check"
+	if _type != "all" {
+		check, ok := sh.findCheck(_type)
+		if !ok {
+			respondSlackCommand(c, fmt.Sprintf("check is not exist, type: %s", _type))
+			return
+		}
+		s := check.Status()
+		mw.Domain, mw.Type, target = s.Domain, _type, fmt.Sprintf("%s/%s", s.Domain, s.Type)
+	}
+
+	added, err := sh.manager.AddWindow(mw)
+	if err != nil {
+		respondSlackCommand(c, errors.Wrap(err, "failed to add maintenance window").Error())
+		return
+	}
+	respondSlackCommand(c, fmt.Sprintf("%s added %s window to %s for %s, id: %s, reason: %s",
+		operator, added.Mode, target, added.Duration, added.ID, added.Reason))
+}
+
+// operate method find check matched with type & call operation function with operator, reason
+func (sh *slackCommandHandler) operate(c *gin.Context, name string, operation operationFunc, _type, reason, operator string) {
+	check, ok := sh.findCheck(_type)
+	if !ok {
+		respondSlackCommand(c, fmt.Sprintf("check is not exist, type: %s", _type))
+		return
+	}
+
+	if err := operation(check, c.Request.Context(), operator, reason); err != nil {
+		respondSlackCommand(c, errors.Wrapf(err, "unable to %s check", name).Error())
+		return
+	}
+	respondSlackCommand(c, fmt.Sprintf("finished to %s check by operator %s\n%s", name, operator, statusToText(check.Status())))
+}
+
+// findCheck method return check usecase whose type is matched with parameter regardless of domain
+func (sh *slackCommandHandler) findCheck(_type string) (checkUsecase, bool) {
+	for _, check := range sh.checks {
+		s := check.Status()
+		if matchCheck(s.Domain, s.Type, "", _type) {
+			return check, true
+		}
+	}
+	return checkUsecase{}, false
+}
+
+// respondSlackCommand respond text of slash command which is visible to every member in channel
+func respondSlackCommand(c *gin.Context, text string) {
+	c.JSON(http.StatusOK, gin.H{"response_type": "in_channel", "text": text})
+}
+
+// statusToText convert domain.CheckStatus to one line text used in response of slash command
+func statusToText(s domain.CheckStatus) string {
+	text := fmt.Sprintf("• %s/%s: *%s* for %s", s.Domain, s.Type, s.State, time.Since(s.StateSince).Round(time.Second))
+	if s.AcknowledgedBy != "" {
+		text += fmt.Sprintf(", acknowledged by %s", s.AcknowledgedBy)
+	}
+	if !s.LastRunTime.IsZero() {
+		text += fmt.Sprintf(", last run: %s (%s)", s.LastRunTime.Format("2006-01-02 15:04:05"), s.LastProcessLevel)
+	}
+	return text
+}
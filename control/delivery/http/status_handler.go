@@ -0,0 +1,89 @@
+// Create package in v.1.1.0
+// http package is delivery layer to inspect & control every check usecase in syscheck, srvcheck domain with HTTP API
+// unlike http delivery in each domain, handler in this package deal with all check usecase regardless of domain
+
+// status_handler.go is file that define http handler exposing current status of every check usecase
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// stateSeverity represent severity of each check state, used for deciding the worst state
+var stateSeverity = map[string]int{
+	domain.CheckStateHealthy:    0,
+	domain.CheckStateWarning:    1,
+	domain.CheckStateRecovering: 2,
+	domain.CheckStateUnhealthy:  3,
+}
+
+// statusHandler represent the http handler for status of every check usecase
+type statusHandler struct {
+	scheduleReporter scheduleReporter
+	reporters        []domain.CheckStatusReporter
+}
+
+// NewStatusHandler initialize the resources of status about check usecase to HTTP API endpoint
+// next run time of each check is reported together if check process is scheduled in scheduleReporter
+func NewStatusHandler(r *gin.Engine, ra readAuthenticator, sr scheduleReporter, reporters ...domain.CheckStatusReporter) {
+	h := &statusHandler{
+		scheduleReporter: sr,
+		reporters:        reporters,
+	}
+
+	r.GET("status", ra.AuthenticateReader(), h.GetStatus)
+}
+
+// GetStatus method respond current status of every check usecase, and status code is decided with the worst state
+// status code is 503 (Service Unavailable) only if any check usecase is unhealthy, otherwise status code is 200
+func (sh *statusHandler) GetStatus(c *gin.Context) {
+	worst := domain.CheckStateHealthy
+	checks := make([]gin.H, len(sh.reporters))
+	schedules := sh.scheduleReporter.Schedules()
+
+	for i, reporter := range sh.reporters {
+		status := reporter.Status()
+		if stateSeverity[status.State] > stateSeverity[worst] {
+			worst = status.State
+		}
+
+		checks[i] = statusToJSON(status)
+		for _, schedule := range schedules {
+			if schedule.Domain == status.Domain && schedule.Type == status.Type {
+				checks[i]["schedule"] = schedule.Spec
+				checks[i]["next_run_time"] = schedule.NextRunTime
+			}
+		}
+	}
+
+	code := http.StatusOK
+	if worst == domain.CheckStateUnhealthy {
+		code = http.StatusServiceUnavailable
+	}
+
+	c.JSON(code, gin.H{
+		"status": code, "code": 0, "message": "current status of every check, state is the worst state of all checks",
+		"state": worst, "checks": checks,
+	})
+}
+
+// statusToJSON convert domain.CheckStatus to JSON object used in response of HTTP API
+func statusToJSON(status domain.CheckStatus) gin.H {
+	return gin.H{
+		"domain":             status.Domain,
+		"type":               status.Type,
+		"state":              status.State,
+		"state_since":        status.StateSince,
+		"state_duration":     time.Since(status.StateSince).Round(time.Second).String(),
+		"acknowledged_by":    status.AcknowledgedBy,
+		"last_run_time":      status.LastRunTime,
+		"last_uuid":          status.LastUUID,
+		"last_process_level": status.LastProcessLevel,
+		"last_values":        status.LastValues,
+	}
+}
@@ -0,0 +1,156 @@
+// Create file in v.1.1.0
+// stream_handler.go is file that define http handler streaming every check history as server-sent events
+
+package http
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// const value used for streaming check history
+const (
+	historyBufferSize = 50               // buffer size of channel receiving check history in each stream
+	keepAliveInterval = time.Second * 15 // interval of ping event preventing proxy from closing idle stream
+)
+
+// levelSeverity represent severity of each process level, used for filtering check history with minimum level
+// severity of check history is the highest severity of process levels which check process passed through
+var levelSeverity = map[string]int{
+	"HEALTHY":       0,
+	"SKIPPED":       0,
+	"PAUSED":        0,
+	"ACKNOWLEDGED":  0,
+	"RESET":         0,
+	"WARNING":       1,
+	"SUPPRESSED":    1,
+	"SIMULATED":     1,
+	"WEAK_DETECTED": 2,
+	"RECOVERING":    2,
+	"RECOVERED":     2,
+	"TIMEOUT":       2,
+	"UNHEALTHY":     3,
+	"ERROR":         3,
+	"ESCALATED":     3,
+}
+
+// componentKeys is key list of dotted map which is common in every check history, so is excluded from values
+var componentKeys = []string{
+	"version", "agent", "@timestamp", "domain", "type", "uuid", "process_level", "message", "error",
+	"alerted", "alarm_text", "alarm_time", "alarm_error", "operator", "operation_reason", "alarm_results",
+}
+
+// streamHandler represent the http handler for streaming check history
+type streamHandler struct {
+	historySubscriber historySubscriber
+}
+
+// historySubscriber is interface that subscribe check history published after check process is finished
+// you can see implementation in broker package
+type historySubscriber interface {
+	// Subscribe return channel receiving check history & cancel function stopping subscription
+	Subscribe(buffer int) (histories <-chan domain.CheckHistory, cancel func())
+}
+
+// NewStreamHandler initialize the resources of check history stream to HTTP API endpoint
+func NewStreamHandler(r *gin.Engine, ra readAuthenticator, hs historySubscriber) {
+	h := &streamHandler{
+		historySubscriber: hs,
+	}
+
+	r.GET("histories/stream", ra.AuthenticateReader(), h.StreamHistories)
+}
+
+// StreamHistories method send check history as history event until client close connection or process is stopped
+// check history can be filtered with domain, type & minimum process level in query (Ex, ?type=consul&level=WARNING)
+func (sh *streamHandler) StreamHistories(c *gin.Context) {
+	_domain, _type, level := c.Query("domain"), c.Query("type"), strings.ToUpper(c.Query("level"))
+	minSeverity, ok := levelSeverity[level]
+	if level != "" && !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": http.StatusBadRequest, "code": 0,
+			"message": fmt.Sprintf("unknown process level in query, level: %s", c.Query("level")),
+		})
+		return
+	}
+
+	histories, cancel := sh.historySubscriber.Subscribe(historyBufferSize)
+	defer cancel()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+
+	// flush header before first event so that client can know stream is opened
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	c.Stream(func(_ io.Writer) bool {
+		select {
+		case history := <-histories:
+			if matchCheck(history.Domain(), history.Type(), _domain, _type) && historySeverity(history) >= minSeverity {
+				c.SSEvent("history", historyToJSON(history))
+			}
+		case t := <-ticker.C:
+			c.SSEvent("ping", t)
+		case <-c.Request.Context().Done():
+			return false
+		}
+		return true
+	})
+}
+
+// matchCheck return if check domain & type is matched with filter, empty filter is matched with every check
+// type filter is matched with check type without Check suffix ignoring case (Ex, consul -> ConsulCheck)
+func matchCheck(checkDomain, checkType, _domain, _type string) bool {
+	if _domain != "" && checkDomain != _domain {
+		return false
+	}
+	if _type != "" && !strings.EqualFold(strings.TrimSuffix(checkType, "Check"), strings.TrimSuffix(_type, "Check")) {
+		return false
+	}
+	return true
+}
+
+// historySeverity return the highest severity of process levels which check process passed through
+func historySeverity(history domain.CheckHistory) (severity int) {
+	for _, level := range history.ProcessLevels() {
+		if levelSeverity[level] > severity {
+			severity = levelSeverity[level]
+		}
+	}
+	return
+}
+
+// historyToJSON convert domain.CheckHistory to JSON object used in history event of stream
+func historyToJSON(history domain.CheckHistory) gin.H {
+	m := history.DottedMapWithPrefix("")
+	h := gin.H{
+		"domain":        history.Domain(),
+		"type":          history.Type(),
+		"timestamp":     history.Timestamp(),
+		"uuid":          m["uuid"],
+		"process_level": m["process_level"],
+		"message":       m["message"],
+		"alerted":       history.IsAlerted(),
+		"error":         nil,
+	}
+
+	if err := history.Err(); err != nil {
+		h["error"] = err.Error()
+	}
+
+	for _, key := range componentKeys {
+		delete(m, key)
+	}
+	h["values"] = m
+	return h
+}
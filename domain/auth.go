@@ -0,0 +1,25 @@
+// Create file in v.1.1.0
+// auth.go is file that declare model struct & const about credential used for authenticating HTTP API request
+// credential is declared in environment variable & role of that decides which endpoint is accessible
+
+package domain
+
+// const value represent role of credential, used in Role field of Credential
+// each role can access every endpoint which lower role can access (read < trigger < operator)
+const (
+	RoleRead     = "read"     // represent that credential can access only read-only endpoint (Ex, GET /status)
+	RoleTrigger  = "trigger"  // represent that credential can trigger check process which may run remediation
+	RoleOperator = "operator" // represent that credential can control check usecase, maintenance window & schedule
+)
+
+// Credential model is used for representing bearer token or HMAC key which authenticate HTTP API request
+type Credential struct {
+	// Name specifies name of credential owner, used as operator name in check history
+	Name string
+
+	// Role specifies role of credential, one of Role const value
+	Role string
+
+	// Secret specifies bearer token or HMAC key of credential
+	Secret string
+}
@@ -0,0 +1,133 @@
+// Create file in v.1.1.0
+// check.go is file that declare interface & model used in common in every check domain (syscheck, srvcheck)
+// it is used in another layer which is handling check result regardless of check domain or check type
+
+package domain
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// CheckHistory is interface that every check history model in syscheck, srvcheck domain implement
+type CheckHistory interface {
+	// Domain method returns domain of check history (Ex, syscheck, srvcheck)
+	Domain() string
+
+	// Type method returns detail check type of check history (Ex, CPUCheck, ConsulCheck)
+	Type() string
+
+	// Timestamp method returns the time when check history was created
+	Timestamp() time.Time
+
+	// ProcessLevels method returns all process level which check process passed through
+	ProcessLevels() []string
+
+	// Err method returns error occurred while handling check process
+	Err() error
+
+	// IsAlerted method returns if alarm was sent while handling check process
+	IsAlerted() bool
+
+	// Remediations method returns description of remediation actions performed in check process
+	Remediations() []string
+
+	// DottedMapWithPrefix method convert check history to dotted map with prefix
+	DottedMapWithPrefix(prefix string) map[string]interface{}
+}
+
+// const value represent state of check usecase, used in State field of CheckStatus
+const (
+	CheckStateHealthy    = "HEALTHY"    // represent that check usecase is healthy now
+	CheckStateWarning    = "WARNING"    // represent that check usecase is warning now, but not weak yet
+	CheckStateRecovering = "RECOVERING" // represent that check usecase is recovering weak now
+	CheckStateUnhealthy  = "UNHEALTHY"  // represent that check usecase is unhealthy now (administrator should check)
+)
+
+// CheckStatus model is used for representing current status of check usecase & result of latest check process
+type CheckStatus struct {
+	// Domain specifies domain of check usecase (Ex, syscheck, srvcheck)
+	Domain string
+
+	// Type specifies detail check type of check usecase (Ex, CPUCheck, ConsulCheck)
+	Type string
+
+	// State specifies current state of check usecase, one of CheckState const value
+	State string
+
+	// StateSince specifies the time when check usecase entered into current state
+	StateSince time.Time
+
+	// LastRunTime specifies the time when latest check process was run
+	LastRunTime time.Time
+
+	// LastUUID specifies UUID of latest check process
+	LastUUID string
+
+	// LastProcessLevel specifies process level of latest check process
+	LastProcessLevel string
+
+	// LastValues specifies summary of values measured in latest check process
+	LastValues map[string]interface{}
+
+	// AcknowledgedBy specifies name of operator who acknowledged current unhealthy state (empty if not acknowledged)
+	AcknowledgedBy string
+}
+
+// CheckStatusReporter is interface that every check usecase implement to report current status
+type CheckStatusReporter interface {
+	// Status method returns current status of check usecase & result of latest check process
+	Status() CheckStatus
+}
+
+// error value returned from method of CheckController when operation is not available in current state
+var (
+	ErrCheckNotUnhealthy = errors.New("check is not in unhealthy state")
+	ErrCheckRecovering   = errors.New("check is recovering now, try again after recovering is finished")
+)
+
+// CheckController is interface that every check usecase implement to be controlled manually by operator
+// every operation is stored as check history in repository with operator & reason, and notified to slack
+type CheckController interface {
+	// get Status method from embedding CheckStatusReporter
+	CheckStatusReporter
+
+	// Acknowledge method acknowledge unhealthy state of check usecase, return ErrCheckNotUnhealthy if not unhealthy
+	Acknowledge(ctx context.Context, operator, reason string) error
+
+	// Reset method force check usecase back to healthy state, return ErrCheckRecovering if recovering now
+	Reset(ctx context.Context, operator, reason string) error
+
+	// Rerun method reset state of check usecase and run check process immediately
+	Rerun(ctx context.Context, operator, reason string) error
+}
+
+// CheckSchedule model is used for representing schedule of check process in delivery layer
+type CheckSchedule struct {
+	// Domain specifies domain of scheduled check (Ex, syscheck, srvcheck)
+	Domain string
+
+	// Type specifies detail check type of scheduled check (Ex, CPUCheck, ConsulCheck)
+	Type string
+
+	// Spec specifies schedule spec, interval or cron expression with optional jitter (Ex, 5m ~30s, 0 3 * * *)
+	Spec string
+
+	// NextRunTime specifies the time when check process will be run next
+	NextRunTime time.Time
+}
+
+// dryRunKey is type of context key used for setting dry-run mode in context
+type dryRunKey struct{}
+
+// WithDryRun return context derived from parent which let check usecase simulate remediation without executing
+func WithDryRun(parent context.Context) context.Context {
+	return context.WithValue(parent, dryRunKey{}, true)
+}
+
+// IsDryRun return if dry-run mode is set in context with WithDryRun function
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
@@ -0,0 +1,19 @@
+// Create file in v.1.1.0
+// clock.go is file that declare interface about timer created from clock injected to each of package
+// timer is declared in domain package, because clock returning timer is implemented in clock package (real, fake)
+
+package domain
+
+import (
+	"time"
+)
+
+// Timer is interface that deliver the time to channel once after duration, like time.Timer of standard package
+// you can see implementation in clock package
+type Timer interface {
+	// C method returns channel receiving the time when timer is fired
+	C() <-chan time.Time
+
+	// Stop method prevent timer from firing, return false if timer was already fired or stopped
+	Stop() bool
+}
@@ -0,0 +1,50 @@
+// Create file in v.1.1.0
+// config.go is file that declare model struct about config of every domain validated & reloaded from config file
+
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfigChange model is used for representing change of config value applied by reloading config file
+type ConfigChange struct {
+	// Key specifies key of changed config value (Ex, syscheck.cpucheck.cpuWarningUsage)
+	Key string
+
+	// Old specifies config value before reload in string format
+	Old string
+
+	// New specifies config value after reload in string format
+	New string
+
+	// RestartRequired specifies if changed value isn't applied until process is restarted (Ex, list of check instance)
+	RestartRequired bool
+}
+
+// ConfigError model is used for representing problem of config value found while validating config
+type ConfigError struct {
+	// Key specifies key path of invalid config value (Ex, syscheck.cpucheck.cpuWarningUsage, ES_ADDRESS)
+	Key string
+
+	// Message specifies why config value is invalid
+	Message string
+}
+
+// Error method return key path & message of problem, implement error interface
+func (ce ConfigError) Error() string {
+	return ce.Key + ": " + ce.Message
+}
+
+// ConfigErrors is list of ConfigError, which implement error interface so that every problem is reported at once
+type ConfigErrors []ConfigError
+
+// Error method return every problem in list with one problem per line, implement error interface
+func (ces ConfigErrors) Error() string {
+	lines := make([]string, len(ces))
+	for i, ce := range ces {
+		lines[i] = "- " + ce.Error()
+	}
+	return fmt.Sprintf("%d invalid config value(s)\n%s", len(ces), strings.Join(lines, "\n"))
+}
@@ -0,0 +1,210 @@
+// Create file in v.1.1.0
+// incident.go is file that declare model struct & repo, usecase interface about incident of check
+// incident spans check processes from detection of weak (WARNING, WEAK_DETECTED) to recovery or reset by operator
+
+package domain
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// const value represent state of incident, used in State method of Incident & IncidentFilter
+const (
+	IncidentStateOpen   = "OPEN"   // represent that incident is not closed yet
+	IncidentStateClosed = "CLOSED" // represent that incident is closed with recovery or reset by operator
+)
+
+// ErrIncidentNotFound is error value returned from incident repository & usecase when incident with ID doesn't exist
+var ErrIncidentNotFound = errors.New("incident is not found")
+
+// Incident model is used for linking check processes about one weak of check from detection to recovery
+// alarms sent in check process have ID of incident, so that notifier backend can group them (Ex, slack thread)
+type Incident struct {
+	// ID specifies uuid of check process in which incident was opened
+	ID string
+
+	// Domain specifies domain of check which incident is about (Ex, syscheck)
+	Domain string
+
+	// Type specifies type of check which incident is about (Ex, DiskCheck)
+	Type string
+
+	// OpenedLevel specifies process level of check process opening incident (WARNING or WEAK_DETECTED)
+	OpenedLevel string
+
+	// OpenedAt specifies the time when check process opening incident was run
+	OpenedAt time.Time
+
+	// LastHealthyAt specifies the time when check process was healthy lastly before incident was opened
+	// it's zero if there was no healthy check process since start of health checker
+	LastHealthyAt time.Time
+
+	// ClosedLevel specifies process level of check history closing incident (RECOVERED or RESET), empty if open
+	ClosedLevel string
+
+	// ClosedAt specifies the time when incident was closed, zero if open
+	ClosedAt time.Time
+
+	// ClosedBy specifies operator who closed incident by resetting check, empty if closed by recovery
+	ClosedBy string
+
+	// Runs specifies uuid of every check process & operation related to incident in order of time
+	Runs []string
+
+	// Remediations specifies remediation actions performed in check processes of incident
+	Remediations []IncidentRemediation
+
+	// Alarms specifies alarms sent about incident
+	Alarms []IncidentAlarm
+}
+
+// IncidentRemediation model is used for representing remediation action performed in check process of incident
+type IncidentRemediation struct {
+	// UUID specifies uuid of check process performing remediation
+	UUID string
+
+	// Time specifies the time when check process performing remediation was run
+	Time time.Time
+
+	// Action specifies description of remediation action (Ex, pruned docker system, reclaimed 2.00GB)
+	Action string
+
+	// Simulated specifies if remediation was simulated without executing in dry-run mode
+	Simulated bool
+}
+
+// IncidentAlarm model is used for representing alarm sent about incident
+type IncidentAlarm struct {
+	// UUID specifies uuid of check process sending alarm
+	UUID string
+
+	// Time specifies the time when alarm was built in check process
+	Time time.Time
+
+	// Level specifies process level which alarm is about (Ex, RECOVERED)
+	Level string
+
+	// Text specifies text of alarm
+	Text string
+}
+
+// State method return state of incident, one of IncidentState const value
+func (i Incident) State() string {
+	if i.ClosedAt.IsZero() {
+		return IncidentStateOpen
+	}
+	return IncidentStateClosed
+}
+
+// TimeToDetect method return duration from last healthy check process to check process opening incident
+// it's upper bound of time taken to detect weak, and zero if there was no healthy check process before incident
+func (i Incident) TimeToDetect() time.Duration {
+	if i.LastHealthyAt.IsZero() || i.LastHealthyAt.After(i.OpenedAt) {
+		return 0
+	}
+	return i.OpenedAt.Sub(i.LastHealthyAt)
+}
+
+// TimeToRecover method return duration from opening to closing of incident, zero if incident is open
+func (i Incident) TimeToRecover() time.Duration {
+	if i.ClosedAt.IsZero() {
+		return 0
+	}
+	return i.ClosedAt.Sub(i.OpenedAt)
+}
+
+// Copy method return deep copy of incident, so that returned one can be used without race with the original
+func (i Incident) Copy() Incident {
+	i.Runs = append([]string{}, i.Runs...)
+	i.Remediations = append([]IncidentRemediation{}, i.Remediations...)
+	i.Alarms = append([]IncidentAlarm{}, i.Alarms...)
+	return i
+}
+
+// IncidentFilter model is used for filtering incidents fetched from repository, empty field is matched with every incident
+type IncidentFilter struct {
+	// Domain specifies domain of check which incident is about (Ex, syscheck)
+	Domain string
+
+	// Type specifies type of check which incident is about (Ex, DiskCheck)
+	Type string
+
+	// State specifies state of incident, one of IncidentState const value
+	State string
+
+	// From, To specifies range of the time when incident was opened
+	From, To time.Time
+
+	// Size specifies max number of incidents, recently opened incident is returned first
+	Size int
+}
+
+// IncidentStats model is used for representing statistics of incidents (Ex, MTTD, MTTR)
+type IncidentStats struct {
+	// Total specifies number of incidents
+	Total int
+
+	// Open specifies number of incidents not closed yet
+	Open int
+
+	// MTTD specifies mean time to detect of incidents whose TimeToDetect is known
+	MTTD time.Duration
+
+	// MTTR specifies mean time to recover of closed incidents
+	MTTR time.Duration
+}
+
+// IncidentStatsOf return statistics of incidents received from parameter
+func IncidentStatsOf(incidents []Incident) (stats IncidentStats) {
+	var detected, closed int
+	var ttd, ttr time.Duration
+	for _, incident := range incidents {
+		stats.Total++
+		if incident.State() == IncidentStateOpen {
+			stats.Open++
+		} else {
+			closed++
+			ttr += incident.TimeToRecover()
+		}
+		if d := incident.TimeToDetect(); d > 0 {
+			detected++
+			ttd += d
+		}
+	}
+
+	if detected > 0 {
+		stats.MTTD = ttd / time.Duration(detected)
+	}
+	if closed > 0 {
+		stats.MTTR = ttr / time.Duration(closed)
+	}
+	return
+}
+
+// IncidentRepository is abstract method used in business layer
+// Repository is implemented with elastic search in v.1.1.0
+type IncidentRepository interface {
+	// Migrate method build environment for storage in stores such as Mysql or Elasticsearch, etc.
+	Migrate() error
+
+	// Store method save Incident model in repository, incident having same ID is overwritten
+	// b in return represents bytes of response body(map[string]interface{})
+	Store(incident Incident) (b []byte, err error)
+
+	// FetchIncidents method return incidents matched with filter, recently opened incident is returned first
+	FetchIncidents(ctx context.Context, filter IncidentFilter) ([]Incident, error)
+
+	// FetchIncident method return incident with ID, return ErrIncidentNotFound if not exist
+	FetchIncident(ctx context.Context, id string) (Incident, error)
+}
+
+// IncidentUseCase is interface used as business process handler about incident
+type IncidentUseCase interface {
+	// GetIncidents method return incidents matched with filter & statistics of them (Ex, MTTD, MTTR)
+	GetIncidents(ctx context.Context, filter IncidentFilter) ([]Incident, IncidentStats, error)
+
+	// GetIncident method return incident with ID, return ErrIncidentNotFound if not exist
+	GetIncident(ctx context.Context, id string) (Incident, error)
+}
@@ -0,0 +1,46 @@
+// Create file in v.1.1.0
+// maintenance.go is file that declare model struct about maintenance window which pause check or suppress remediation
+// maintenance window is declared in config file or added from HTTP API by operator
+
+package domain
+
+// const value represent mode of maintenance window, used in Mode field of MaintenanceWindow
+const (
+	MaintenanceModePause    = "pause"    // represent that check process is not run while window is active
+	MaintenanceModeSuppress = "suppress" // represent that check process is run but remediation is suppressed
+)
+
+// MaintenanceWindow model is used for representing time range in which check is paused or remediation is suppressed
+// if Weekday is set, window is repeated every week and Start, End is clock (Ex, 03:00) in maintenance timezone
+// if not, window is one-off and Start, End is date time (Ex, 2021-04-01 03:00) or Duration from now is used
+type MaintenanceWindow struct {
+	// ID specifies identifier of maintenance window, generated when window is added
+	ID string `json:"id" mapstructure:"id"`
+
+	// Domain specifies domain of check to apply window (Ex, syscheck), every domain if empty
+	Domain string `json:"domain" mapstructure:"domain"`
+
+	// Type specifies check type to apply window without Check suffix (Ex, cpu), every type if empty
+	Type string `json:"type" mapstructure:"type"`
+
+	// Mode specifies mode of maintenance window, one of MaintenanceMode const value
+	Mode string `json:"mode" mapstructure:"mode"`
+
+	// Weekday specifies day of the week in which window is repeated (Ex, sunday)
+	Weekday string `json:"weekday" mapstructure:"weekday"`
+
+	// Start specifies the time when window is started
+	Start string `json:"start" mapstructure:"start"`
+
+	// End specifies the time when window is ended
+	End string `json:"end" mapstructure:"end"`
+
+	// Duration specifies duration of one-off window starting from now, used only if Start is empty (Ex, 30m)
+	Duration string `json:"duration" mapstructure:"duration"`
+
+	// Reason specifies the reason why maintenance window is needed
+	Reason string `json:"reason" mapstructure:"reason"`
+
+	// Operator specifies name of operator who added maintenance window (config if declared in config file)
+	Operator string `json:"operator" mapstructure:"operator"`
+}
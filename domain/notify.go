@@ -0,0 +1,287 @@
+// Create file in v.1.1.0
+// notify.go is file that declare model struct about alarm sent to notifier backends in check process
+// alarm is fanned out to every notifier backend (Ex, slack, webhook, discord, email) whose filter is matched with alarm
+
+package domain
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// Alarm model is used for representing alarm sent to notifier backends in check process
+type Alarm struct {
+	// Domain specifies domain of check sending alarm (Ex, syscheck)
+	Domain string
+
+	// Type specifies type of check sending alarm (Ex, DiskCheck)
+	Type string
+
+	// Kind specifies kind of check sending alarm, which is different from Type in named check instance (Ex, DiskCheck)
+	Kind string
+
+	// Level specifies process level which alarm is about (Ex, WEAK_DETECTED)
+	Level string
+
+	// Emoji specifies emoji name representing alarm (Ex, pill)
+	Emoji string
+
+	// Text specifies text of alarm
+	Text string
+
+	// UUID specifies uuid of check process sending alarm
+	UUID string
+
+	// Incident specifies ID of incident which alarm is about, which is uuid of check process opening incident
+	// alarms about one incident (Ex, weak detected, recovering, recovered) have same incident, empty if not about incident
+	Incident string
+
+	// Resolved specifies if incident which alarm is about is resolved (Ex, status of check become healthy)
+	Resolved bool
+
+	// Channel specifies channel of notifier backend which alarm is sent to, set by routing rule (Ex, #oncall)
+	// alarm is sent to default channel of backend if empty, and it's ignored in backend not having channel
+	Channel string
+
+	// Mention specifies mention added to alarm, set by routing rule (Ex, <!subteam^S0123ABCD|@backend>)
+	Mention string
+
+	// Fields specifies structured detail of alarm with AlarmField key, only field having value is set
+	// it's used for rendering rich message in notifier backend (Ex, slack block kit)
+	Fields map[string]string
+}
+
+// key of Alarm.Fields, notifier backend render each field with label of its own according to check type
+const (
+	AlarmFieldMeasured  = "measured"  // value measured in check process (Ex, total cpu usage)
+	AlarmFieldThreshold = "threshold" // threshold compared with measured value (Ex, maximum cpu usage)
+	AlarmFieldTarget    = "target"    // target affected by check process (Ex, removed container, deleted index)
+	AlarmFieldNode      = "node"      // node which check process is run in
+)
+
+// AlarmResult model is used for representing result of alarm sent to one notifier backend
+type AlarmResult struct {
+	// Backend specifies name of notifier backend (Ex, slack)
+	Backend string
+
+	// Time specifies the time when backend sent alarm
+	Time time.Time
+
+	// Text specifies text sent actually by backend, or text of alarm if suppressed
+	Text string
+
+	// Err specifies error occurred while sending alarm to backend
+	Err error
+
+	// Suppressed specifies if alarm was not sent to backend by notification policy (Ex, deduplication, rate limit)
+	Suppressed bool
+
+	// SuppressReason specifies the reason why alarm was suppressed by notification policy
+	SuppressReason string
+}
+
+// AlarmResults is type of AlarmResult list, which is result of alarm fanned out to notifier backends
+type AlarmResults []AlarmResult
+
+// Sent method return if alarm was sent to any backend without suppression of notification policy
+func (results AlarmResults) Sent() bool {
+	for _, result := range results {
+		if !result.Suppressed {
+			return true
+		}
+	}
+	return false
+}
+
+// Suppressed method return if alarm was suppressed in every backend by notification policy, and reason of that
+func (results AlarmResults) Suppressed() (suppressed bool, reason string) {
+	if len(results) == 0 || results.Sent() {
+		return false, ""
+	}
+	return true, results[0].SuppressReason
+}
+
+// Text method return text of first backend succeed to send alarm
+func (results AlarmResults) Text() string {
+	for _, result := range results {
+		if result.Err == nil && !result.Suppressed {
+			return result.Text
+		}
+	}
+	return ""
+}
+
+// Time method return the time when alarm was sent first among every backend
+func (results AlarmResults) Time() (t time.Time) {
+	for _, result := range results {
+		if result.Err == nil && !result.Suppressed && (t.IsZero() || result.Time.Before(t)) {
+			t = result.Time
+		}
+	}
+	return
+}
+
+// Err method return error joined with error of every backend failed to send alarm, or nil if every backend succeed
+func (results AlarmResults) Err() error {
+	var msgs []string
+	for _, result := range results {
+		if result.Err != nil {
+			msgs = append(msgs, fmt.Sprintf("%s: %v", result.Backend, result.Err))
+		}
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.Errorf("failed to send alarm to %d backends, %s", len(msgs), strings.Join(msgs, "; "))
+}
+
+// Maps method return result of every backend as list of map, used for setting dotted map of check history
+func (results AlarmResults) Maps() []map[string]interface{} {
+	maps := make([]map[string]interface{}, len(results))
+	for i, result := range results {
+		maps[i] = map[string]interface{}{
+			"backend": result.Backend, "time": result.Time, "text": result.Text, "error": nil,
+			"suppressed": result.Suppressed, "suppress_reason": result.SuppressReason,
+		}
+		if result.Err != nil {
+			maps[i]["error"] = result.Err.Error()
+		}
+	}
+	return maps
+}
+
+// NotifyFilter model is used for filtering alarm sent to notifier backend, empty field is matched with every alarm
+type NotifyFilter struct {
+	// Domains specifies domains of check sending alarm (Ex, syscheck)
+	Domains []string `json:"domains" mapstructure:"domains"`
+
+	// Types specifies check types sending alarm without Check suffix (Ex, cpu)
+	Types []string `json:"types" mapstructure:"types"`
+
+	// Levels specifies process levels which alarm is about (Ex, WEAK_DETECTED, UNHEALTHY)
+	Levels []string `json:"levels" mapstructure:"levels"`
+}
+
+// Match method return if alarm is matched with every not empty field of filter
+// type is compared without Check suffix ignoring case, and level is compared ignoring case
+func (f NotifyFilter) Match(alarm Alarm) bool {
+	return matchAny(f.Domains, alarm.Domain, strings.EqualFold) &&
+		matchAny(f.Types, strings.TrimSuffix(alarm.Type, "Check"), func(t, v string) bool {
+			return strings.EqualFold(strings.TrimSuffix(t, "Check"), v)
+		}) &&
+		matchAny(f.Levels, alarm.Level, strings.EqualFold)
+}
+
+// matchAny return if value is matched with any of targets using equal function, empty targets is matched with every value
+func matchAny(targets []string, value string, equal func(target, value string) bool) bool {
+	if len(targets) == 0 {
+		return true
+	}
+	for _, target := range targets {
+		if equal(target, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifyRoute model is used for representing routing rule which send alarm matched with filter to channel of backend
+// alarm is sent to every matched route in addition to backends whose filter is matched with alarm
+type NotifyRoute struct {
+	// Filter specifies filter of alarm sent with this route
+	Filter NotifyFilter `json:"filter" mapstructure:"filter"`
+
+	// Backend specifies name of notifier backend which alarm is sent to (Ex, slack, discord)
+	Backend string `json:"backend" mapstructure:"backend"`
+
+	// Channel specifies channel of backend which alarm is sent to, default channel of backend is used if empty
+	Channel string `json:"channel" mapstructure:"channel"`
+
+	// Mention specifies mention added to alarm such as user group (Ex, <!subteam^S0123ABCD|@backend>)
+	Mention string `json:"mention" mapstructure:"mention"`
+}
+
+// NotifyPolicy model is used for deciding if alarm is sent or suppressed before fanned out to notifier backends
+// every rule is applied per check (domain & type), and rule is disabled if duration or count of that is zero
+type NotifyPolicy struct {
+	// DedupWindow specifies duration in which identical alarm (same level & text) is sent only once
+	DedupWindow time.Duration
+
+	// RateLimit specifies max number of alarms sent from one check in RateLimitWindow
+	RateLimit int
+
+	// RateLimitWindow specifies duration of window used for rate limit
+	RateLimitWindow time.Duration
+
+	// FlapThreshold specifies number of alarms having same level in FlapWindow, from which check is regarded as flapping
+	FlapThreshold int
+
+	// FlapWindow specifies duration of window used for flap detection
+	FlapWindow time.Duration
+
+	// ExemptLevels specifies process levels of alarm which isn't suppressed by rate limit & flap detection (Ex, UNHEALTHY)
+	// alarm of these levels is still deduplicated, because that is about state which operator should know anytime
+	ExemptLevels []string
+}
+
+// Exempted method return if alarm of level is exempted from rate limit & flap detection, level is compared ignoring case
+func (np NotifyPolicy) Exempted(level string) bool {
+	for _, exempt := range np.ExemptLevels {
+		if strings.EqualFold(exempt, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// EscalationPolicy model is used for sending reminder alarm about check staying unhealthy without acknowledgement
+// first reminder is sent after After duration or AfterRuns check runs, and it's repeated at interval growing by Multiplier
+type EscalationPolicy struct {
+	// After specifies duration of unhealthy status after which first reminder is sent (0 -> not used)
+	After time.Duration `json:"after" mapstructure:"after"`
+
+	// AfterRuns specifies number of check runs in unhealthy status after which first reminder is sent (0 -> not used)
+	AfterRuns int `json:"afterRuns" mapstructure:"afterRuns"`
+
+	// Interval specifies interval between first & second reminder, reminder is not repeated if zero
+	Interval time.Duration `json:"interval" mapstructure:"interval"`
+
+	// Multiplier specifies how much interval grows after each reminder (Ex, 2 -> 30m, 1h, 2h, ...)
+	Multiplier float64 `json:"multiplier" mapstructure:"multiplier"`
+
+	// MaxInterval specifies max interval between reminders, interval doesn't grow more than this (0 -> a year)
+	MaxInterval time.Duration `json:"maxInterval" mapstructure:"maxInterval"`
+
+	// Channel specifies channel which reminder is sent to, default channel of backend is used if empty (Ex, #oncall)
+	Channel string `json:"channel" mapstructure:"channel"`
+
+	// Mention specifies mention added to reminder such as user group (Ex, <!subteam^S0123ABCD|@backend>)
+	Mention string `json:"mention" mapstructure:"mention"`
+}
+
+// Enabled method return if escalation is enabled, which means After or AfterRuns is set
+func (p EscalationPolicy) Enabled() bool {
+	return p.After > 0 || p.AfterRuns > 0
+}
+
+// IntervalAfter method return interval between n-th reminder and next one, return zero if reminder is not repeated
+func (p EscalationPolicy) IntervalAfter(n int) time.Duration {
+	if p.Interval <= 0 {
+		return 0
+	}
+
+	limit := float64(p.MaxInterval)
+	if p.MaxInterval <= 0 {
+		limit = float64(time.Hour * 24 * 365)
+	}
+
+	interval := float64(p.Interval)
+	for i := 1; i < n && p.Multiplier > 1 && interval < limit; i++ {
+		interval *= p.Multiplier
+	}
+	return time.Duration(math.Min(interval, limit))
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEscalationPolicyIntervalAfter(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		policy EscalationPolicy
+		want   []time.Duration
+	}{{
+		name:   "not repeated",
+		policy: EscalationPolicy{After: time.Minute * 15, Multiplier: 2},
+		want:   []time.Duration{0, 0, 0},
+	}, {
+		name:   "fixed interval",
+		policy: EscalationPolicy{After: time.Minute * 15, Interval: time.Minute * 30},
+		want:   []time.Duration{time.Minute * 30, time.Minute * 30, time.Minute * 30},
+	}, {
+		name:   "growing interval",
+		policy: EscalationPolicy{After: time.Minute * 15, Interval: time.Minute * 30, Multiplier: 2},
+		want:   []time.Duration{time.Minute * 30, time.Hour, time.Hour * 2, time.Hour * 4},
+	}, {
+		name:   "growing interval limited by max interval",
+		policy: EscalationPolicy{After: time.Minute * 15, Interval: time.Minute * 30, Multiplier: 3, MaxInterval: time.Hour * 2},
+		want:   []time.Duration{time.Minute * 30, time.Minute * 90, time.Hour * 2, time.Hour * 2},
+	}, {
+		name:   "growing interval limited by a year without max interval",
+		policy: EscalationPolicy{After: time.Minute * 15, Interval: time.Hour * 24 * 100, Multiplier: 10},
+		want:   []time.Duration{time.Hour * 24 * 100, time.Hour * 24 * 365, time.Hour * 24 * 365},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			for i, want := range tc.want {
+				if got := tc.policy.IntervalAfter(i + 1); got != want {
+					t.Fatalf("IntervalAfter(%d) = %s, want %s", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEscalationPolicyEnabled(t *testing.T) {
+	if (EscalationPolicy{Interval: time.Hour}).Enabled() {
+		t.Fatal("policy without After & AfterRuns is enabled")
+	}
+	if !(EscalationPolicy{After: time.Hour}).Enabled() || !(EscalationPolicy{AfterRuns: 3}).Enabled() {
+		t.Fatal("policy with After or AfterRuns isn't enabled")
+	}
+}
+
+func TestNotifyPolicyExempted(t *testing.T) {
+	policy := NotifyPolicy{ExemptLevels: []string{"UNHEALTHY", "recovered"}}
+	for level, want := range map[string]bool{"UNHEALTHY": true, "unhealthy": true, "RECOVERED": true, "WARNING": false} {
+		if got := policy.Exempted(level); got != want {
+			t.Fatalf("Exempted(%s) = %t, want %t", level, got, want)
+		}
+	}
+}
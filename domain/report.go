@@ -0,0 +1,121 @@
+// Create file in v.1.1.0
+// report.go is file that declare model struct & repo, usecase interface about digest report of check history
+// digest report summarise every check history stored in syscheck, srvcheck domain over period (daily, weekly)
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"github.com/inhies/go-bytesize"
+	"github.com/pkg/errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// const value represent period of digest report, used in Period field of Report
+const (
+	ReportPeriodDaily  = "daily"  // represent that report summarise check history of last 24 hours
+	ReportPeriodWeekly = "weekly" // represent that report summarise check history of last 7 days
+)
+
+// const value represent unit of value summarised in CheckReport, used in Unit field of CheckReport
+const (
+	ReportUnitCore     = "core"     // represent that value is cpu usage in core
+	ReportUnitBytes    = "bytes"    // represent that value is byte size, formatted with bytesize (Ex, 2.00GB)
+	ReportUnitShards   = "shards"   // represent that value is number of elasticsearch shards
+	ReportUnitInstance = "instance" // represent that value is number of service instance registered in consul
+)
+
+// ErrInvalidReportPeriod is error returned when period of report is neither daily nor weekly
+var ErrInvalidReportPeriod = errors.New("report period must be daily or weekly")
+
+// Report model is used for representing digest report summarising every check history over period
+type Report struct {
+	// Period specifies period of report (Ex, daily, weekly)
+	Period string
+
+	// From, To specifies time range of check history summarised in report
+	From, To time.Time
+
+	// Checks specifies summary of each check, sorted by domain & type
+	Checks []CheckReport
+}
+
+// CheckReport model is used for representing summary of one check over period of report
+type CheckReport struct {
+	// Domain specifies domain of check (Ex, syscheck, srvcheck)
+	Domain string
+
+	// Type specifies detail check type (Ex, CPUCheck, ConsulCheck)
+	Type string
+
+	// Kind specifies kind of check, which is different from Type in named check instance (Ex, CPUCheck, ConsulCheck)
+	Kind string
+
+	// Runs specifies number of check process run over period, operation history (Ex, acknowledge) isn't counted
+	Runs int
+
+	// Metric specifies name of value measured in check process & summarised in Min, Avg, Max (Ex, total_usage_core)
+	Metric string
+
+	// Unit specifies unit of value measured in check process, one of ReportUnit const value
+	Unit string
+
+	// Min, Avg, Max specifies minimum, average & maximum of value measured over period
+	Min, Avg, Max float64
+
+	// Warnings specifies number of check process whose process level is warning
+	Warnings int
+
+	// WeakDetections specifies number of check process detecting weak of check
+	WeakDetections int
+
+	// Remediations specifies number of remediation performed actually (Ex, container removed), not simulated
+	Remediations int
+
+	// Unhealthy specifies total duration in which check stayed unhealthy over period
+	Unhealthy time.Duration
+}
+
+// ReportRepository is interface for repository layer used in report usecase layer
+// Repository is implemented with elasticsearch in v.1.1.0
+type ReportRepository interface {
+	// FetchHistories method return every check history stored between from & to as dotted map, sorted by timestamp
+	FetchHistories(ctx context.Context, from, to time.Time) ([]map[string]interface{}, error)
+}
+
+// ReportUseCase is interface used as business process handler about digest report
+type ReportUseCase interface {
+	// GenerateReport method summarise check history of period ending at end time (or now if zero) and return report
+	GenerateReport(ctx context.Context, period string, end time.Time) (Report, error)
+
+	// PublishReport method generate report of period ending now & post that to slack
+	PublishReport(ctx context.Context, period string) error
+}
+
+// ReportPeriodRange return start time of report period ending at end time, or ErrInvalidReportPeriod if period is invalid
+func ReportPeriodRange(period string, end time.Time) (from time.Time, err error) {
+	switch period {
+	case ReportPeriodDaily:
+		from = end.Add(-time.Hour * 24)
+	case ReportPeriodWeekly:
+		from = end.Add(-time.Hour * 24 * 7)
+	default:
+		err = ErrInvalidReportPeriod
+	}
+	return
+}
+
+// FormatValue method format value measured in check with unit of CheckReport (Ex, 1.25 core, 2.00GB, 12.5 shards)
+func (cr CheckReport) FormatValue(v float64) string {
+	switch cr.Unit {
+	case ReportUnitBytes:
+		return bytesize.New(v).String()
+	case ReportUnitCore:
+		return fmt.Sprintf("%.02f core", v)
+	default:
+		return fmt.Sprintf("%s %s", strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64), cr.Unit)
+	}
+}
@@ -33,6 +33,9 @@ type serviceCheckHistoryComponent struct {
 	// _type specifies detail service type in service check domain (Ex, elasticsearch, swarm, consul, etc ...)
 	_type string
 
+	// kind specifies kind of check which created this model, same with _type if check instance isn't named (Ex, SwarmpitCheck)
+	kind string
+
 	// ---
 
 	// public field in below, these fields don't have fixed value so set in another package from custom user
@@ -50,7 +53,7 @@ type serviceCheckHistoryComponent struct {
 
 	// ---
 
-	// field in below is about alarm result and is private so call SetAlarmResult method to set this field value
+	// field in below is about alarm result and is private so call AddAlarmResults method to set this field value
 	// Alerted specifies if alert result or status in while handling service check process.
 	alerted bool
 
@@ -62,6 +65,33 @@ type serviceCheckHistoryComponent struct {
 
 	// alarmErr specifies Error occurred when sending alarm.
 	alarmErr error
+
+	// alarmResults specifies result of alarm sent to each notifier backend (Ex, slack, webhook, email)
+	alarmResults AlarmResults
+
+	// alarmSuppressReason specifies the reason why latest alarm was suppressed by notification policy
+	alarmSuppressReason string
+
+	// ---
+
+	// field in below is about operation by operator and is private so call SetOperation method to set this field value
+	// operator specifies name of operator who handled service check (Ex, acknowledge, reset) manually
+	operator string
+
+	// operationReason specifies the reason why operator handled service check manually
+	operationReason string
+
+	// ---
+
+	// field in below is about dry-run mode and is private so call SetSimulated method to set this field value
+	// simulated specifies if remediation in service check process was simulated without executing (dry-run mode)
+	simulated bool
+
+	// ---
+
+	// field in below is about incident and is private so call SetIncident method to set this field value
+	// incident specifies ID of incident which service check process is related to (empty if not related)
+	incident string
 }
 
 // serviceCheckHistoryRepositoryComponent is basic interface using by embedded in every repository about service check history
@@ -70,13 +100,14 @@ type serviceCheckHistoryRepositoryComponent interface {
 	Migrate() error
 }
 
-// FillPrivateComponent FillComponent fill field of serviceCheckHistoryComponent if is empty
-func (sch *serviceCheckHistoryComponent) FillPrivateComponent() {
+// FillPrivateComponent fill private field of serviceCheckHistoryComponent with fixed value & created time received from parameter
+func (sch *serviceCheckHistoryComponent) FillPrivateComponent(now time.Time) {
 	sch.version = version
 	sch.agent = "sms-health-check"
 	sch.domain = "srvcheck"
 	sch._type = "None"
-	sch.timestamp = time.Now()
+	sch.kind = "None"
+	sch.timestamp = now
 }
 
 // DottedMapWithPrefix convert serviceCheckHistoryComponent to dotted map and return that
@@ -94,6 +125,7 @@ func (sch *serviceCheckHistoryComponent) DottedMapWithPrefix(prefix string) (m m
 	m[prefix+"@timestamp"] = sch.timestamp
 	m[prefix+"domain"] = sch.domain
 	m[prefix+"type"] = sch._type
+	m[prefix+"kind"] = sch.kind
 
 	// setting public field value in dotted map
 	m[prefix+"uuid"] = sch.UUID
@@ -110,16 +142,55 @@ func (sch *serviceCheckHistoryComponent) DottedMapWithPrefix(prefix string) (m m
 	m[prefix+"alarm_text"] = sch.alarmText
 	m[prefix+"alarm_time"] = sch.alarmTime
 	m[prefix+"alarm_error"] = sch.alarmErr
+	m[prefix+"alarm_results"] = sch.alarmResults.Maps()
+	m[prefix+"alarm_suppress_reason"] = sch.alarmSuppressReason
+
+	// setting operation field value in dotted map
+	m[prefix+"operator"] = sch.operator
+	m[prefix+"operation_reason"] = sch.operationReason
+
+	// setting dry-run field value in dotted map
+	m[prefix+"simulated"] = sch.simulated
+
+	// setting incident field value in dotted map
+	m[prefix+"incident"] = sch.incident
 
 	return
 }
 
-// SetAlarmResult set field value about alarm result with parameter
-func (sch *serviceCheckHistoryComponent) SetAlarmResult(t time.Time, text string, err error) {
-	sch.alerted = true
-	sch.alarmTime = t
-	sch.alarmText = text
-	sch.alarmErr = err
+// AddAlarmResults add result of alarm fanned out to notifier backends, it can be called for every alarm in check process
+// alarm time & text is about alarm sent first, and alarm error is joined with errors of every failed backend
+func (sch *serviceCheckHistoryComponent) AddAlarmResults(results AlarmResults) {
+	sch.alarmResults = append(sch.alarmResults, results...)
+	sch.alerted = sch.alarmResults.Sent()
+	sch.alarmTime = sch.alarmResults.Time()
+	sch.alarmText = sch.alarmResults.Text()
+	sch.alarmErr = sch.alarmResults.Err()
+	if suppressed, reason := results.Suppressed(); suppressed {
+		sch.alarmSuppressReason = reason
+	}
+}
+
+// SetOperation set field value about operation by operator with parameter
+func (sch *serviceCheckHistoryComponent) SetOperation(operator, reason string) {
+	sch.operator = operator
+	sch.operationReason = reason
+}
+
+// SetSimulated set field value representing that remediation was simulated without executing
+func (sch *serviceCheckHistoryComponent) SetSimulated() {
+	sch.simulated = true
+}
+
+// SetIncident set ID of incident which check process is related to
+func (sch *serviceCheckHistoryComponent) SetIncident(id string) {
+	sch.incident = id
+}
+
+// SetType set type tag of check instance which created history, kind of check set in FillPrivateComponent is kept
+// it is called in check instance declared with own name in config file (Ex, swarmpit-sidecar)
+func (sch *serviceCheckHistoryComponent) SetType(_type string) {
+	sch._type = _type
 }
 
 // SetError method set Message & Error field with err get from param
@@ -128,6 +199,26 @@ func (sch *serviceCheckHistoryComponent) SetError(err error) {
 	sch.Error = err
 }
 
+// Domain method returns domain of service check history, implement Domain method of CheckHistory
+func (sch *serviceCheckHistoryComponent) Domain() string { return sch.domain }
+
+// Type method returns detail check type of service check history, implement Type method of CheckHistory
+func (sch *serviceCheckHistoryComponent) Type() string { return sch._type }
+
+// Timestamp method returns created time of service check history, implement Timestamp method of CheckHistory
+func (sch *serviceCheckHistoryComponent) Timestamp() time.Time { return sch.timestamp }
+
+// ProcessLevels method returns copy of process level slice, implement ProcessLevels method of CheckHistory
+func (sch *serviceCheckHistoryComponent) ProcessLevels() []string {
+	return append([]string{}, sch.ProcessLevel...)
+}
+
+// Err method returns Error field value, implement Err method of CheckHistory
+func (sch *serviceCheckHistoryComponent) Err() error { return sch.Error }
+
+// IsAlerted method returns if alarm was sent in service check, implement IsAlerted method of CheckHistory
+func (sch *serviceCheckHistoryComponent) IsAlerted() bool { return sch.alerted }
+
 // srvcheckProcessLevel is string custom type used for representing service check process level
 type srvcheckProcessLevel []string
 
@@ -7,6 +7,7 @@ package domain
 import (
 	"context"
 	"strings"
+	"time"
 )
 
 // ConsulCheckHistory model is used for record consul check history and result
@@ -46,14 +47,34 @@ type ConsulCheckHistoryRepository interface {
 
 // ConsulCheckUseCase is interface used as business process handler about consul check
 type ConsulCheckUseCase interface {
+	// get Status, Acknowledge, Reset, Rerun method from embedding CheckController
+	CheckController
+
 	// CheckConsul method check consul status and store check history using repository
 	CheckConsul(ctx context.Context) error
 }
 
+// ConsulCheckThresholds model is used for representing target list & threshold of consul check, which is read at once in
+// every check process, it is immutable snapshot of config so that values reloaded at the same time aren't mixed
+type ConsulCheckThresholds struct {
+	// CheckTargetServices specifies services to check if registered in consul & connectable
+	CheckTargetServices []string
+
+	// ConsulServiceNameSpace specifies name space of service registered in consul (Ex, DMS.SMS.v1.service.)
+	ConsulServiceNameSpace string
+
+	// DockerServiceNameSpace specifies name space of service in docker swarm (Ex, DSM_SMS_service-)
+	DockerServiceNameSpace string
+
+	// ConnCheckPingTimeOut specifies timeout of gRPC ping to check connection of service
+	ConnCheckPingTimeOut time.Duration
+}
+
 // FillPrivateComponent overriding FillPrivateComponent method of serviceCheckHistoryComponent
-func (ch *ConsulCheckHistory) FillPrivateComponent() {
-	ch.serviceCheckHistoryComponent.FillPrivateComponent()
+func (ch *ConsulCheckHistory) FillPrivateComponent(now time.Time) {
+	ch.serviceCheckHistoryComponent.FillPrivateComponent(now)
 	ch._type = "ConsulCheck"
+	ch.kind = "ConsulCheck"
 }
 
 // DottedMapWithPrefix convert SwarmpitCheckHistory to dotted map and return using MapWithPrefixKey of upper struct
@@ -75,3 +96,14 @@ func (ch *ConsulCheckHistory) DottedMapWithPrefix(prefix string) (m map[string]i
 
 	return
 }
+
+// Remediations method returns description of remediation actions performed in consul check, implement Remediations method of CheckHistory
+func (ch *ConsulCheckHistory) Remediations() (actions []string) {
+	if ch.IfInstanceDeregistered {
+		actions = append(actions, "deregistered instances "+strings.Join(ch.DeregisteredInstances, ", "))
+	}
+	if ch.IfContainerRestarted {
+		actions = append(actions, "restarted containers "+strings.Join(ch.RestartedContainers, ", "))
+	}
+	return
+}
@@ -7,6 +7,7 @@ package domain
 import (
 	"context"
 	"strings"
+	"time"
 )
 
 // ElasticsearchCheckHistory model is used for record elasticsearch check history and result
@@ -46,14 +47,31 @@ type ElasticsearchCheckHistoryRepository interface {
 
 // ElasticsearchCheckUseCase is interface used as business process handler about elasticsearch check
 type ElasticsearchCheckUseCase interface {
+	// get Status, Acknowledge, Reset, Rerun method from embedding CheckController
+	CheckController
+
 	// CheckElasticsearch method check elasticsearch status and store check history using repository
 	CheckElasticsearch(ctx context.Context) error
 }
 
+// ElasticsearchCheckThresholds model is used for representing threshold of elasticsearch check, which is read at once in
+// every check process, it is immutable snapshot of config so that thresholds reloaded at the same time aren't mixed
+type ElasticsearchCheckThresholds struct {
+	// MaximumShardsNumber specifies maximum shards number of cluster over which jaeger index is deleted
+	MaximumShardsNumber int
+
+	// JaegerIndexPattern specifies pattern of jaeger index to delete (Ex, jaeger-*)
+	JaegerIndexPattern string
+
+	// JaegerIndexMinLifeCycle specifies minimum life cycle of jaeger index, younger index is not deleted
+	JaegerIndexMinLifeCycle time.Duration
+}
+
 // FillPrivateComponent overriding FillPrivateComponent method of serviceCheckHistoryComponent
-func (eh *ElasticsearchCheckHistory) FillPrivateComponent() {
-	eh.serviceCheckHistoryComponent.FillPrivateComponent()
+func (eh *ElasticsearchCheckHistory) FillPrivateComponent(now time.Time) {
+	eh.serviceCheckHistoryComponent.FillPrivateComponent(now)
 	eh._type = "ElasticsearchCheck"
+	eh.kind = "ElasticsearchCheck"
 }
 
 // DottedMapWithPrefix convert ElasticsearchCheckHistory to dotted map and return using MapWithPrefixKey of upper struct
@@ -88,3 +106,11 @@ func (eh *ElasticsearchCheckHistory) SetClusterHealth(cluster interface {
 	eh.UnassignedShards = cluster.UnassignedShards()
 	eh.ActiveShardsPercent = cluster.ActiveShardsPercent()
 }
+
+// Remediations method returns description of remediation actions performed in elasticsearch check, implement Remediations method of CheckHistory
+func (eh *ElasticsearchCheckHistory) Remediations() (actions []string) {
+	if eh.IfJaegerIndexDeleted {
+		actions = append(actions, "deleted jaeger indices "+strings.Join(eh.DeletedJaegerIndices, ", "))
+	}
+	return
+}
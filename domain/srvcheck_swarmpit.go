@@ -7,6 +7,7 @@ package domain
 import (
 	"context"
 	"github.com/inhies/go-bytesize"
+	"time"
 )
 
 // SwarmpitCheckHistory model is used for record swarmpit check history and result
@@ -34,14 +35,28 @@ type SwarmpitCheckHistoryRepository interface {
 
 // SwarmpitCheckUseCase is interface used as business process handler about swarmpit check
 type SwarmpitCheckUseCase interface {
+	// get Status, Acknowledge, Reset, Rerun method from embedding CheckController
+	CheckController
+
 	// CheckSwarmpit method check swarmpit status and store check history using repository
 	CheckSwarmpit(ctx context.Context) error
 }
 
+// SwarmpitCheckThresholds model is used for representing threshold of swarmpit check, which is read at once in every
+// check process, it is immutable snapshot of config so that thresholds reloaded at the same time aren't mixed
+type SwarmpitCheckThresholds struct {
+	// AppServiceName specifies name of swarmpit app service in docker swarm
+	AppServiceName string
+
+	// AppMaxMemoryUsage specifies memory usage of swarmpit app container over which container is restarted
+	AppMaxMemoryUsage bytesize.ByteSize
+}
+
 // FillPrivateComponent overriding FillPrivateComponent method of serviceCheckHistoryComponent
-func (sh *SwarmpitCheckHistory) FillPrivateComponent() {
-	sh.serviceCheckHistoryComponent.FillPrivateComponent()
+func (sh *SwarmpitCheckHistory) FillPrivateComponent(now time.Time) {
+	sh.serviceCheckHistoryComponent.FillPrivateComponent(now)
 	sh._type = "SwarmpitCheck"
+	sh.kind = "SwarmpitCheck"
 }
 
 // DottedMapWithPrefix convert SwarmpitCheckHistory to dotted map and return using MapWithPrefixKey of upper struct
@@ -59,3 +74,11 @@ func (sh *SwarmpitCheckHistory) DottedMapWithPrefix(prefix string) (m map[string
 
 	return
 }
+
+// Remediations method returns description of remediation actions performed in swarmpit check, implement Remediations method of CheckHistory
+func (sh *SwarmpitCheckHistory) Remediations() (actions []string) {
+	if sh.IfSwarmpitAppRestarted {
+		actions = append(actions, "restarted swarmpit app container")
+	}
+	return
+}
@@ -34,6 +34,9 @@ type systemCheckHistoryComponent struct {
 	// _type specifies detail service type in system check domain (Ex, DiskCheck, CPUCheck)
 	_type string
 
+	// kind specifies kind of check which created this model, same with _type if check instance isn't named (Ex, DiskCheck)
+	kind string
+
 	// ---
 
 	// public field in below, these fields don't have fixed value so set in another package from custom user
@@ -51,7 +54,7 @@ type systemCheckHistoryComponent struct {
 
 	// ---
 
-	// field in below is about alarm result and is private so call SetAlarmResult method to set this field value
+	// field in below is about alarm result and is private so call AddAlarmResults method to set this field value
 	// Alerted specifies if alert result or status in while handling system check process.
 	alerted bool
 
@@ -63,6 +66,33 @@ type systemCheckHistoryComponent struct {
 
 	// alarmErr specifies Error occurred when sending alarm.
 	alarmErr error
+
+	// alarmResults specifies result of alarm sent to each notifier backend (Ex, slack, webhook, email)
+	alarmResults AlarmResults
+
+	// alarmSuppressReason specifies the reason why latest alarm was suppressed by notification policy
+	alarmSuppressReason string
+
+	// ---
+
+	// field in below is about operation by operator and is private so call SetOperation method to set this field value
+	// operator specifies name of operator who handled system check (Ex, acknowledge, reset) manually
+	operator string
+
+	// operationReason specifies the reason why operator handled system check manually
+	operationReason string
+
+	// ---
+
+	// field in below is about dry-run mode and is private so call SetSimulated method to set this field value
+	// simulated specifies if remediation in system check process was simulated without executing (dry-run mode)
+	simulated bool
+
+	// ---
+
+	// field in below is about incident and is private so call SetIncident method to set this field value
+	// incident specifies ID of incident which system check process is related to (empty if not related)
+	incident string
 }
 
 // systemCheckHistoryRepositoryComponent is basic interface using by embedded in every repository about check history
@@ -71,13 +101,14 @@ type systemCheckHistoryRepositoryComponent interface {
 	Migrate() error
 }
 
-// FillComponent fill field of systemCheckHistoryComponent if is empty
-func (sch *systemCheckHistoryComponent) FillPrivateComponent() {
+// FillPrivateComponent fill private field of systemCheckHistoryComponent with fixed value & created time received from parameter
+func (sch *systemCheckHistoryComponent) FillPrivateComponent(now time.Time) {
 	sch.version = version
 	sch.agent = "sms-health-check"
 	sch.domain = "syscheck"
 	sch._type = "None"
-	sch.timestamp = time.Now()
+	sch.kind = "None"
+	sch.timestamp = now
 }
 
 // DottedMapWithPrefix convert systemCheckHistoryComponent to dotted map and return that
@@ -95,6 +126,7 @@ func (sch *systemCheckHistoryComponent) DottedMapWithPrefix(prefix string) (m ma
 	m[prefix+"@timestamp"] = sch.timestamp
 	m[prefix+"domain"] = sch.domain
 	m[prefix+"type"] = sch._type
+	m[prefix+"kind"] = sch.kind
 
 	// setting public field value in dotted map
 	m[prefix+"uuid"] = sch.UUID
@@ -111,16 +143,55 @@ func (sch *systemCheckHistoryComponent) DottedMapWithPrefix(prefix string) (m ma
 	m[prefix+"alarm_text"] = sch.alarmText
 	m[prefix+"alarm_time"] = sch.alarmTime
 	m[prefix+"alarm_error"] = sch.alarmErr
+	m[prefix+"alarm_results"] = sch.alarmResults.Maps()
+	m[prefix+"alarm_suppress_reason"] = sch.alarmSuppressReason
+
+	// setting operation field value in dotted map
+	m[prefix+"operator"] = sch.operator
+	m[prefix+"operation_reason"] = sch.operationReason
+
+	// setting dry-run field value in dotted map
+	m[prefix+"simulated"] = sch.simulated
+
+	// setting incident field value in dotted map
+	m[prefix+"incident"] = sch.incident
 
 	return
 }
 
-// SetAlarmResult set field value about alarm result with parameter
-func (sch *systemCheckHistoryComponent) SetAlarmResult(t time.Time, text string, err error) {
-	sch.alerted = true
-	sch.alarmTime = t
-	sch.alarmText = text
-	sch.alarmErr = err
+// AddAlarmResults add result of alarm fanned out to notifier backends, it can be called for every alarm in check process
+// alarm time & text is about alarm sent first, and alarm error is joined with errors of every failed backend
+func (sch *systemCheckHistoryComponent) AddAlarmResults(results AlarmResults) {
+	sch.alarmResults = append(sch.alarmResults, results...)
+	sch.alerted = sch.alarmResults.Sent()
+	sch.alarmTime = sch.alarmResults.Time()
+	sch.alarmText = sch.alarmResults.Text()
+	sch.alarmErr = sch.alarmResults.Err()
+	if suppressed, reason := results.Suppressed(); suppressed {
+		sch.alarmSuppressReason = reason
+	}
+}
+
+// SetOperation set field value about operation by operator with parameter
+func (sch *systemCheckHistoryComponent) SetOperation(operator, reason string) {
+	sch.operator = operator
+	sch.operationReason = reason
+}
+
+// SetSimulated set field value representing that remediation was simulated without executing
+func (sch *systemCheckHistoryComponent) SetSimulated() {
+	sch.simulated = true
+}
+
+// SetIncident set ID of incident which check process is related to
+func (sch *systemCheckHistoryComponent) SetIncident(id string) {
+	sch.incident = id
+}
+
+// SetType set type tag of check instance which created history, kind of check set in FillPrivateComponent is kept
+// it is called in check instance declared with own name in config file (Ex, swarmpit-sidecar)
+func (sch *systemCheckHistoryComponent) SetType(_type string) {
+	sch._type = _type
 }
 
 // SetError method set Message & Error field with err get from param
@@ -129,6 +200,26 @@ func (sch *systemCheckHistoryComponent) SetError(err error) {
 	sch.Error = err
 }
 
+// Domain method returns domain of system check history, implement Domain method of CheckHistory
+func (sch *systemCheckHistoryComponent) Domain() string { return sch.domain }
+
+// Type method returns detail check type of system check history, implement Type method of CheckHistory
+func (sch *systemCheckHistoryComponent) Type() string { return sch._type }
+
+// Timestamp method returns created time of system check history, implement Timestamp method of CheckHistory
+func (sch *systemCheckHistoryComponent) Timestamp() time.Time { return sch.timestamp }
+
+// ProcessLevels method returns copy of process level slice, implement ProcessLevels method of CheckHistory
+func (sch *systemCheckHistoryComponent) ProcessLevels() []string {
+	return append([]string{}, sch.ProcessLevel...)
+}
+
+// Err method returns Error field value, implement Err method of CheckHistory
+func (sch *systemCheckHistoryComponent) Err() error { return sch.Error }
+
+// IsAlerted method returns if alarm was sent in system check, implement IsAlerted method of CheckHistory
+func (sch *systemCheckHistoryComponent) IsAlerted() bool { return sch.alerted }
+
 // syscheckProcessLevel is string custom type used for representing system check process level
 type syscheckProcessLevel []string
 
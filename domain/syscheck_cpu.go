@@ -6,6 +6,8 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"time"
 )
 
 // CPUCheckHistory model is used for record cpu health check history and result
@@ -39,14 +41,31 @@ type CPUCheckHistoryRepository interface {
 
 // DiskCheckUseCase is interface used as business process handler about cpu check
 type CPUCheckUseCase interface {
+	// get Status, Acknowledge, Reset, Rerun method from embedding CheckController
+	CheckController
+
 	// CheckCPU method check cpu usage status and store cpu check history using repository
 	CheckCPU(ctx context.Context) error
 }
 
+// CPUCheckThresholds model is used for representing threshold of cpu check, which is read at once in every check process
+// it is immutable snapshot of config, so that thresholds reloaded at the same time aren't mixed in one check process
+type CPUCheckThresholds struct {
+	// WarningUsage specifies cpu usage as core count over which cpu check is warning
+	WarningUsage float64
+
+	// MaximumUsage specifies cpu usage as core count over which cpu is weak & most cpu consumed container is removed
+	MaximumUsage float64
+
+	// MinimumUsageToRemove specifies minimum cpu usage of container to decide whether remove container or not
+	MinimumUsageToRemove float64
+}
+
 // FillPrivateComponent overriding FillPrivateComponent method of systemCheckHistoryComponent
-func (ch *CPUCheckHistory) FillPrivateComponent() {
-	ch.systemCheckHistoryComponent.FillPrivateComponent()
+func (ch *CPUCheckHistory) FillPrivateComponent(now time.Time) {
+	ch.systemCheckHistoryComponent.FillPrivateComponent(now)
 	ch._type = "CPUCheck"
+	ch.kind = "CPUCheck"
 }
 
 // DottedMapWithPrefix convert CPUCheckHistory to dotted map and return using MapWithPrefixKey of upper struct
@@ -66,3 +85,11 @@ func (ch *CPUCheckHistory) DottedMapWithPrefix(prefix string) (m map[string]inte
 
 	return
 }
+
+// Remediations method returns description of remediation actions performed in cpu check, implement Remediations method of CheckHistory
+func (ch *CPUCheckHistory) Remediations() (actions []string) {
+	if ch.TemporaryFreeCore > 0 {
+		actions = append(actions, fmt.Sprintf("removed container %s, freed %.02f core", ch.MostCPUConsumeContainer, ch.TemporaryFreeCore))
+	}
+	return
+}
@@ -6,7 +6,9 @@ package domain
 
 import (
 	"context"
+	"fmt"
 	"github.com/inhies/go-bytesize"
+	"time"
 )
 
 // DiskCheckHistory model is used for record disk health check history and result
@@ -34,14 +36,25 @@ type DiskCheckHistoryRepository interface {
 
 // DiskCheckUseCase is interface used as business process handler about disk check
 type DiskCheckUseCase interface {
+	// get Status, Acknowledge, Reset, Rerun method from embedding CheckController
+	CheckController
+
 	// CheckDisk method check disk capacity status and store disk check history using repository
 	CheckDisk(ctx context.Context) error
 }
 
+// DiskCheckThresholds model is used for representing threshold of disk check, which is read at once in every check process
+// it is immutable snapshot of config, so that thresholds reloaded at the same time aren't mixed in one check process
+type DiskCheckThresholds struct {
+	// MinCapacity specifies minimum disk capacity and is standard to decide to if disk is healthy
+	MinCapacity bytesize.ByteSize
+}
+
 // FillPrivateComponent overriding FillPrivateComponent method of systemCheckHistoryComponent
-func (dh *DiskCheckHistory) FillPrivateComponent() {
-	dh.systemCheckHistoryComponent.FillPrivateComponent()
+func (dh *DiskCheckHistory) FillPrivateComponent(now time.Time) {
+	dh.systemCheckHistoryComponent.FillPrivateComponent(now)
 	dh._type = "DiskCheck"
+	dh.kind = "DiskCheck"
 }
 
 // DottedMapWithPrefix convert DiskCheckHistory to dotted map and return using MapWithPrefixKey of upper struct
@@ -59,3 +72,11 @@ func (dh *DiskCheckHistory) DottedMapWithPrefix(prefix string) (m map[string]int
 
 	return
 }
+
+// Remediations method returns description of remediation actions performed in disk check, implement Remediations method of CheckHistory
+func (dh *DiskCheckHistory) Remediations() (actions []string) {
+	if dh.ReclaimedCap > 0 {
+		actions = append(actions, fmt.Sprintf("pruned docker system, reclaimed %s", dh.ReclaimedCap))
+	}
+	return
+}
@@ -6,7 +6,9 @@ package domain
 
 import (
 	"context"
+	"fmt"
 	"github.com/inhies/go-bytesize"
+	"time"
 )
 
 // MemCheckHistory model is used for record memory health check history and result
@@ -40,14 +42,31 @@ type MemoryCheckHistoryRepository interface {
 
 // MemoryCheckUseCase is interface used as business process handler about memory check
 type MemoryCheckUseCase interface {
+	// get Status, Acknowledge, Reset, Rerun method from embedding CheckController
+	CheckController
+
 	// CheckMemory method check memory usage status and store memory check history using repository
 	CheckMemory(ctx context.Context) error
 }
 
+// MemoryCheckThresholds model is used for representing threshold of memory check, which is read at once in every check process
+// it is immutable snapshot of config, so that thresholds reloaded at the same time aren't mixed in one check process
+type MemoryCheckThresholds struct {
+	// WarningUsage specifies memory usage over which memory check is warning
+	WarningUsage bytesize.ByteSize
+
+	// MaximumUsage specifies memory usage over which memory is weak & most memory consumed container is removed
+	MaximumUsage bytesize.ByteSize
+
+	// MinimumUsageToRemove specifies minimum memory usage of container to decide whether remove container or not
+	MinimumUsageToRemove bytesize.ByteSize
+}
+
 // FillPrivateComponent overriding FillPrivateComponent method of systemCheckHistoryComponent
-func (mc *MemoryCheckHistory) FillPrivateComponent() {
-	mc.systemCheckHistoryComponent.FillPrivateComponent()
+func (mc *MemoryCheckHistory) FillPrivateComponent(now time.Time) {
+	mc.systemCheckHistoryComponent.FillPrivateComponent(now)
 	mc._type = "MemoryCheck"
+	mc.kind = "MemoryCheck"
 }
 
 // DottedMapWithPrefix convert CPUCheckHistory to dotted map and return using MapWithPrefixKey of upper struct
@@ -67,3 +86,11 @@ func (mc *MemoryCheckHistory) DottedMapWithPrefix(prefix string) (m map[string]i
 
 	return
 }
+
+// Remediations method returns description of remediation actions performed in memory check, implement Remediations method of CheckHistory
+func (mc *MemoryCheckHistory) Remediations() (actions []string) {
+	if mc.TemporaryFreeMemory > 0 {
+		actions = append(actions, fmt.Sprintf("removed container %s, freed %s", mc.MostMemoryConsumeContainer, mc.TemporaryFreeMemory))
+	}
+	return
+}
@@ -0,0 +1,86 @@
+// Create package in v.1.1.0
+// esindex package define struct which manage elasticsearch index storing check history of each domain
+// index manager migrate index, decide index to write history & roll over, delete old index out of storing history
+
+// in manager.go file, define struct type of index manager & initializer that are not method.
+// Also if exist, custom type or variable used in common in each of method will declared in this file.
+
+package esindex
+
+import (
+	"github.com/elastic/go-elasticsearch/v7"
+	"io"
+	"time"
+)
+
+// const value represent way to roll over index, returned from IndexRollover method of config
+const (
+	indexRolloverNone    = "none"    // represent that write all history to single index
+	indexRolloverDaily   = "daily"   // represent that write history to dated index per a day (Ex, sms-system-check-2021.06.01)
+	indexRolloverMonthly = "monthly" // represent that write history to dated index per a month (Ex, sms-system-check-2021.06)
+	indexRolloverAlias   = "alias"   // represent that write history to rollover alias managed with rollover API
+)
+
+// indexMaintainCycle is cycle to roll over alias & delete old index in Maintain method of indexManager
+const indexMaintainCycle = time.Hour
+
+// indexManager is struct that migrate index of domain & decide index to write history & roll over, delete old index
+// it is shared by every repository of domain using same index, so create one per domain and inject that to repositories
+type indexManager struct {
+	// myCfg is used for getting index name, shard, replica, rollover & retention config
+	myCfg config
+
+	// esCli is elasticsearch client connection used for calling index, template, rollover, delete API
+	esCli *elasticsearch.Client
+
+	// bodyWriter is implementation of reqBodyWriter interface to write []byte for request body
+	bodyWriter reqBodyWriter
+}
+
+// config is interface used as config of indexManager, you can see implementation in config package of each domain
+type config interface {
+	// IndexName method returns the index name of elasticsearch about domain
+	IndexName() string
+
+	// IndexShardNum method returns the number of index shard in elasticsearch about domain
+	IndexShardNum() int
+
+	// IndexReplicaNum method returns the number of index replica in elasticsearch about domain
+	IndexReplicaNum() int
+
+	// IndexRollover method returns how to roll over index in elasticsearch about domain (none, daily, monthly, alias)
+	IndexRollover() string
+
+	// IndexRolloverMaxAge method returns max age of write index before rolling over with alias
+	IndexRolloverMaxAge() time.Duration
+
+	// IndexRetention method returns retention period of index in elasticsearch about domain (zero is infinite)
+	IndexRetention() time.Duration
+}
+
+// reqBodyWriter is private interface to use as writing []byte for request body
+type reqBodyWriter interface {
+	io.Writer
+	io.WriterTo
+}
+
+// New return new indexManager pointer instance with config of domain, elasticsearch client & request body writer
+func New(cfg config, cli *elasticsearch.Client, w reqBodyWriter) *indexManager {
+	return &indexManager{
+		myCfg:      cfg,
+		esCli:      cli,
+		bodyWriter: w,
+	}
+}
+
+// WriteIndex return index name to write history created at time received from parameter
+func (im *indexManager) WriteIndex(t time.Time) string {
+	switch im.myCfg.IndexRollover() {
+	case indexRolloverDaily:
+		return im.myCfg.IndexName() + "-" + t.Format("2006.01.02")
+	case indexRolloverMonthly:
+		return im.myCfg.IndexName() + "-" + t.Format("2006.01")
+	default:
+		return im.myCfg.IndexName()
+	}
+}
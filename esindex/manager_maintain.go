@@ -0,0 +1,142 @@
+// Create file in v.1.1.0
+// manager_maintain.go file define method of indexManager about maintaining index rolled over from index of domain
+// maintenance is run periodically out of storing history, so that slow elasticsearch API doesn't block check process
+
+package esindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/pkg/errors"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Maintain roll over write alias & delete index which is older than retention once per indexMaintainCycle until ctx is done
+// it should be run in its own goroutine out of storing history, and error occurred while maintaining is just logged
+func (im *indexManager) Maintain(ctx context.Context) {
+	ticker := time.NewTicker(indexMaintainCycle)
+	defer ticker.Stop()
+
+	for {
+		im.maintain(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// maintain roll over write alias & delete expired index once with ctx according to rollover & retention config
+func (im *indexManager) maintain(ctx context.Context) {
+	if im.myCfg.IndexRollover() == indexRolloverAlias {
+		if err := im.rolloverAlias(ctx); err != nil {
+			log.Printf("failed to roll over index alias, alias: %s, err: %v", im.myCfg.IndexName(), err)
+		}
+	}
+
+	if im.myCfg.IndexRollover() != indexRolloverNone && im.myCfg.IndexRetention() > 0 {
+		if err := im.deleteExpiredIndices(ctx); err != nil {
+			log.Printf("failed to delete expired indices, index: %s, err: %v", im.myCfg.IndexName(), err)
+		}
+	}
+}
+
+// rolloverAlias call rollover API to create new write index if write index is older than max age
+func (im *indexManager) rolloverAlias(ctx context.Context) error {
+	b, _ := json.Marshal(map[string]interface{}{
+		"conditions": map[string]interface{}{
+			"max_age": fmt.Sprintf("%ds", int64(im.myCfg.IndexRolloverMaxAge().Seconds())),
+		},
+	})
+
+	resp, err := (esapi.IndicesRolloverRequest{
+		Alias:         im.myCfg.IndexName(),
+		Body:          bytes.NewReader(b),
+		MasterTimeout: time.Second * 5,
+		Timeout:       time.Second * 5,
+	}).Do(ctx, im.esCli)
+
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to call IndicesRollover, resp: %+v", resp))
+	} else if resp.IsError() {
+		return errors.Errorf("IndicesRollover return error code, resp: %+v", resp)
+	}
+	return nil
+}
+
+// deleteExpiredIndices delete index rolled over from index name which is created before retention period
+// the newest index is never deleted, because it could be current write index
+func (im *indexManager) deleteExpiredIndices(ctx context.Context) error {
+	resp, err := (esapi.CatIndicesRequest{
+		Index:         []string{im.myCfg.IndexName() + "-*"},
+		Format:        "JSON",
+		MasterTimeout: time.Second * 5,
+
+		H: []string{"index", "creation.date"},
+	}).Do(ctx, im.esCli)
+
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to call CatIndicesRequest, resp: %+v", resp))
+	} else if resp.IsError() {
+		return errors.Errorf("CatIndicesRequest return error code, resp: %+v", resp)
+	}
+
+	var ms []map[string]string
+	if err = json.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return errors.Wrap(err, "failed to decode resp body to map slice")
+	}
+
+	// parse creation date(epoch millis) of each index & sort in ascending order to exclude the newest index
+	created := make([]struct {
+		name string
+		date time.Time
+	}, 0, len(ms))
+	for _, m := range ms {
+		millis, err := strconv.ParseInt(m["creation.date"], 10, 64)
+		if err != nil {
+			continue
+		}
+		created = append(created, struct {
+			name string
+			date time.Time
+		}{name: m["index"], date: time.Unix(0, millis*int64(time.Millisecond))})
+	}
+	sort.Slice(created, func(i, j int) bool { return created[i].date.Before(created[j].date) })
+	if len(created) > 0 {
+		created = created[:len(created)-1]
+	}
+
+	expired := time.Now().Add(-im.myCfg.IndexRetention())
+	var indices []string
+	for _, index := range created {
+		if index.date.Before(expired) {
+			indices = append(indices, index.name)
+		}
+	}
+
+	if len(indices) == 0 {
+		return nil
+	}
+
+	delResp, err := (esapi.IndicesDeleteRequest{
+		Index:         indices,
+		MasterTimeout: time.Second * 5,
+		Timeout:       time.Second * 5,
+	}).Do(ctx, im.esCli)
+
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to call IndicesDeleteRequest, resp: %+v", delResp))
+	} else if delResp.IsError() {
+		return errors.Errorf("IndicesDeleteRequest return error code, resp: %+v", delResp)
+	}
+
+	log.Printf("deleted expired history indices, indices: %v", indices)
+	return nil
+}
@@ -0,0 +1,141 @@
+// Create file in v.1.1.0
+// manager_migrate.go file define method of indexManager about migrating index of domain
+// implement index manager interface defined in elasticsearch repository of each domain
+
+package esindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+)
+
+// Migrate method, if index doesn't exist, create index with name and shard number in config
+// if index is rolled over, put index template & create first index having write alias instead of single index
+func (im *indexManager) Migrate() error {
+	switch im.myCfg.IndexRollover() {
+	case indexRolloverNone:
+		return im.createIndexIfNotExist()
+	case indexRolloverDaily, indexRolloverMonthly:
+		return im.putIndexTemplate()
+	case indexRolloverAlias:
+		if err := im.putIndexTemplate(); err != nil {
+			return err
+		}
+		return im.createAliasIfNotExist()
+	default:
+		return errors.Errorf("unknown index rollover, rollover: %s", im.myCfg.IndexRollover())
+	}
+}
+
+// createIndexIfNotExist method create single index with name and shard number if index doesn't exist
+func (im *indexManager) createIndexIfNotExist() error {
+	resp, err := (esapi.IndicesExistsRequest{
+		Index: []string{im.myCfg.IndexName()},
+	}).Do(context.Background(), im.esCli)
+
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to call IndicesExists, resp: %+v", resp))
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		body := map[string]interface{}{}
+		body["settings.number_of_shards"] = im.myCfg.IndexShardNum()
+		body["settings.number_of_replicas"] = im.myCfg.IndexReplicaNum()
+
+		buf, err := im.writeBody(body)
+		if err != nil {
+			return err
+		}
+
+		if resp, err := (esapi.IndicesCreateRequest{
+			Index:         im.myCfg.IndexName(),
+			Body:          bytes.NewReader(buf.Bytes()),
+			MasterTimeout: time.Second * 5,
+			Timeout:       time.Second * 5,
+		}).Do(context.Background(), im.esCli); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to call IndicesCreate, resp: %+v", resp))
+		}
+	}
+
+	return nil
+}
+
+// putIndexTemplate method put index template applying shard number to every index rolled over from index name
+func (im *indexManager) putIndexTemplate() error {
+	body := map[string]interface{}{}
+	body["index_patterns"] = []string{im.myCfg.IndexName() + "-*"}
+	body["settings.number_of_shards"] = im.myCfg.IndexShardNum()
+	body["settings.number_of_replicas"] = im.myCfg.IndexReplicaNum()
+
+	buf, err := im.writeBody(body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := (esapi.IndicesPutTemplateRequest{
+		Name:          im.myCfg.IndexName(),
+		Body:          bytes.NewReader(buf.Bytes()),
+		MasterTimeout: time.Second * 5,
+	}).Do(context.Background(), im.esCli)
+
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to call IndicesPutTemplate, resp: %+v", resp))
+	} else if resp.IsError() {
+		return errors.Errorf("IndicesPutTemplate return error code, resp: %+v", resp)
+	}
+
+	return nil
+}
+
+// createAliasIfNotExist method create first index having index name as write alias if alias doesn't exist
+func (im *indexManager) createAliasIfNotExist() error {
+	resp, err := (esapi.IndicesExistsAliasRequest{
+		Name: []string{im.myCfg.IndexName()},
+	}).Do(context.Background(), im.esCli)
+
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to call IndicesExistsAlias, resp: %+v", resp))
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		body := map[string]interface{}{}
+		body["aliases."+im.myCfg.IndexName()+".is_write_index"] = true
+
+		buf, err := im.writeBody(body)
+		if err != nil {
+			return err
+		}
+
+		// Ex) sms-system-check-000001 (rollover API increase number suffix of index name)
+		if resp, err := (esapi.IndicesCreateRequest{
+			Index:         im.myCfg.IndexName() + "-000001",
+			Body:          bytes.NewReader(buf.Bytes()),
+			MasterTimeout: time.Second * 5,
+			Timeout:       time.Second * 5,
+		}).Do(context.Background(), im.esCli); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to call IndicesCreate, resp: %+v", resp))
+		}
+	}
+
+	return nil
+}
+
+// writeBody method convert dotted map to request body using body writer & return buffer
+func (im *indexManager) writeBody(body map[string]interface{}) (*bytes.Buffer, error) {
+	b, _ := json.Marshal(body)
+	if _, err := im.bodyWriter.Write(b); err != nil {
+		return nil, errors.Wrap(err, "failed to write map to body writer")
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := im.bodyWriter.WriteTo(buf); err != nil {
+		return nil, errors.Wrap(err, "failed to body writer WriteTo method")
+	}
+	return buf, nil
+}
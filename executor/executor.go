@@ -0,0 +1,54 @@
+// Create package in v.1.1.0
+// executor package define struct which run check process of usecase with single flight policy & run timeout
+// it is used in every check usecase to prevent running the same check process concurrently from channel & http delivery
+
+// in executor.go file, define struct type of check executor & initializer that are not method.
+// Also if exist, custom type or variable used in common in each of method will declared in this file.
+
+package executor
+
+import (
+	"time"
+)
+
+// const value represent policy about overlapped check process, which is set in execution config of each domain
+const (
+	overlapPolicySkip  = "skip"  // represent that new check process is skipped if previous one is running
+	overlapPolicyQueue = "queue" // represent that only one check process can wait for previous one, others are skipped
+	overlapPolicyWait  = "wait"  // represent that every check process wait for previous one
+)
+
+// process level of history created when check process isn't run to the end, which is same as const value in usecase
+const (
+	skippedLevel = "SKIPPED" // represent that check process is skipped because previous one is running
+	timeoutLevel = "TIMEOUT" // represent that check process is stopped because of run timeout or cancel
+)
+
+// checkExecutor run check process of one check usecase with single flight policy & run timeout
+type checkExecutor struct {
+	// myCfg is used for getting overlap policy & run timeout, which is read in every execution of check process
+	myCfg config
+
+	// semaphore is channel having one buffer used for single flight of check process
+	semaphore chan struct{}
+
+	// queued is set to 1 if check process is waiting for running one in queue policy
+	queued int32
+}
+
+// config is interface used as config of checkExecutor, you can see implementation in config package of each domain
+type config interface {
+	// OverlapPolicy method returns policy about check process overlapped with running one (skip, queue, wait)
+	OverlapPolicy() string
+
+	// RunTimeout method returns max duration of check process run (no timeout if zero)
+	RunTimeout() time.Duration
+}
+
+// New return new checkExecutor pointer instance with config having overlap policy & run timeout
+func New(cfg config) *checkExecutor {
+	return &checkExecutor{
+		myCfg:     cfg,
+		semaphore: make(chan struct{}, 1),
+	}
+}
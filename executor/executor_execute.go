@@ -0,0 +1,99 @@
+// Create file in v.1.1.0
+// executor_execute.go file define method of checkExecutor about executing check process
+// implement check executor interface defined in usecase of each domain
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// Execute method run check function with single flight & timeout, and handle history with handle function
+// if check process is skipped or timed out, history created from fallback function with level is handled instead
+// history of timed out check process is also handled later when check function is finished
+func (ce *checkExecutor) Execute(
+	ctx context.Context,
+	check func(ctx context.Context) domain.CheckHistory,
+	fallback func(level, message string) domain.CheckHistory,
+	handle func(history domain.CheckHistory, duration time.Duration) error,
+) error {
+	if err := ce.acquire(ctx); err != nil {
+		return handle(fallback(skippedLevel, errors.Wrap(err, "check process is skipped").Error()), 0)
+	}
+
+	start := time.Now()
+	var runCtx context.Context
+	var cancel context.CancelFunc
+	if timeout := ce.myCfg.RunTimeout(); timeout > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		runCtx, cancel = context.WithCancel(ctx)
+	}
+
+	historyC := make(chan domain.CheckHistory, 1)
+	go func() {
+		defer func() { <-ce.semaphore }()
+		historyC <- check(runCtx)
+	}()
+
+	select {
+	case history := <-historyC:
+		cancel()
+		return handle(history, time.Since(start))
+	case <-runCtx.Done():
+		msg := fmt.Sprintf("check process is stopped after %s, err: %v", time.Since(start).Round(time.Millisecond), runCtx.Err())
+		err := handle(fallback(timeoutLevel, msg), time.Since(start))
+
+		// late history is handled in WaitGroup of context (if exist), so that graceful shutdown wait for storing that
+		wg, _ := ctx.Value("WaitGroup").(*sync.WaitGroup)
+		if wg != nil {
+			wg.Add(1)
+		}
+		go func() {
+			defer cancel()
+			if wg != nil {
+				defer wg.Done()
+			}
+			if err := handle(<-historyC, time.Since(start)); err != nil {
+				log.Printf("failed to handle history of stopped check process, err: %v", err)
+			}
+		}()
+		return err
+	}
+}
+
+// acquire method acquire semaphore according to overlap policy, return error if check process should be skipped
+func (ce *checkExecutor) acquire(ctx context.Context) error {
+	select {
+	case ce.semaphore <- struct{}{}:
+		return nil
+	default:
+	}
+
+	switch ce.myCfg.OverlapPolicy() {
+	case overlapPolicyQueue:
+		if !atomic.CompareAndSwapInt32(&ce.queued, 0, 1) {
+			return errors.New("previous check process is running and another one is already queued")
+		}
+		defer atomic.StoreInt32(&ce.queued, 0)
+	case overlapPolicyWait:
+		break
+	default:
+		return errors.New("previous check process is still running")
+	}
+
+	select {
+	case ce.semaphore <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "context is done while waiting for previous check process")
+	}
+}
@@ -0,0 +1,192 @@
+package executor
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// testConfig is config of checkExecutor used in test, which has fixed overlap policy & run timeout
+type testConfig struct {
+	policy  string
+	timeout time.Duration
+}
+
+func (tc testConfig) OverlapPolicy() string     { return tc.policy }
+func (tc testConfig) RunTimeout() time.Duration { return tc.timeout }
+
+// testRun is helper running check process in checkExecutor & recording level of every handled history
+type testRun struct {
+	executor *checkExecutor
+	mutex    sync.Mutex
+	levels   []string
+}
+
+func newTestRun(policy string, timeout time.Duration) *testRun {
+	return &testRun{executor: New(testConfig{policy: policy, timeout: timeout})}
+}
+
+// execute run check process returning history with level after release channel is closed, started is closed when run
+func (tr *testRun) execute(ctx context.Context, level string, started, release chan struct{}) error {
+	return tr.executor.Execute(ctx, func(ctx context.Context) domain.CheckHistory {
+		close(started)
+		<-release
+		return newHistory(level)
+	}, func(level, message string) domain.CheckHistory {
+		return newHistory(level)
+	}, func(history domain.CheckHistory, duration time.Duration) error {
+		tr.mutex.Lock()
+		defer tr.mutex.Unlock()
+		tr.levels = append(tr.levels, history.ProcessLevels()...)
+		return nil
+	})
+}
+
+// handled return copy of levels of every history handled until now
+func (tr *testRun) handled() []string {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	return append([]string{}, tr.levels...)
+}
+
+func newHistory(level string) domain.CheckHistory {
+	history := new(domain.CPUCheckHistory)
+	history.ProcessLevel.Set(level)
+	return history
+}
+
+// waitFor wait until condition is true, and fail test if it isn't true in a second
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if condition() {
+			return
+		}
+	}
+	t.Fatal("condition isn't satisfied in a second")
+}
+
+func assertLevels(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("handled levels = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("handled levels = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestExecuteSkipPolicy(t *testing.T) {
+	tr := newTestRun(overlapPolicySkip, 0)
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error)
+	go func() { done <- tr.execute(context.Background(), "FIRST", started, release) }()
+	<-started
+
+	if err := tr.execute(context.Background(), "SECOND", make(chan struct{}), release); err != nil {
+		t.Fatalf("unexpected error, err: %v", err)
+	}
+	assertLevels(t, tr.handled(), skippedLevel)
+
+	close(release)
+	<-done
+	assertLevels(t, tr.handled(), skippedLevel, "FIRST")
+}
+
+func TestExecuteQueuePolicy(t *testing.T) {
+	tr := newTestRun(overlapPolicyQueue, 0)
+	started, release, done := make(chan struct{}), make(chan struct{}), make(chan error)
+	go func() { done <- tr.execute(context.Background(), "FIRST", started, release) }()
+	<-started
+
+	queuedStarted, queuedRelease, queuedDone := make(chan struct{}), make(chan struct{}), make(chan error)
+	go func() { queuedDone <- tr.execute(context.Background(), "QUEUED", queuedStarted, queuedRelease) }()
+	waitFor(t, func() bool { return atomic.LoadInt32(&tr.executor.queued) == 1 })
+
+	// only one check process can wait in queue, so another one is skipped
+	if err := tr.execute(context.Background(), "THIRD", make(chan struct{}), release); err != nil {
+		t.Fatalf("unexpected error, err: %v", err)
+	}
+	assertLevels(t, tr.handled(), skippedLevel)
+
+	// queued check process is run after previous one is finished
+	close(release)
+	<-done
+	<-queuedStarted
+	assertLevels(t, tr.handled(), skippedLevel, "FIRST")
+
+	close(queuedRelease)
+	<-queuedDone
+	assertLevels(t, tr.handled(), skippedLevel, "FIRST", "QUEUED")
+}
+
+func TestExecuteWaitPolicy(t *testing.T) {
+	tr := newTestRun(overlapPolicyWait, 0)
+	started, release, done := make(chan struct{}), make(chan struct{}), make(chan error)
+	go func() { done <- tr.execute(context.Background(), "FIRST", started, release) }()
+	<-started
+
+	// every check process wait for previous one, and run after that in turn
+	waitRelease, waitDone := make(chan struct{}), make(chan error, 2)
+	go func() { waitDone <- tr.execute(context.Background(), "WAITING", make(chan struct{}), waitRelease) }()
+	go func() { waitDone <- tr.execute(context.Background(), "WAITING", make(chan struct{}), waitRelease) }()
+	time.Sleep(time.Millisecond * 10)
+	assertLevels(t, tr.handled())
+
+	close(release)
+	<-done
+	assertLevels(t, tr.handled(), "FIRST")
+
+	close(waitRelease)
+	<-waitDone
+	<-waitDone
+	assertLevels(t, tr.handled(), "FIRST", "WAITING", "WAITING")
+}
+
+func TestExecuteWaitPolicyCanceled(t *testing.T) {
+	tr := newTestRun(overlapPolicyWait, 0)
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error)
+	go func() { done <- tr.execute(context.Background(), "FIRST", started, release) }()
+	<-started
+
+	// check process waiting for previous one is skipped if context is done while waiting
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	if err := tr.execute(ctx, "WAITING", make(chan struct{}), release); err != nil {
+		t.Fatalf("unexpected error, err: %v", err)
+	}
+	assertLevels(t, tr.handled(), skippedLevel)
+
+	close(release)
+	<-done
+}
+
+func TestExecuteTimeoutHandleLateHistory(t *testing.T) {
+	tr := newTestRun(overlapPolicySkip, time.Millisecond*10)
+	wg := &sync.WaitGroup{}
+	ctx := context.WithValue(context.Background(), "WaitGroup", wg)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	if err := tr.execute(ctx, "LATE", started, release); err != nil {
+		t.Fatalf("unexpected error, err: %v", err)
+	}
+	assertLevels(t, tr.handled(), timeoutLevel)
+
+	// check process stopped by timeout still holds semaphore until it's finished
+	if err := tr.execute(context.Background(), "SECOND", make(chan struct{}), release); err != nil {
+		t.Fatalf("unexpected error, err: %v", err)
+	}
+	assertLevels(t, tr.handled(), timeoutLevel, skippedLevel)
+
+	// history of stopped check process is handled later in WaitGroup of context
+	close(release)
+	wg.Wait()
+	assertLevels(t, tr.handled(), timeoutLevel, skippedLevel, "LATE")
+}
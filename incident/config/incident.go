@@ -0,0 +1,122 @@
+// Create package in v.1.1.0
+// config package contains App global variable with config value about incident from environment variable or config file
+// App return field value from method having same name with that field name
+
+// incident.go is file that define incidentConfig type which is type of App
+// Also, App implement various config interface each of package in incident domain by declaring method
+
+package config
+
+import (
+	"github.com/spf13/viper"
+	"time"
+)
+
+// App is the application config about incident domain
+var App *incidentConfig
+
+// incidentConfig having config value and implement various interface about Config by declaring method
+type incidentConfig struct {
+	// fields about index information in elasticsearch (implement esIncidentRepositoryConfig)
+	// indexName represent name of elasticsearch index including incident document
+	indexName *string
+
+	// indexShardNum represent shard number of elasticsearch index storing incident document
+	indexShardNum *int
+
+	// indexReplicaNum represent replica number of elasticsearch index to replace index when node become unable
+	indexReplicaNum *int
+
+	// fetchTimeout represent max duration of fetching incidents from elasticsearch
+	fetchTimeout *time.Duration
+
+	// ---
+
+	// fields using in incident usecase (implement incidentUsecaseConfig)
+	// defaultFetchSize represent number of incidents returned when size isn't specified in filter
+	defaultFetchSize *int
+}
+
+// default const value about incidentConfig field
+const (
+	defaultIndexName       = "sms-incident"   // default const string for indexName
+	defaultIndexShardNum   = 1                // default const int for indexShardNum
+	defaultIndexReplicaNum = 0                // default const int for indexReplicaNum
+	defaultFetchTimeout    = time.Second * 10 // default const Duration for fetchTimeout
+
+	defaultDefaultFetchSize = 20 // default const int for defaultFetchSize
+)
+
+// implement IndexName method of esIncidentRepositoryConfig interface
+func (ic *incidentConfig) IndexName() string {
+	var key = "incident.repository.elasticsearch.index.name"
+	if ic.indexName == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, defaultIndexName)
+		}
+		ic.indexName = _string(viper.GetString(key))
+	}
+	return *ic.indexName
+}
+
+// implement IndexShardNum method of esIncidentRepositoryConfig interface
+func (ic *incidentConfig) IndexShardNum() int {
+	var key = "incident.repository.elasticsearch.index.shardNum"
+	if ic.indexShardNum == nil {
+		if _, ok := viper.Get(key).(int); !ok {
+			viper.Set(key, defaultIndexShardNum)
+		}
+		ic.indexShardNum = _int(viper.GetInt(key))
+	}
+	return *ic.indexShardNum
+}
+
+// implement IndexReplicaNum method of esIncidentRepositoryConfig interface
+func (ic *incidentConfig) IndexReplicaNum() int {
+	var key = "incident.repository.elasticsearch.index.replicaNum"
+	if ic.indexReplicaNum == nil {
+		if _, ok := viper.Get(key).(int); !ok {
+			viper.Set(key, defaultIndexReplicaNum)
+		}
+		ic.indexReplicaNum = _int(viper.GetInt(key))
+	}
+	return *ic.indexReplicaNum
+}
+
+// implement FetchTimeout method of esIncidentRepositoryConfig interface
+func (ic *incidentConfig) FetchTimeout() time.Duration {
+	var key = "incident.repository.elasticsearch.fetchTimeout"
+	if ic.fetchTimeout != nil {
+		return *ic.fetchTimeout
+	}
+
+	d, err := time.ParseDuration(viper.GetString(key))
+	if err != nil || d <= 0 {
+		viper.Set(key, defaultFetchTimeout.String())
+		d = defaultFetchTimeout
+	}
+
+	ic.fetchTimeout = &d
+	return *ic.fetchTimeout
+}
+
+// implement DefaultFetchSize method of incidentUsecaseConfig interface
+func (ic *incidentConfig) DefaultFetchSize() int {
+	var key = "incident.defaultFetchSize"
+	if ic.defaultFetchSize == nil {
+		if v, ok := viper.Get(key).(int); !ok || v <= 0 {
+			viper.Set(key, defaultDefaultFetchSize)
+		}
+		ic.defaultFetchSize = _int(viper.GetInt(key))
+	}
+	return *ic.defaultFetchSize
+}
+
+// init function initialize App global variable
+func init() {
+	App = &incidentConfig{}
+}
+
+// function returns pointer variable generated from parameter
+func _string(s string) *string { return &s }
+func _int(i int) *int          { return &i }
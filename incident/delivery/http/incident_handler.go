@@ -0,0 +1,162 @@
+// Create package in v.1.1.0
+// http package is for delivery layer exposing incident of incident domain to HTTP API endpoint
+// incidents are listed with filter in query & statistics of them (Ex, MTTD, MTTR), or one incident is looked up with ID
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// incidentHandler represent the http handler for incident
+type incidentHandler struct {
+	iUsecase domain.IncidentUseCase
+}
+
+// readerAuthenticator is interface that authenticate request to endpoint reading incident
+// you can see implementation in auth package
+type readerAuthenticator interface {
+	// AuthenticateReader return gin middleware which abort request if credential having reader role is not authenticated
+	AuthenticateReader() gin.HandlerFunc
+}
+
+// NewIncidentHandler initialize the resources of incident domain to HTTP API endpoint
+func NewIncidentHandler(r *gin.Engine, ra readerAuthenticator, iu domain.IncidentUseCase) {
+	h := &incidentHandler{
+		iUsecase: iu,
+	}
+
+	r.GET("incidents", ra.AuthenticateReader(), h.GetIncidents)
+	r.GET("incidents/:id", ra.AuthenticateReader(), h.GetIncident)
+}
+
+// GetIncidents method respond incidents matched with filter in query & statistics of them, recently opened one first
+// filter is set with ?domain=, ?type= (Ex, DiskCheck), ?state= (open, closed), ?from=, ?to= (RFC3339) & ?size= in query
+func (ih *incidentHandler) GetIncidents(c *gin.Context) {
+	filter := domain.IncidentFilter{
+		Domain: c.Query("domain"),
+		Type:   c.Query("type"),
+		State:  c.Query("state"),
+	}
+
+	if filter.State != "" && !strings.EqualFold(filter.State, domain.IncidentStateOpen) && !strings.EqualFold(filter.State, domain.IncidentStateClosed) {
+		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "code": 0, "message": "state in query must be open or closed"})
+		return
+	}
+
+	for key, t := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		if s := c.Query(key); s != "" {
+			parsed, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"status": http.StatusBadRequest, "code": 0,
+					"message": errors.Wrapf(err, "%s in query must be RFC3339 time", key).Error(),
+				})
+				return
+			}
+			*t = parsed
+		}
+	}
+
+	if s := c.Query("size"); s != "" {
+		size, err := strconv.Atoi(s)
+		if err != nil || size <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "code": 0, "message": "size in query must be positive integer"})
+			return
+		}
+		filter.Size = size
+	}
+
+	incidents, stats, err := ih.iUsecase.GetIncidents(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": http.StatusInternalServerError, "code": 0,
+			"message": errors.Wrap(err, "failed to get incidents").Error(),
+		})
+		return
+	}
+
+	results := make([]gin.H, len(incidents))
+	for i, incident := range incidents {
+		results[i] = incidentToJSON(incident)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": http.StatusOK, "code": 0, "message": "succeed to get incidents matched with filter",
+		"incidents": results,
+		"stats": gin.H{
+			"total":        stats.Total,
+			"open":         stats.Open,
+			"mttd_seconds": stats.MTTD.Seconds(),
+			"mttr_seconds": stats.MTTR.Seconds(),
+		},
+	})
+}
+
+// GetIncident method respond incident with ID in path, which is uuid of check process opening incident
+func (ih *incidentHandler) GetIncident(c *gin.Context) {
+	incident, err := ih.iUsecase.GetIncident(c.Request.Context(), c.Param("id"))
+	switch err {
+	case nil:
+		break
+	case domain.ErrIncidentNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "code": 0, "message": err.Error()})
+		return
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": http.StatusInternalServerError, "code": 0,
+			"message": errors.Wrap(err, "failed to get incident").Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": http.StatusOK, "code": 0, "message": "succeed to get incident",
+		"incident": incidentToJSON(incident),
+	})
+}
+
+// incidentToJSON convert domain.Incident to JSON object used in response of HTTP API
+func incidentToJSON(incident domain.Incident) gin.H {
+	remediations := make([]gin.H, len(incident.Remediations))
+	for i, r := range incident.Remediations {
+		remediations[i] = gin.H{"uuid": r.UUID, "time": r.Time, "action": r.Action, "simulated": r.Simulated}
+	}
+
+	alarms := make([]gin.H, len(incident.Alarms))
+	for i, a := range incident.Alarms {
+		alarms[i] = gin.H{"uuid": a.UUID, "time": a.Time, "level": a.Level, "text": a.Text}
+	}
+
+	result := gin.H{
+		"id":                      incident.ID,
+		"domain":                  incident.Domain,
+		"type":                    incident.Type,
+		"state":                   incident.State(),
+		"opened_level":            incident.OpenedLevel,
+		"opened_at":               incident.OpenedAt,
+		"last_healthy_at":         nil,
+		"closed_level":            incident.ClosedLevel,
+		"closed_at":               nil,
+		"closed_by":               incident.ClosedBy,
+		"time_to_detect_seconds":  incident.TimeToDetect().Seconds(),
+		"time_to_recover_seconds": incident.TimeToRecover().Seconds(),
+		"runs":                    incident.Runs,
+		"remediations":            remediations,
+		"alarms":                  alarms,
+	}
+	if !incident.LastHealthyAt.IsZero() {
+		result["last_healthy_at"] = incident.LastHealthyAt
+	}
+	if !incident.ClosedAt.IsZero() {
+		result["closed_at"] = incident.ClosedAt
+	}
+	return result
+}
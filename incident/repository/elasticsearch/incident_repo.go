@@ -0,0 +1,347 @@
+// Create package in v.1.1.0
+// elasticsearch package is for implementations of incident domain repository using elasticsearch
+// incident is stored as one document per incident with ID of incident, so that it's overwritten whenever updated
+
+// incident_repo.go is file that define implement incident repository using elasticsearch
+// incident model is converted to incidentDocument having field in snake case before stored in elasticsearch
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// maxFetchSize is max number of incidents fetched in one search request, which is default max result window of index
+const maxFetchSize = 10000
+
+// esIncidentRepository is to handle Incident model using elasticsearch as data store
+type esIncidentRepository struct {
+	// myCfg is used for get incident repository config about elasticsearch
+	myCfg esIncidentRepositoryConfig
+
+	// esCli is elasticsearch client connection injected from the outside package
+	esCli *elasticsearch.Client
+}
+
+// esIncidentRepositoryConfig is the config for incident repository using elasticsearch
+type esIncidentRepositoryConfig interface {
+	// IndexName method returns the index name of elasticsearch about incident
+	IndexName() string
+
+	// IndexShardNum method returns the number of index shard in elasticsearch about incident
+	IndexShardNum() int
+
+	// IndexReplicaNum method returns the number of index replica in elasticsearch about incident
+	IndexReplicaNum() int
+
+	// FetchTimeout method returns max duration of fetching incidents
+	FetchTimeout() time.Duration
+}
+
+// incidentDocument is binding struct of incident document stored in elasticsearch
+type incidentDocument struct {
+	ID                   string                `json:"id"`
+	Domain               string                `json:"domain"`
+	Type                 string                `json:"type"`
+	State                string                `json:"state"`
+	OpenedLevel          string                `json:"opened_level"`
+	OpenedAt             time.Time             `json:"opened_at"`
+	LastHealthyAt        *time.Time            `json:"last_healthy_at,omitempty"`
+	ClosedLevel          string                `json:"closed_level"`
+	ClosedAt             *time.Time            `json:"closed_at,omitempty"`
+	ClosedBy             string                `json:"closed_by"`
+	TimeToDetectSeconds  float64               `json:"time_to_detect_seconds"`
+	TimeToRecoverSeconds float64               `json:"time_to_recover_seconds"`
+	Runs                 []string              `json:"runs"`
+	Remediations         []remediationDocument `json:"remediations"`
+	Alarms               []alarmDocument       `json:"alarms"`
+}
+
+// remediationDocument is binding struct of remediation action in incident document
+type remediationDocument struct {
+	UUID      string    `json:"uuid"`
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	Simulated bool      `json:"simulated"`
+}
+
+// alarmDocument is binding struct of alarm in incident document
+type alarmDocument struct {
+	UUID  string    `json:"uuid"`
+	Time  time.Time `json:"time"`
+	Level string    `json:"level"`
+	Text  string    `json:"text"`
+}
+
+// searchResponse is binding struct of response body about search request
+type searchResponse struct {
+	Hits struct {
+		Hits []struct {
+			Source incidentDocument `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// getResponse is binding struct of response body about get request
+type getResponse struct {
+	Found  bool             `json:"found"`
+	Source incidentDocument `json:"_source"`
+}
+
+// NewESIncidentRepository return new object that implement IncidentRepository interface
+func NewESIncidentRepository(cfg esIncidentRepositoryConfig, cli *elasticsearch.Client) domain.IncidentRepository {
+	repo := &esIncidentRepository{
+		myCfg: cfg,
+		esCli: cli,
+	}
+
+	if err := repo.Migrate(); err != nil {
+		log.Fatal(errors.Wrap(err, "could not migrate repository").Error())
+	}
+
+	return repo
+}
+
+// Implement Migrate method of IncidentRepository interface
+// if index doesn't exist, create index with shard number & mapping of field used in filter as keyword or date
+func (eir *esIncidentRepository) Migrate() error {
+	resp, err := (esapi.IndicesExistsRequest{
+		Index: []string{eir.myCfg.IndexName()},
+	}).Do(context.Background(), eir.esCli)
+
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to call IndicesExists, resp: %+v", resp))
+	}
+
+	if resp.StatusCode != http.StatusNotFound {
+		return nil
+	}
+
+	keyword, date := map[string]interface{}{"type": "keyword"}, map[string]interface{}{"type": "date"}
+	body, _ := json.Marshal(map[string]interface{}{
+		"settings": map[string]interface{}{
+			"number_of_shards":   eir.myCfg.IndexShardNum(),
+			"number_of_replicas": eir.myCfg.IndexReplicaNum(),
+		},
+		"mappings": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"id": keyword, "domain": keyword, "type": keyword, "state": keyword,
+				"opened_level": keyword, "closed_level": keyword, "runs": keyword,
+				"opened_at": date, "closed_at": date, "last_healthy_at": date,
+			},
+		},
+	})
+
+	resp, err = (esapi.IndicesCreateRequest{
+		Index:         eir.myCfg.IndexName(),
+		Body:          bytes.NewReader(body),
+		MasterTimeout: time.Second * 5,
+		Timeout:       time.Second * 5,
+	}).Do(context.Background(), eir.esCli)
+
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to call IndicesCreate, resp: %+v", resp))
+	} else if resp.IsError() {
+		return errors.Errorf("IndicesCreate return error code, resp: %+v", resp)
+	}
+
+	return nil
+}
+
+// Implement Store method of IncidentRepository interface
+func (eir *esIncidentRepository) Store(incident domain.Incident) (b []byte, err error) {
+	body, _ := json.Marshal(documentFromIncident(incident))
+
+	resp, err := (esapi.IndexRequest{
+		Index:      eir.myCfg.IndexName(),
+		DocumentID: incident.ID,
+		Body:       bytes.NewReader(body),
+		Timeout:    time.Second * 5,
+	}).Do(context.Background(), eir.esCli)
+
+	if err != nil {
+		err = errors.Wrap(err, fmt.Sprintf("failed to call IndexRequest, resp: %+v", resp))
+		return
+	} else if resp.IsError() {
+		err = errors.Errorf("IndexRequest return error code, resp: %+v", resp)
+		return
+	}
+
+	result := map[string]interface{}{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	b, _ = json.Marshal(result)
+	return
+}
+
+// Implement FetchIncidents method of IncidentRepository interface
+func (eir *esIncidentRepository) FetchIncidents(ctx context.Context, filter domain.IncidentFilter) (incidents []domain.Incident, err error) {
+	ctx, cancel := context.WithTimeout(ctx, eir.myCfg.FetchTimeout())
+	defer cancel()
+
+	filters := []interface{}{}
+	for field, value := range map[string]string{"domain": filter.Domain, "type": filter.Type, "state": filter.State} {
+		if value != "" {
+			filters = append(filters, map[string]interface{}{"term": map[string]interface{}{field: value}})
+		}
+	}
+	if !filter.From.IsZero() || !filter.To.IsZero() {
+		openedAt := map[string]interface{}{}
+		if !filter.From.IsZero() {
+			openedAt["gte"] = filter.From.Format(time.RFC3339Nano)
+		}
+		if !filter.To.IsZero() {
+			openedAt["lt"] = filter.To.Format(time.RFC3339Nano)
+		}
+		filters = append(filters, map[string]interface{}{"range": map[string]interface{}{"opened_at": openedAt}})
+	}
+
+	size := filter.Size
+	if size <= 0 || size > maxFetchSize {
+		size = maxFetchSize
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"size":  size,
+		"sort":  []interface{}{map[string]interface{}{"opened_at": "desc"}},
+		"query": map[string]interface{}{"bool": map[string]interface{}{"filter": filters}},
+	})
+
+	resp, err := (esapi.SearchRequest{
+		Index: []string{eir.myCfg.IndexName()},
+		Body:  bytes.NewReader(body),
+	}).Do(ctx, eir.esCli)
+
+	if err != nil {
+		err = errors.Wrap(err, "failed to call SearchRequest")
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.IsError() {
+		err = errors.Errorf("SearchRequest return error code, resp: %+v", resp)
+		return
+	}
+
+	result := searchResponse{}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		err = errors.Wrap(err, "failed to decode response body")
+		return
+	}
+
+	incidents = make([]domain.Incident, len(result.Hits.Hits))
+	for i, hit := range result.Hits.Hits {
+		incidents[i] = hit.Source.incident()
+	}
+	return
+}
+
+// Implement FetchIncident method of IncidentRepository interface
+func (eir *esIncidentRepository) FetchIncident(ctx context.Context, id string) (incident domain.Incident, err error) {
+	ctx, cancel := context.WithTimeout(ctx, eir.myCfg.FetchTimeout())
+	defer cancel()
+
+	resp, err := (esapi.GetRequest{
+		Index:      eir.myCfg.IndexName(),
+		DocumentID: id,
+	}).Do(ctx, eir.esCli)
+
+	if err != nil {
+		err = errors.Wrap(err, "failed to call GetRequest")
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		err = domain.ErrIncidentNotFound
+		return
+	} else if resp.IsError() {
+		err = errors.Errorf("GetRequest return error code, resp: %+v", resp)
+		return
+	}
+
+	result := getResponse{}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		err = errors.Wrap(err, "failed to decode response body")
+		return
+	}
+
+	if !result.Found {
+		err = domain.ErrIncidentNotFound
+		return
+	}
+	incident = result.Source.incident()
+	return
+}
+
+// documentFromIncident convert domain.Incident to incidentDocument stored in elasticsearch
+func documentFromIncident(incident domain.Incident) (doc incidentDocument) {
+	doc = incidentDocument{
+		ID:                   incident.ID,
+		Domain:               incident.Domain,
+		Type:                 incident.Type,
+		State:                incident.State(),
+		OpenedLevel:          incident.OpenedLevel,
+		OpenedAt:             incident.OpenedAt,
+		ClosedLevel:          incident.ClosedLevel,
+		ClosedBy:             incident.ClosedBy,
+		TimeToDetectSeconds:  incident.TimeToDetect().Seconds(),
+		TimeToRecoverSeconds: incident.TimeToRecover().Seconds(),
+		Runs:                 append([]string{}, incident.Runs...),
+		Remediations:         make([]remediationDocument, len(incident.Remediations)),
+		Alarms:               make([]alarmDocument, len(incident.Alarms)),
+	}
+
+	if !incident.LastHealthyAt.IsZero() {
+		doc.LastHealthyAt = &incident.LastHealthyAt
+	}
+	if !incident.ClosedAt.IsZero() {
+		doc.ClosedAt = &incident.ClosedAt
+	}
+	for i, r := range incident.Remediations {
+		doc.Remediations[i] = remediationDocument{UUID: r.UUID, Time: r.Time, Action: r.Action, Simulated: r.Simulated}
+	}
+	for i, a := range incident.Alarms {
+		doc.Alarms[i] = alarmDocument{UUID: a.UUID, Time: a.Time, Level: a.Level, Text: a.Text}
+	}
+	return
+}
+
+// incident method convert incidentDocument fetched from elasticsearch to domain.Incident
+func (doc incidentDocument) incident() (incident domain.Incident) {
+	incident = domain.Incident{
+		ID:           doc.ID,
+		Domain:       doc.Domain,
+		Type:         doc.Type,
+		OpenedLevel:  doc.OpenedLevel,
+		OpenedAt:     doc.OpenedAt,
+		ClosedLevel:  doc.ClosedLevel,
+		ClosedBy:     doc.ClosedBy,
+		Runs:         doc.Runs,
+		Remediations: make([]domain.IncidentRemediation, len(doc.Remediations)),
+		Alarms:       make([]domain.IncidentAlarm, len(doc.Alarms)),
+	}
+
+	if doc.LastHealthyAt != nil {
+		incident.LastHealthyAt = *doc.LastHealthyAt
+	}
+	if doc.ClosedAt != nil {
+		incident.ClosedAt = *doc.ClosedAt
+	}
+	for i, r := range doc.Remediations {
+		incident.Remediations[i] = domain.IncidentRemediation{UUID: r.UUID, Time: r.Time, Action: r.Action, Simulated: r.Simulated}
+	}
+	for i, a := range doc.Alarms {
+		incident.Alarms[i] = domain.IncidentAlarm{UUID: a.UUID, Time: a.Time, Level: a.Level, Text: a.Text}
+	}
+	return
+}
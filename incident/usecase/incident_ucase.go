@@ -0,0 +1,70 @@
+// Create package in v.1.1.0
+// usecase package declare implementation of usecase interface about incident domain
+// all usecase implementation will accept any input from Delivery layer
+// This usecase layer will depends to Repository layer
+
+// incident_ucase.go is file that define usecase implementation about incident opened & closed in check usecase
+// incident is stored by check usecase of syscheck, srvcheck domain, so this usecase only read incident from repository
+
+package usecase
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"strings"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// incidentUsecase implement IncidentUseCase interface in domain and used in delivery layer
+type incidentUsecase struct {
+	// myCfg is used for getting incident usecase config
+	myCfg incidentUsecaseConfig
+
+	// incidentRepo is used for fetching incident stored by check usecase and injected from outside
+	incidentRepo domain.IncidentRepository
+}
+
+// incidentUsecaseConfig is interface get config value for incident usecase
+type incidentUsecaseConfig interface {
+	// DefaultFetchSize method returns number of incidents returned when size isn't specified in filter
+	DefaultFetchSize() int
+}
+
+// NewIncidentUsecase function return incidentUsecase ptr instance after initializing
+func NewIncidentUsecase(
+	cfg incidentUsecaseConfig,
+	ir domain.IncidentRepository,
+) domain.IncidentUseCase {
+	return &incidentUsecase{
+		myCfg:        cfg,
+		incidentRepo: ir,
+	}
+}
+
+// GetIncidents fetch incidents matched with filter from repository & return them with statistics (Ex, MTTD, MTTR)
+// Implement GetIncidents method of domain.IncidentUseCase interface
+func (iu *incidentUsecase) GetIncidents(ctx context.Context, filter domain.IncidentFilter) (incidents []domain.Incident, stats domain.IncidentStats, err error) {
+	if filter.Size <= 0 {
+		filter.Size = iu.myCfg.DefaultFetchSize()
+	}
+	filter.State = strings.ToUpper(filter.State)
+
+	if incidents, err = iu.incidentRepo.FetchIncidents(ctx, filter); err != nil {
+		err = errors.Wrap(err, "failed to fetch incidents from repository")
+		return
+	}
+
+	stats = domain.IncidentStatsOf(incidents)
+	return
+}
+
+// GetIncident fetch incident with ID from repository, return domain.ErrIncidentNotFound if not exist
+// Implement GetIncident method of domain.IncidentUseCase interface
+func (iu *incidentUsecase) GetIncident(ctx context.Context, id string) (incident domain.Incident, err error) {
+	incident, err = iu.incidentRepo.FetchIncident(ctx, id)
+	if err != nil && err != domain.ErrIncidentNotFound {
+		err = errors.Wrap(err, "failed to fetch incident from repository")
+	}
+	return
+}
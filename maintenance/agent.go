@@ -0,0 +1,179 @@
+// Create package in v.1.1.0
+// maintenance package define struct which is implement various interface about maintenance window
+// maintenance window pause check process or suppress remediation of check usecase in specific time range
+
+// in agent.go file, define struct type of maintenance agent & initializer that are not method.
+// Also if exist, custom type or variable used in common in each of method will declared in this file.
+
+package maintenance
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// maintenanceAgent manage maintenance windows in memory and implement maintenance agency interface in usecase
+type maintenanceAgent struct {
+	// location is timezone used for parsing time of maintenance window, which is timezone of clock
+	location *time.Location
+
+	// clock is used for getting current time to decide if maintenance window is active or expired
+	clock clock
+
+	// windows is list of maintenance window added in agent
+	windows []window
+
+	// mutex help to prevent race condition when access windows field
+	mutex sync.RWMutex
+}
+
+// window is struct having maintenance window model & time range parsed from that model
+type window struct {
+	domain.MaintenanceWindow
+
+	// weekday, startClock, endClock is used if window is repeated every week
+	weekly               bool
+	weekday              time.Weekday
+	startClock, endClock time.Duration
+
+	// start, end is used if window is one-off
+	start, end time.Time
+}
+
+// clock is interface that return current time & timezone, so that time can be faked in test
+// you can see implementation in clock package
+type clock interface {
+	// Now method returns current time in timezone of clock
+	Now() time.Time
+
+	// Location method returns timezone of clock
+	Location() *time.Location
+}
+
+// NewAgent return new initialized instance of maintenanceAgent pointer type with clock, timezone of clock is used for window
+func NewAgent(c clock) *maintenanceAgent {
+	return &maintenanceAgent{
+		location: c.Location(),
+		clock:    c,
+		windows:  []window{},
+		mutex:    sync.RWMutex{},
+	}
+}
+
+// weekdays is map having weekday name as key, used for parsing Weekday of maintenance window
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// parseWindow parse maintenance window model to window with timezone, return error if invalid
+func parseWindow(mw domain.MaintenanceWindow, loc *time.Location, now time.Time) (w window, err error) {
+	w.MaintenanceWindow = mw
+	if mw.Mode != domain.MaintenanceModePause && mw.Mode != domain.MaintenanceModeSuppress {
+		err = fmt.Errorf("mode must be %s or %s, mode: %s", domain.MaintenanceModePause, domain.MaintenanceModeSuppress, mw.Mode)
+		return
+	}
+
+	if mw.Weekday != "" {
+		var ok bool
+		if w.weekday, ok = weekdays[strings.ToLower(mw.Weekday)]; !ok {
+			err = fmt.Errorf("invalid weekday, weekday: %s", mw.Weekday)
+			return
+		}
+		if w.startClock, err = parseClock(mw.Start); err != nil {
+			err = errors.Wrap(err, "invalid start clock")
+			return
+		}
+		if w.endClock, err = parseClock(mw.End); err != nil {
+			err = errors.Wrap(err, "invalid end clock")
+			return
+		}
+		if w.endClock <= w.startClock {
+			w.endClock += time.Hour * 24
+		}
+		w.weekly = true
+		return
+	}
+
+	if mw.Start == "" {
+		d, parseErr := time.ParseDuration(mw.Duration)
+		if parseErr != nil || d <= 0 {
+			err = fmt.Errorf("start or positive duration must be set, duration: %s", mw.Duration)
+			return
+		}
+		w.start, w.end = now, now.Add(d)
+		w.Start, w.End = w.start.In(loc).Format(dateTimeLayout), w.end.In(loc).Format(dateTimeLayout)
+		return
+	}
+
+	if w.start, err = time.ParseInLocation(dateTimeLayout, mw.Start, loc); err != nil {
+		err = errors.Wrap(err, "invalid start date time")
+		return
+	}
+	if w.end, err = time.ParseInLocation(dateTimeLayout, mw.End, loc); err != nil {
+		err = errors.Wrap(err, "invalid end date time")
+		return
+	}
+	if !w.end.After(w.start) {
+		err = fmt.Errorf("end must be after start, start: %s, end: %s", mw.Start, mw.End)
+	}
+	return
+}
+
+// dateTimeLayout is layout of Start, End in one-off maintenance window
+const dateTimeLayout = "2006-01-02 15:04"
+
+// parseClock parse clock string (Ex, 03:00) to duration from midnight
+func parseClock(clock string) (d time.Duration, err error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return
+	}
+	d = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	return
+}
+
+// isActive return if window is active at time received from parameter
+func (w window) isActive(now time.Time, loc *time.Location) bool {
+	if !w.weekly {
+		return !now.Before(w.start) && now.Before(w.end)
+	}
+
+	now = now.In(loc)
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	// window started yesterday can be still active if it crosses midnight
+	for _, day := range []time.Time{midnight, midnight.AddDate(0, 0, -1)} {
+		if day.Weekday() != w.weekday {
+			continue
+		}
+		if start, end := day.Add(w.startClock), day.Add(w.endClock); !now.Before(start) && now.Before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExpired return if one-off window was already ended at time received from parameter
+func (w window) isExpired(now time.Time) bool {
+	return !w.weekly && !now.Before(w.end)
+}
+
+// isMatched return if window is applied to check with domain & type
+// _type is matched without Check suffix ignoring case (Ex, cpu -> CPUCheck)
+func (w window) isMatched(_domain, _type string) bool {
+	if w.Domain != "" && w.Domain != _domain {
+		return false
+	}
+	return w.Type == "" || strings.EqualFold(strings.TrimSuffix(w.Type, "Check"), strings.TrimSuffix(_type, "Check"))
+}
+
+// newWindowID return new identifier of maintenance window
+func newWindowID() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,104 @@
+// Create file in v.1.1.0
+// agent_window.go file define method of maintenanceAgent about adding, removing & matching maintenance window
+// implement agency interface about maintenance defined in each of domain
+
+package maintenance
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// AddWindow parse & add maintenance window, return added window having generated ID
+func (ma *maintenanceAgent) AddWindow(mw domain.MaintenanceWindow) (added domain.MaintenanceWindow, err error) {
+	if mw.ID == "" {
+		mw.ID = newWindowID()
+	}
+
+	w, err := parseWindow(mw, ma.location, ma.clock.Now())
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse maintenance window")
+		return
+	}
+
+	ma.mutex.Lock()
+	defer ma.mutex.Unlock()
+	for _, exist := range ma.windows {
+		if exist.ID == w.ID {
+			err = fmt.Errorf("maintenance window is already exist, id: %s", w.ID)
+			return
+		}
+	}
+	ma.windows = append(ma.windows, w)
+	added = w.MaintenanceWindow
+	return
+}
+
+// RemoveWindow remove maintenance window with id, return error if not exist
+func (ma *maintenanceAgent) RemoveWindow(id string) error {
+	ma.mutex.Lock()
+	defer ma.mutex.Unlock()
+
+	for i, w := range ma.windows {
+		if w.ID == id {
+			ma.windows = append(ma.windows[:i], ma.windows[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("maintenance window is not exist, id: %s", id)
+}
+
+// Windows return every maintenance window which is not expired yet
+func (ma *maintenanceAgent) Windows() (windows []domain.MaintenanceWindow) {
+	ma.mutex.Lock()
+	defer ma.mutex.Unlock()
+	ma.removeExpired(ma.clock.Now())
+
+	windows = make([]domain.MaintenanceWindow, len(ma.windows))
+	for i, w := range ma.windows {
+		windows[i] = w.MaintenanceWindow
+	}
+	return
+}
+
+// IsPaused return if check with domain & type is paused now, and reason of active maintenance window
+func (ma *maintenanceAgent) IsPaused(_domain, _type string) (paused bool, reason string) {
+	return ma.activeWindow(_domain, _type, domain.MaintenanceModePause)
+}
+
+// IsRemediationSuppressed return if remediation of check with domain & type is suppressed now, and reason of window
+// remediation is also suppressed while check is paused
+func (ma *maintenanceAgent) IsRemediationSuppressed(_domain, _type string) (suppressed bool, reason string) {
+	if suppressed, reason = ma.activeWindow(_domain, _type, domain.MaintenanceModeSuppress); suppressed {
+		return
+	}
+	return ma.activeWindow(_domain, _type, domain.MaintenanceModePause)
+}
+
+// activeWindow return if active window with mode is matched with check domain & type, and reason of that window
+func (ma *maintenanceAgent) activeWindow(_domain, _type, mode string) (active bool, reason string) {
+	ma.mutex.RLock()
+	defer ma.mutex.RUnlock()
+
+	now := ma.clock.Now()
+	for _, w := range ma.windows {
+		if w.Mode == mode && w.isMatched(_domain, _type) && w.isActive(now, ma.location) {
+			return true, fmt.Sprintf("%s (window id: %s, operator: %s)", w.Reason, w.ID, w.Operator)
+		}
+	}
+	return
+}
+
+// removeExpired remove one-off windows which were already ended, it must be called while holding mutex
+func (ma *maintenanceAgent) removeExpired(now time.Time) {
+	windows := ma.windows[:0]
+	for _, w := range ma.windows {
+		if !w.isExpired(now) {
+			windows = append(windows, w)
+		}
+	}
+	ma.windows = windows
+}
@@ -0,0 +1,76 @@
+// Create package in v.1.1.0
+// notify package define struct which is implement various interface about alarm notification using in each of domain
+// there are kind of notifier backend such as slack, generic JSON webhook, discord webhook, smtp email, etc.
+
+// in agent.go file, define struct type of notify agent & initializer that are not method.
+// Also if exist, custom type or variable used in common in each of method will declared in this file.
+
+package notify
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// sendTimeout is max duration of sending alarm to one backend, so that slow backend doesn't block check process
+const sendTimeout = time.Second * 10
+
+// notifyAgent is struct that fan out alarm to every notifier backend whose filter is matched with alarm
+type notifyAgent struct {
+	// backends is list of notifier backend registered with AddBackend method
+	backends []backend
+
+	// policy is used for deciding if alarm is sent or suppressed before fanned out to backends
+	policy *alarmPolicy
+
+	// clock is used for getting current time when alarm occurs, which notification policy is applied at
+	clock clock
+}
+
+// backend is struct binding alarm sender with name & filter of notifier backend
+type backend struct {
+	// name specifies name of notifier backend, recorded in alarm result (Ex, slack, webhook)
+	name string
+
+	// filter specifies filter of alarm sent to this backend
+	filter domain.NotifyFilter
+
+	// sender is used for sending alarm to this backend
+	sender alarmSender
+
+	// channel, mention specifies channel & mention set to alarm sent to this backend, which is declared in routing rule
+	channel, mention string
+}
+
+// alarmSender is interface that send alarm to one notifier backend & return send time, text sent actually
+// you can see implementation in this package (webhook, discord, smtp) & slack package
+type alarmSender interface {
+	// SendAlarm send alarm to notifier backend and return send time & text & error
+	SendAlarm(ctx context.Context, alarm domain.Alarm) (t time.Time, text string, err error)
+}
+
+// clock is interface that return current time in configured timezone & timer, so that notification policy can be driven in test
+// you can see implementation in clock package
+type clock interface {
+	// Now method returns current time in timezone of clock
+	Now() time.Time
+
+	// AfterFunc method call f after duration d and returns timer which can stop that, used for flushing suppressed alarms
+	AfterFunc(d time.Duration, f func()) domain.Timer
+}
+
+// NewAgent return new initialized instance of notifyAgent pointer type with notification policy & clock, without any backend
+func NewAgent(policy domain.NotifyPolicy, c clock) *notifyAgent {
+	na := &notifyAgent{
+		backends: []backend{},
+		clock:    c,
+	}
+	na.policy = newAlarmPolicy(policy, c, na.notifySuppressed)
+	return na
+}
+
+// httpClient is HTTP client used in sender posting alarm to webhook URL
+var httpClient = &http.Client{Timeout: sendTimeout}
@@ -0,0 +1,127 @@
+// Create file in v.1.1.0
+// agent_notify.go file define method of notifyAgent about registering backend & fanning out alarm
+// implement notify agency interface defined in usecase of each domain
+
+package notify
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// AddBackend register notifier backend with name, filter & sender, alarm is sent to backend in order of registration
+func (na *notifyAgent) AddBackend(name string, filter domain.NotifyFilter, sender alarmSender) {
+	na.backends = append(na.backends, backend{name: name, filter: filter, sender: sender})
+	log.Printf("notifier backend is added, name: %s, filter: %+v", name, filter)
+}
+
+// AddRoute register routing rule sending alarm matched with filter to channel of backend with mention
+// backend of route must be registered with AddBackend method before, and slack is used if backend of route is empty
+func (na *notifyAgent) AddRoute(route domain.NotifyRoute) error {
+	if route.Backend == "" {
+		route.Backend = "slack"
+	}
+
+	for _, b := range na.backends {
+		if b.name != route.Backend {
+			continue
+		}
+
+		name := route.Backend
+		if route.Channel != "" {
+			name += ":" + route.Channel
+		}
+		na.backends = append(na.backends, backend{
+			name:    name,
+			filter:  route.Filter,
+			sender:  b.sender,
+			channel: route.Channel,
+			mention: route.Mention,
+		})
+		log.Printf("notifier route is added, name: %s, mention: %s, filter: %+v", name, route.Mention, route.Filter)
+		return nil
+	}
+
+	return errors.Errorf("notifier backend of route is not registered, backend: %s", route.Backend)
+}
+
+// Notify send alarm to every backend whose filter is matched with alarm at the same time & return result of each backend
+// failure of one backend doesn't affect another backend, so that alarm is delivered even if one of them is down
+// if alarm is suppressed by notification policy, alarm isn't sent & result of each backend has reason of suppression
+// channel of route overrides channel of alarm (Ex, escalation channel), and mention of route is added to that of alarm
+func (na *notifyAgent) Notify(ctx context.Context, alarm domain.Alarm) domain.AlarmResults {
+	matched := na.match(alarm)
+	results := make(domain.AlarmResults, len(matched))
+	if len(matched) == 0 {
+		return results
+	}
+
+	suppressed, reason, text := na.policy.decide(alarm, na.clock.Now())
+	if suppressed {
+		log.Printf("alarm is suppressed by notification policy, check: %s/%s, uuid: %s, reason: %s", alarm.Domain, alarm.Type, alarm.UUID, reason)
+		for i, b := range matched {
+			results[i] = domain.AlarmResult{Backend: b.name, Text: alarm.Text, Suppressed: true, SuppressReason: reason}
+		}
+		return results
+	}
+	alarm.Text = text
+
+	return na.send(ctx, alarm, matched)
+}
+
+// notifySuppressed send summary of suppressed alarms to every backend whose filter is matched with that
+// it is called from notification policy when window of suppression is passed, so result is only logged
+func (na *notifyAgent) notifySuppressed(alarm domain.Alarm) {
+	matched := na.match(alarm)
+	if len(matched) == 0 {
+		return
+	}
+
+	log.Printf("summary of suppressed alarms is sent, check: %s/%s, uuid: %s, text: %s", alarm.Domain, alarm.Type, alarm.UUID, alarm.Text)
+	na.send(context.Background(), alarm, matched)
+}
+
+// match method return every backend whose filter is matched with alarm, in order of registration
+func (na *notifyAgent) match(alarm domain.Alarm) (matched []backend) {
+	for _, b := range na.backends {
+		if b.filter.Match(alarm) {
+			matched = append(matched, b)
+		}
+	}
+	return
+}
+
+// send method send alarm to every backend received from parameter at the same time & return result of each backend
+func (na *notifyAgent) send(ctx context.Context, alarm domain.Alarm, matched []backend) domain.AlarmResults {
+	results := make(domain.AlarmResults, len(matched))
+	wg := sync.WaitGroup{}
+	for i, b := range matched {
+		wg.Add(1)
+		go func(i int, b backend) {
+			defer wg.Done()
+			sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+			defer cancel()
+
+			alarm := alarm
+			if b.channel != "" {
+				alarm.Channel = b.channel
+			}
+			if b.mention != "" {
+				alarm.Mention = strings.TrimSpace(alarm.Mention + " " + b.mention)
+			}
+			t, text, err := b.sender.SendAlarm(sendCtx, alarm)
+			if err != nil {
+				log.Println(errors.Wrapf(err, "failed to send alarm to %s backend, uuid: %s", b.name, alarm.UUID))
+			}
+			results[i] = domain.AlarmResult{Backend: b.name, Time: t, Text: text, Err: err}
+		}(i, b)
+	}
+	wg.Wait()
+
+	return results
+}
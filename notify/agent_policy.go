@@ -0,0 +1,168 @@
+// Create file in v.1.1.0
+// agent_policy.go file define notification policy deciding if alarm is sent or suppressed before fanned out
+// alarm is suppressed by deduplication, rate limit & flap detection per check, and suppressed alarms are summarised in next one
+// or in summary alarm flushed when window of suppression is passed, if any alarm isn't sent from check until then
+
+package notify
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// alarmPolicy is struct that decide if alarm is sent with notification policy & alarm history of each check
+type alarmPolicy struct {
+	// policy is notification policy received from outside package
+	policy domain.NotifyPolicy
+
+	// checks is alarm history per check key (domain/type)
+	checks map[string]*checkAlarms
+
+	// flush is function sending summary of suppressed alarms, which is called when window of suppression is passed
+	flush func(alarm domain.Alarm)
+
+	// clock is used for creating timer calling flush function, so that flush can be driven by fake clock in test
+	clock clock
+
+	// mutex is used for preventing race condition in checks, because every usecase send alarm concurrently
+	mutex sync.Mutex
+}
+
+// checkAlarms is struct having alarm history of one check used for applying notification policy
+type checkAlarms struct {
+	// lastSent is the time when identical alarm (level & text) was sent lastly
+	lastSent map[string]time.Time
+
+	// sent is list of time when alarm was sent, used for rate limit
+	sent []time.Time
+
+	// occurred is list of time when alarm per level occurred with text different from last one, used for flap detection
+	occurred map[string][]time.Time
+
+	// lastText is text of alarm per level which occurred lastly regardless of suppression
+	lastText map[string]string
+
+	// suppressed is number of alarms suppressed since alarm was sent lastly
+	suppressed int
+
+	// suppressedSince is the time when first alarm among suppressed ones occurred
+	suppressedSince time.Time
+
+	// lastSuppressed is alarm suppressed lastly, which summary of suppressed alarms is made from
+	lastSuppressed domain.Alarm
+
+	// flushTimer is timer flushing summary of suppressed alarms, which is stopped if summary is added to alarm sent before
+	flushTimer domain.Timer
+}
+
+// newAlarmPolicy return new alarmPolicy pointer instance with notification policy, clock & function flushing suppressed alarms
+func newAlarmPolicy(policy domain.NotifyPolicy, c clock, flush func(alarm domain.Alarm)) *alarmPolicy {
+	return &alarmPolicy{
+		policy: policy,
+		checks: map[string]*checkAlarms{},
+		flush:  flush,
+		clock:  c,
+	}
+}
+
+// decide method return if alarm should be suppressed & reason of that, or text of alarm to send if not suppressed
+// text of alarm sent after suppression has summary of suppressed alarms (Ex, suppressed 14 similar alerts since 15:04:05)
+// alarm of exempt level (Ex, UNHEALTHY, RECOVERED) is only deduplicated, and isn't suppressed by rate limit & flap detection
+func (ap *alarmPolicy) decide(alarm domain.Alarm, now time.Time) (suppressed bool, reason, text string) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	key := alarm.Domain + "/" + alarm.Type
+	ca, ok := ap.checks[key]
+	if !ok {
+		ca = &checkAlarms{lastSent: map[string]time.Time{}, occurred: map[string][]time.Time{}, lastText: map[string]string{}}
+		ap.checks[key] = ca
+	}
+
+	identical := alarm.Level + "\n" + alarm.Text
+	ca.sent = within(ca.sent, now, ap.policy.RateLimitWindow)
+	ca.occurred[alarm.Level] = within(ca.occurred[alarm.Level], now, ap.policy.FlapWindow)
+	duplicated := ap.policy.DedupWindow > 0 && now.Sub(ca.lastSent[identical]) < ap.policy.DedupWindow
+	if ca.lastText[alarm.Level] != alarm.Text {
+		// alarm repeated with same text isn't counted, because it's not flapping but persistent state (Ex, dependency is down)
+		ca.occurred[alarm.Level] = append(ca.occurred[alarm.Level], now)
+		ca.lastText[alarm.Level] = alarm.Text
+	}
+	occurred := len(ca.occurred[alarm.Level])
+	exempted := ap.policy.Exempted(alarm.Level)
+
+	// wait is duration after which suppression is expected to end, summary of suppressed alarms is flushed after that
+	var wait time.Duration
+	switch {
+	case duplicated:
+		reason = fmt.Sprintf("identical alarm was already sent within %s", ap.policy.DedupWindow)
+		wait = ap.policy.DedupWindow
+	case !exempted && ap.policy.FlapThreshold > 0 && occurred > ap.policy.FlapThreshold:
+		reason = fmt.Sprintf("check is flapping, %d alarms of %s level occurred within %s", occurred, alarm.Level, ap.policy.FlapWindow)
+		wait = ap.policy.FlapWindow
+	case !exempted && ap.policy.RateLimit > 0 && len(ca.sent) >= ap.policy.RateLimit:
+		reason = fmt.Sprintf("rate limit exceeded, %d alarms were already sent within %s", len(ca.sent), ap.policy.RateLimitWindow)
+		wait = ap.policy.RateLimitWindow - now.Sub(ca.sent[len(ca.sent)-ap.policy.RateLimit])
+	}
+
+	if reason != "" {
+		if ca.suppressed == 0 {
+			ca.suppressedSince = now
+			ca.flushTimer = ap.clock.AfterFunc(wait, func() { ap.flushSuppressed(key) })
+		}
+		ca.suppressed++
+		ca.lastSuppressed = alarm
+		return true, reason, ""
+	}
+
+	text = alarm.Text
+	if !exempted && ap.policy.FlapThreshold > 0 && occurred == ap.policy.FlapThreshold {
+		text += fmt.Sprintf(" (flapping detected, similar alerts will be suppressed for %s)", ap.policy.FlapWindow)
+	}
+	if ca.suppressed > 0 {
+		text += fmt.Sprintf(" (suppressed %d similar alerts since %s)", ca.suppressed, ca.suppressedSince.Format("15:04:05"))
+		ca.suppressed = 0
+		ca.flushTimer.Stop()
+	}
+
+	ca.lastSent[identical] = now
+	ca.sent = append(ca.sent, now)
+	for k, t := range ca.lastSent {
+		if now.Sub(t) >= ap.policy.DedupWindow {
+			delete(ca.lastSent, k)
+		}
+	}
+	return false, "", text
+}
+
+// flushSuppressed method send summary of alarms suppressed in check with key, if that isn't added to alarm sent before
+// summary is sent with level, uuid & fields of alarm suppressed lastly, and isn't limited by notification policy
+func (ap *alarmPolicy) flushSuppressed(key string) {
+	ap.mutex.Lock()
+	ca := ap.checks[key]
+	if ca.suppressed == 0 {
+		ap.mutex.Unlock()
+		return
+	}
+
+	alarm := ca.lastSuppressed
+	alarm.Emoji = "mute"
+	alarm.Text = fmt.Sprintf("suppressed %d similar alerts since %s, latest: %s", ca.suppressed, ca.suppressedSince.Format("15:04:05"), alarm.Text)
+	ca.suppressed = 0
+	ap.mutex.Unlock()
+
+	ap.flush(alarm)
+}
+
+// within return times which is within window from now, times must be sorted in ascending order
+func within(times []time.Time, now time.Time, window time.Duration) []time.Time {
+	for i, t := range times {
+		if now.Sub(t) < window {
+			return times[i:]
+		}
+	}
+	return times[:0]
+}
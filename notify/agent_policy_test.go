@@ -0,0 +1,176 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	fakeclock "github.com/DMS-SMS/v1-health-check/clock"
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// testPolicy is helper deciding alarm with alarmPolicy driven by fake clock & recording every flushed alarm
+type testPolicy struct {
+	policy  *alarmPolicy
+	clock   interface{ Advance(d time.Duration) }
+	now     func() time.Time
+	flushed []domain.Alarm
+}
+
+func newTestPolicy(policy domain.NotifyPolicy) *testPolicy {
+	fc := fakeclock.NewFake(time.Date(2021, 6, 1, 15, 0, 0, 0, time.UTC))
+	tp := &testPolicy{clock: fc, now: fc.Now}
+	tp.policy = newAlarmPolicy(policy, fc, func(alarm domain.Alarm) { tp.flushed = append(tp.flushed, alarm) })
+	return tp
+}
+
+// decide decide alarm about cpu check with level & text at current time of fake clock
+func (tp *testPolicy) decide(level, text string) (suppressed bool, reason, sent string) {
+	return tp.policy.decide(domain.Alarm{Domain: "syscheck", Type: "CPUCheck", Level: level, Text: text}, tp.now())
+}
+
+func (tp *testPolicy) mustSend(t *testing.T, level, text string) string {
+	t.Helper()
+	suppressed, reason, sent := tp.decide(level, text)
+	if suppressed {
+		t.Fatalf("alarm %q is suppressed, reason: %s", text, reason)
+	}
+	return sent
+}
+
+func (tp *testPolicy) mustSuppress(t *testing.T, level, text, reason string) {
+	t.Helper()
+	suppressed, got, _ := tp.decide(level, text)
+	if !suppressed {
+		t.Fatalf("alarm %q is sent, want suppressed by %s", text, reason)
+	}
+	if !strings.Contains(got, reason) {
+		t.Fatalf("reason = %q, want to contain %q", got, reason)
+	}
+}
+
+func TestAlarmPolicyDeduplicate(t *testing.T) {
+	tp := newTestPolicy(domain.NotifyPolicy{DedupWindow: time.Minute * 10})
+
+	tp.mustSend(t, "WARNING", "cpu usage is high")
+	tp.clock.Advance(time.Minute)
+	tp.mustSuppress(t, "WARNING", "cpu usage is high", "identical alarm")
+
+	tp.mustSuppress(t, "WARNING", "cpu usage is high", "identical alarm")
+
+	// alarm with different level or text isn't identical, and it is sent with summary of suppressed alarms
+	sent := tp.mustSend(t, "UNHEALTHY", "cpu usage is high")
+	if want := "cpu usage is high (suppressed 2 similar alerts since 15:01:00)"; sent != want {
+		t.Fatalf("sent text = %q, want %q", sent, want)
+	}
+	tp.mustSend(t, "WARNING", "cpu usage is very high")
+
+	// identical alarm is sent again after dedup window, and summary added to alarm sent before isn't flushed
+	tp.clock.Advance(time.Minute * 9)
+	if sent := tp.mustSend(t, "WARNING", "cpu usage is high"); sent != "cpu usage is high" {
+		t.Fatalf("sent text = %q, want without summary", sent)
+	}
+	tp.clock.Advance(time.Hour)
+	if len(tp.flushed) != 0 {
+		t.Fatalf("flushed alarms = %v, want nothing", tp.flushed)
+	}
+}
+
+func TestAlarmPolicyRateLimit(t *testing.T) {
+	tp := newTestPolicy(domain.NotifyPolicy{RateLimit: 2, RateLimitWindow: time.Minute * 10})
+
+	tp.mustSend(t, "WARNING", "alarm 1")
+	tp.clock.Advance(time.Minute)
+	tp.mustSend(t, "ERROR", "alarm 2")
+	tp.clock.Advance(time.Minute)
+	tp.mustSuppress(t, "WEAK_DETECTED", "alarm 3", "rate limit exceeded")
+	tp.mustSuppress(t, "WEAK_DETECTED", "alarm 4", "rate limit exceeded")
+
+	// summary of suppressed alarms is flushed & alarm is sent again when first alarm in window is out of window
+	tp.clock.Advance(time.Minute*8 - time.Second)
+	tp.mustSuppress(t, "WEAK_DETECTED", "alarm 5", "rate limit exceeded")
+	if len(tp.flushed) != 0 {
+		t.Fatalf("flushed alarms = %v, want nothing before window is passed", tp.flushed)
+	}
+	tp.clock.Advance(time.Second)
+	if len(tp.flushed) != 1 || tp.flushed[0].Text != "suppressed 3 similar alerts since 15:02:00, latest: alarm 5" {
+		t.Fatalf("flushed alarms = %v, want one summary of 3 alarms", tp.flushed)
+	}
+	if sent := tp.mustSend(t, "WARNING", "alarm 6"); sent != "alarm 6" {
+		t.Fatalf("sent text = %q, want without summary", sent)
+	}
+}
+
+func TestAlarmPolicyFlapDetection(t *testing.T) {
+	tp := newTestPolicy(domain.NotifyPolicy{FlapThreshold: 2, FlapWindow: time.Minute * 10})
+
+	tp.mustSend(t, "WARNING", "cpu usage - 1.10")
+	if sent := tp.mustSend(t, "WARNING", "cpu usage - 1.20"); !strings.Contains(sent, "flapping detected") {
+		t.Fatalf("sent text = %q, want to contain notice of flapping", sent)
+	}
+	tp.mustSuppress(t, "WARNING", "cpu usage - 1.30", "check is flapping")
+
+	// alarm repeated with same text is persistent state, so it isn't counted as flapping
+	tp.mustSend(t, "ERROR", "failed to get cpu usage")
+	tp.mustSend(t, "ERROR", "failed to get cpu usage")
+	tp.mustSend(t, "ERROR", "failed to get cpu usage")
+
+	// alarm of level is sent again after flap window is passed
+	tp.clock.Advance(time.Minute * 10)
+	tp.mustSend(t, "WARNING", "cpu usage - 1.40")
+}
+
+func TestAlarmPolicyExemptLevels(t *testing.T) {
+	tp := newTestPolicy(domain.NotifyPolicy{
+		DedupWindow:     time.Minute * 10,
+		RateLimit:       1,
+		RateLimitWindow: time.Minute * 10,
+		FlapThreshold:   1,
+		FlapWindow:      time.Minute * 10,
+		ExemptLevels:    []string{"unhealthy", "recovered"},
+	})
+
+	tp.mustSend(t, "WARNING", "alarm 1")
+	tp.mustSuppress(t, "WARNING", "alarm 2", "check is flapping")
+
+	// alarm of exempt level isn't suppressed by rate limit & flap detection, and level is compared ignoring case
+	tp.mustSend(t, "UNHEALTHY", "cpu check has deteriorated")
+	tp.mustSend(t, "RECOVERED", "cpu check is healthy")
+	tp.mustSend(t, "UNHEALTHY", "cpu check has deteriorated again")
+
+	// but it is still deduplicated
+	tp.mustSuppress(t, "UNHEALTHY", "cpu check has deteriorated again", "identical alarm")
+}
+
+func TestAlarmPolicyFlushSuppressed(t *testing.T) {
+	tp := newTestPolicy(domain.NotifyPolicy{DedupWindow: time.Minute * 10})
+
+	tp.mustSend(t, "WARNING", "cpu usage is high")
+	tp.clock.Advance(time.Minute)
+	tp.mustSuppress(t, "WARNING", "cpu usage is high", "identical alarm")
+	tp.clock.Advance(time.Minute)
+	tp.mustSuppress(t, "WARNING", "cpu usage is high", "identical alarm")
+
+	// summary is flushed when window of first suppression is passed, if any alarm isn't sent from check until then
+	tp.clock.Advance(time.Minute * 8)
+	if len(tp.flushed) != 0 {
+		t.Fatalf("flushed alarms = %v, want nothing before window is passed", tp.flushed)
+	}
+	tp.clock.Advance(time.Minute)
+	if len(tp.flushed) != 1 {
+		t.Fatalf("flushed alarms = %v, want one summary", tp.flushed)
+	}
+
+	summary := tp.flushed[0]
+	if want := "suppressed 2 similar alerts since 15:01:00, latest: cpu usage is high"; summary.Text != want {
+		t.Fatalf("summary text = %q, want %q", summary.Text, want)
+	}
+	if summary.Level != "WARNING" || summary.Emoji != "mute" {
+		t.Fatalf("summary level, emoji = %s, %s, want WARNING, mute", summary.Level, summary.Emoji)
+	}
+
+	// flushed alarms aren't summarised again in next alarm
+	if sent := tp.mustSend(t, "WARNING", "cpu usage is high"); sent != "cpu usage is high" {
+		t.Fatalf("sent text = %q, want without summary", sent)
+	}
+}
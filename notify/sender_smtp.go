@@ -0,0 +1,127 @@
+// Create file in v.1.1.0
+// sender_smtp.go file define alarm sender sending alarm as email with SMTP server
+// implement alarmSender interface defined in agent.go file
+
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/pkg/errors"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// smtpSender is struct that send alarm as plain text email to recipients with SMTP server
+type smtpSender struct {
+	// addr is address of SMTP server with port (Ex, smtp.gmail.com:587)
+	addr string
+
+	// auth is used for authenticating to SMTP server, no authentication if nil
+	auth smtp.Auth
+
+	// from is email address of sender
+	from string
+
+	// to is email address list of recipients
+	to []string
+
+	// clock is used for getting send time of alarm in configured timezone
+	clock clock
+}
+
+// NewSMTPSender return smtpSender sending alarm to recipients via SMTP server of addr
+// PLAIN authentication is used if username is not empty, and STARTTLS is used if SMTP server supports that
+func NewSMTPSender(addr, username, password, from string, to []string, c clock) *smtpSender {
+	ss := &smtpSender{
+		addr:  addr,
+		from:  from,
+		to:    to,
+		clock: c,
+	}
+
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		ss.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return ss
+}
+
+// SendAlarm send alarm as email whose subject has level, domain & type of alarm, and return send time & text & error
+// channel & mention of alarm set by routing rule is ignored, because recipients are fixed in smtpSender
+func (ss *smtpSender) SendAlarm(ctx context.Context, alarm domain.Alarm) (t time.Time, text string, err error) {
+	t = ss.clock.Now()
+	text = fmt.Sprintf("%s\n\ndomain: %s\ntype: %s\nlevel: %s\nuuid: %s\nincident: %s\ntime: %s\n",
+		alarm.Text, alarm.Domain, alarm.Type, alarm.Level, alarm.UUID, alarm.Incident, t.Format(time.RFC3339))
+	msg := strings.Join([]string{
+		"From: " + ss.from,
+		"To: " + strings.Join(ss.to, ", "),
+		fmt.Sprintf("Subject: [health-check] [%s] %s/%s", alarm.Level, alarm.Domain, alarm.Type),
+		"Date: " + t.Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		text,
+	}, "\r\n")
+
+	if err = ss.send(ctx, []byte(msg)); err != nil {
+		err = errors.Wrap(err, "failed to send email with SMTP server")
+	}
+	return
+}
+
+// send method dial to SMTP server with context & send message to every recipient
+func (ss *smtpSender) send(ctx context.Context, msg []byte) error {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", ss.addr)
+	if err != nil {
+		return errors.Wrap(err, "failed to dial SMTP server")
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	host, _, _ := net.SplitHostPort(ss.addr)
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return errors.Wrap(err, "failed to create SMTP client")
+	}
+	defer func() { _ = c.Close() }()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return errors.Wrap(err, "failed to start TLS")
+		}
+	}
+	if ss.auth != nil {
+		if err := c.Auth(ss.auth); err != nil {
+			return errors.Wrap(err, "failed to authenticate")
+		}
+	}
+
+	if err := c.Mail(ss.from); err != nil {
+		return errors.Wrap(err, "failed to set sender")
+	}
+	for _, to := range ss.to {
+		if err := c.Rcpt(to); err != nil {
+			return errors.Wrapf(err, "failed to set recipient, address: %s", to)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return errors.Wrap(err, "failed to start data command")
+	}
+	if _, err := w.Write(msg); err != nil {
+		return errors.Wrap(err, "failed to write message")
+	}
+	if err := w.Close(); err != nil {
+		return errors.Wrap(err, "failed to close data command")
+	}
+	return c.Quit()
+}
@@ -0,0 +1,104 @@
+// Create file in v.1.1.0
+// sender_webhook.go file define alarm sender posting alarm to generic JSON webhook & discord webhook
+// implement alarmSender interface defined in agent.go file
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// webhookSender is struct that post alarm to webhook URL as JSON body created with body function
+type webhookSender struct {
+	// url is webhook URL which alarm is posted to
+	url string
+
+	// body return JSON body & text of alarm posted to webhook URL
+	body func(alarm domain.Alarm, t time.Time) (body interface{}, text string)
+
+	// clock is used for getting send time of alarm in configured timezone
+	clock clock
+}
+
+// NewWebhookSender return webhookSender posting every field of alarm & send time as JSON object to url
+// Ex, {"domain": "syscheck", "type": "DiskCheck", "kind": "DiskCheck", "level": "WEAK_DETECTED", "emoji": "pill", "text": "...", "uuid": "...", "incident": "...", "resolved": false, "channel": "", "mention": "", "fields": {...}, "time": "..."}
+func NewWebhookSender(url string, c clock) *webhookSender {
+	return &webhookSender{
+		url:   url,
+		clock: c,
+		body: func(alarm domain.Alarm, t time.Time) (interface{}, string) {
+			return map[string]interface{}{
+				"domain":   alarm.Domain,
+				"type":     alarm.Type,
+				"kind":     alarm.Kind,
+				"level":    alarm.Level,
+				"emoji":    alarm.Emoji,
+				"text":     alarm.Text,
+				"uuid":     alarm.UUID,
+				"incident": alarm.Incident,
+				"resolved": alarm.Resolved,
+				"channel":  alarm.Channel,
+				"mention":  alarm.Mention,
+				"fields":   alarm.Fields,
+				"time":     t,
+			}, alarm.Text
+		},
+	}
+}
+
+// NewDiscordSender return webhookSender posting alarm as content of message to discord webhook url
+// content is started with mention of alarm if set by routing rule (Ex, <@&role-id>), channel of alarm is ignored
+func NewDiscordSender(url string, c clock) *webhookSender {
+	return &webhookSender{
+		url:   url,
+		clock: c,
+		body: func(alarm domain.Alarm, _ time.Time) (interface{}, string) {
+			text := fmt.Sprintf("**[%s] %s/%s** %s (%s)", alarm.Level, alarm.Domain, alarm.Type, alarm.Text, alarm.UUID)
+			if alarm.Incident != "" {
+				text += fmt.Sprintf(" [incident %s]", alarm.Incident)
+			}
+			if alarm.Mention != "" {
+				text = alarm.Mention + " " + text
+			}
+			return map[string]string{"username": "health-check", "content": text}, text
+		},
+	}
+}
+
+// SendAlarm post alarm to webhook URL & return send time, text of alarm, error if status code is not 2xx
+func (ws *webhookSender) SendAlarm(ctx context.Context, alarm domain.Alarm) (t time.Time, text string, err error) {
+	t = ws.clock.Now()
+	body, text := ws.body(alarm, t)
+	b, err := json.Marshal(body)
+	if err != nil {
+		err = errors.Wrap(err, "failed to marshal webhook body")
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ws.url, bytes.NewReader(b))
+	if err != nil {
+		err = errors.Wrap(err, "failed to create request to webhook url")
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		err = errors.Wrap(err, "failed to send request to webhook url")
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err = errors.Errorf("webhook request is rejected, status code: %d", resp.StatusCode)
+	}
+	return
+}
@@ -11,6 +11,8 @@ import (
 	"runtime/pprof"
 	"sync"
 	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
 )
 
 // defaultProfiler profile memory and heap usage & save to s3 per a day or when msg come from signal channel
@@ -19,6 +21,7 @@ type defaultProfiler struct {
 	signalChan chan os.Signal
 	waitGroup  *sync.WaitGroup
 	myCfg      defaultProfilerConfig
+	clock      clock
 }
 
 // defaultProfilerConfig is the config getter interface about default profiler
@@ -30,20 +33,28 @@ type defaultProfilerConfig interface {
 	Version() string
 }
 
-func New(s *session.Session, wg *sync.WaitGroup, cfg defaultProfilerConfig) *defaultProfiler {
+// clock is interface that return current time in configured timezone & timer, used for path & cycle of profile
+// you can see implementation in clock package
+type clock interface {
+	// Now method returns current time in timezone of clock
+	Now() time.Time
+
+	// NewTimer method returns timer firing after duration d
+	NewTimer(d time.Duration) domain.Timer
+}
+
+func New(s *session.Session, wg *sync.WaitGroup, cfg defaultProfilerConfig, c clock) *defaultProfiler {
 	return &defaultProfiler{
 		awsSession: s,
 		signalChan: make(chan os.Signal, 1),
 		waitGroup:  wg,
 		myCfg:      cfg,
+		clock:      c,
 	}
 }
 
 func (dp *defaultProfiler) StartProfiling() {
-	now := time.Now()
-	if now.Location().String() == time.UTC.String() {
-		now = now.Add(time.Hour * 9)
-	}
+	now := dp.clock.Now()
 	nowDate := fmt.Sprintf("%4d-%02d-%02d", now.Year(), now.Month(), now.Day())
 	nowTime := fmt.Sprintf("%02d:%02d:%02d", now.Hour(), now.Minute(), now.Second())
 
@@ -82,11 +93,12 @@ func (dp *defaultProfiler) StartProfiling() {
 
 	// 시작 당일이 끝날 때 까지 대기
 	afterOneDay := now.AddDate(0, 0, 1)
-	tomorrow := time.Date(afterOneDay.Year(), afterOneDay.Month(), afterOneDay.Day(), 0, 0, 0, 0, time.UTC)
-	timeFinSig := time.Tick(tomorrow.Sub(now))
+	tomorrow := time.Date(afterOneDay.Year(), afterOneDay.Month(), afterOneDay.Day(), 0, 0, 0, 0, now.Location())
+	timeFinSig := dp.clock.NewTimer(tomorrow.Sub(now))
+	defer timeFinSig.Stop()
 
 	select {
-	case <-timeFinSig:
+	case <-timeFinSig.C():
 		log.Println("upload profiling result recorded on this day")
 	case <-dp.signalChan:
 		dp.waitGroup.Add(1)
@@ -0,0 +1,130 @@
+// Create package in v.1.1.0
+// prometheus package define struct which is implement various interface about metrics using in each of domain
+// there are kind of metrics such as gauge of measured value, counter of check process, histogram of duration, etc ...
+
+// in agent.go file, define struct type of prometheus agent & initializer that are not method.
+// Also if exist, custom type or variable used in common in each of method will declared in this file.
+
+package prometheus
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace is prefix of every metric name registered in prometheusAgent
+const namespace = "sms_health_check"
+
+// prometheusAgent agent prometheus metrics about check history as implementation
+type prometheusAgent struct {
+	// registry is prometheus registry having every collector registered in NewAgent
+	registry *prometheus.Registry
+
+	// fields in below are counter & histogram about every check process (label: domain, type, etc ...)
+	// runs count check process per final process level
+	runs *prometheus.CounterVec
+
+	// alarms count check process sent alarm per final process level
+	alarms *prometheus.CounterVec
+
+	// remediations count check process started remediation per final process level (process level having WEAK_DETECTED)
+	remediations *prometheus.CounterVec
+
+	// errors count check process occurred error per final process level
+	errors *prometheus.CounterVec
+
+	// durations observe time spent in check process
+	durations *prometheus.HistogramVec
+
+	// ---
+
+	// fields in below are gauge about latest value measured in each check type (label: type, etc ...)
+	// cpuTotalUsage represent total cpu usage core measured in cpu check
+	cpuTotalUsage *prometheus.GaugeVec
+
+	// memoryTotalUsage represent total memory usage bytes measured in memory check
+	memoryTotalUsage *prometheus.GaugeVec
+
+	// diskRemainingCap represent remaining disk capacity bytes measured in disk check
+	diskRemainingCap *prometheus.GaugeVec
+
+	// esShards represent shards number per state(active, active_primary, unassigned) measured in elasticsearch check
+	esShards *prometheus.GaugeVec
+
+	// swarmpitMemoryUsage represent swarmpit app memory usage bytes measured in swarmpit check
+	swarmpitMemoryUsage *prometheus.GaugeVec
+
+	// consulInstances represent instances number per service measured in consul check
+	consulInstances *prometheus.GaugeVec
+}
+
+// NewAgent return new initialized instance of prometheusAgent pointer type with registering collectors
+func NewAgent() *prometheusAgent {
+	pa := &prometheusAgent{
+		registry: prometheus.NewRegistry(),
+
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "runs_total",
+			Help: "Number of check process per check type & final process level",
+		}, []string{"domain", "type", "process_level"}),
+		alarms: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "alarms_total",
+			Help: "Number of check process sent alarm per check type & final process level",
+		}, []string{"domain", "type", "process_level"}),
+		remediations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "remediations_total",
+			Help: "Number of remediation action started in check process per check type & final process level",
+		}, []string{"domain", "type", "process_level"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "errors_total",
+			Help: "Number of check process occurred error per check type & final process level",
+		}, []string{"domain", "type", "process_level"}),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Name: "duration_seconds",
+			Help:    "Time spent in check process per check type",
+			Buckets: []float64{.01, .05, .1, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"domain", "type"}),
+
+		cpuTotalUsage: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace, Name: "cpu_total_usage_core",
+			Help: "Total cpu usage core of system measured in latest cpu check",
+		}, []string{"type"}),
+		memoryTotalUsage: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace, Name: "memory_total_usage_bytes",
+			Help: "Total memory usage bytes of system measured in latest memory check",
+		}, []string{"type"}),
+		diskRemainingCap: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace, Name: "disk_remaining_capacity_bytes",
+			Help: "Remaining disk capacity bytes of system measured in latest disk check",
+		}, []string{"type"}),
+		esShards: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace, Name: "elasticsearch_shards",
+			Help: "Shards number of elasticsearch cluster per state measured in latest elasticsearch check",
+		}, []string{"type", "state"}),
+		swarmpitMemoryUsage: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace, Name: "swarmpit_app_memory_usage_bytes",
+			Help: "Memory usage bytes of swarmpit app container measured in latest swarmpit check",
+		}, []string{"type"}),
+		consulInstances: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace, Name: "consul_instances",
+			Help: "Instances number registered in consul per service measured in latest consul check",
+		}, []string{"type", "service"}),
+	}
+
+	pa.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		pa.runs, pa.alarms, pa.remediations, pa.errors, pa.durations,
+		pa.cpuTotalUsage, pa.memoryTotalUsage, pa.diskRemainingCap,
+		pa.esShards, pa.swarmpitMemoryUsage, pa.consulInstances,
+	)
+
+	return pa
+}
+
+// Handler return http handler exposing metrics registered in agent with prometheus text format
+func (pa *prometheusAgent) Handler() http.Handler {
+	return promhttp.HandlerFor(pa.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,92 @@
+// Create file in v.1.1.0
+// agent_history.go file define method of prometheusAgent about check history
+// implement history observer interface defined in usecase of each domain
+
+package prometheus
+
+import (
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// process level used in deciding which metric to record, which is same as const value declared in syscheck, srvcheck usecase
+const (
+	healthyLevel      = "HEALTHY"       // represent that value is measured & healthy
+	warningLevel      = "WARNING"       // represent that value is measured & warning
+	weakDetectedLevel = "WEAK_DETECTED" // represent that check process started remediation
+	recoveredLevel    = "RECOVERED"     // represent that value is measured & recovered
+	unhealthyLevel    = "UNHEALTHY"     // represent that value is measured & unhealthy
+	suppressedLevel   = "SUPPRESSED"    // represent that value is measured, but remediation is suppressed
+	simulatedLevel    = "SIMULATED"     // represent that value is measured, but remediation is simulated in dry-run mode
+)
+
+// ObserveHistory record counter, histogram & gauge metrics with check history and duration spent in check process
+func (pa *prometheusAgent) ObserveHistory(history domain.CheckHistory, duration time.Duration) {
+	// final process level is used as label instead of joined levels, so that label values are bounded to each level
+	levels := history.ProcessLevels()
+	level := "NONE"
+	if len(levels) != 0 {
+		level = levels[len(levels)-1]
+	}
+
+	pa.runs.WithLabelValues(history.Domain(), history.Type(), level).Inc()
+	pa.durations.WithLabelValues(history.Domain(), history.Type()).Observe(duration.Seconds())
+
+	if history.IsAlerted() {
+		pa.alarms.WithLabelValues(history.Domain(), history.Type(), level).Inc()
+	}
+	if history.Err() != nil {
+		pa.errors.WithLabelValues(history.Domain(), history.Type(), level).Inc()
+	}
+	// remediation simulated in dry-run mode isn't counted, because any action wasn't executed actually
+	if contains(levels, weakDetectedLevel) && !contains(levels, simulatedLevel) {
+		pa.remediations.WithLabelValues(history.Domain(), history.Type(), level).Inc()
+	}
+
+	// gauge is labeled with type tag of check instance, so that value measured in each check instance isn't overwritten
+	// measured value is empty if check process is failed, skipped, paused, timed out or operated by operator
+	if !measuredAt(levels) {
+		return
+	}
+
+	switch h := history.(type) {
+	case *domain.CPUCheckHistory:
+		pa.cpuTotalUsage.WithLabelValues(history.Type()).Set(h.TotalUsageCore)
+	case *domain.MemoryCheckHistory:
+		pa.memoryTotalUsage.WithLabelValues(history.Type()).Set(float64(h.TotalUsageMemory))
+	case *domain.DiskCheckHistory:
+		pa.diskRemainingCap.WithLabelValues(history.Type()).Set(float64(h.RemainingCap))
+	case *domain.ElasticsearchCheckHistory:
+		pa.esShards.WithLabelValues(history.Type(), "active").Set(float64(h.ActiveShards))
+		pa.esShards.WithLabelValues(history.Type(), "active_primary").Set(float64(h.ActivePrimaryShards))
+		pa.esShards.WithLabelValues(history.Type(), "unassigned").Set(float64(h.UnassignedShards))
+	case *domain.SwarmpitCheckHistory:
+		pa.swarmpitMemoryUsage.WithLabelValues(history.Type()).Set(float64(h.SwarmpitAppMemoryUsage))
+	case *domain.ConsulCheckHistory:
+		for srv, instances := range h.InstancesPerService {
+			pa.consulInstances.WithLabelValues(history.Type(), srv).Set(float64(len(instances)))
+		}
+	}
+}
+
+// measuredAt return if value was measured in check process with process levels, same rule as report usecase
+func measuredAt(levels []string) bool {
+	for _, level := range levels {
+		switch level {
+		case healthyLevel, warningLevel, weakDetectedLevel, recoveredLevel, unhealthyLevel, suppressedLevel, simulatedLevel:
+			return true
+		}
+	}
+	return false
+}
+
+// contains return if levels contain level received from parameter
+func contains(levels []string, level string) bool {
+	for _, l := range levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+// Create package in v.1.1.0
+// config package contains App global variable with config value about report from environment variable or config file
+// App return field value from method having same name with that field name
+
+// report.go is file that define reportConfig type which is type of App
+// Also, App implement various config interface each of package in report domain by declaring method
+
+package config
+
+import (
+	"github.com/spf13/viper"
+	"time"
+)
+
+// App is the application config about report domain
+var App *reportConfig
+
+// reportConfig having config value and implement various interface about Config by declaring method
+type reportConfig struct {
+	// fields about index information in elasticsearch (implement esReportRepositoryConfig)
+	// historyIndices represent index name or pattern of elasticsearch index including syscheck, srvcheck history document
+	historyIndices *[]string
+
+	// fetchTimeout represent max duration of fetching check history of report period from elasticsearch
+	fetchTimeout *time.Duration
+
+	// ---
+
+	// fields using in report usecase (implement reportUsecaseConfig)
+	// slackChannel represent slack channel to post report, chat channel of slack agent is used if empty
+	slackChannel *string
+
+	// ---
+
+	// fields using in main function to inject delivery layer (not implement any interface)
+	// dailyDeliverySchedule represent daily report delivery schedule spec (interval or cron expression with jitter)
+	dailyDeliverySchedule *string
+
+	// weeklyDeliverySchedule represent weekly report delivery schedule spec (interval or cron expression with jitter)
+	weeklyDeliverySchedule *string
+}
+
+// default const value about reportConfig field
+const (
+	defaultFetchTimeout = time.Minute // default const Duration for fetchTimeout
+	defaultSlackChannel = ""          // default const string for slackChannel
+
+	defaultDailyDeliverySchedule  = "0 9 * * *" // default const string for dailyDeliverySchedule (every day at 09:00)
+	defaultWeeklyDeliverySchedule = "0 9 * * 1" // default const string for weeklyDeliverySchedule (every monday at 09:00)
+)
+
+// default value about historyIndices field, which match every index of syscheck, srvcheck with default index name
+var defaultHistoryIndices = []string{"sms-system-check*", "sms-service-check*"}
+
+// implement HistoryIndices method of esReportRepositoryConfig interface
+func (rc *reportConfig) HistoryIndices() []string {
+	var key = "report.repository.elasticsearch.indices"
+	if rc.historyIndices == nil {
+		if _, ok := viper.Get(key).([]interface{}); !ok {
+			viper.Set(key, defaultHistoryIndices)
+		}
+		rc.historyIndices = _strings(viper.GetStringSlice(key))
+	}
+	return *rc.historyIndices
+}
+
+// implement FetchTimeout method of esReportRepositoryConfig interface
+func (rc *reportConfig) FetchTimeout() time.Duration {
+	var key = "report.repository.elasticsearch.fetchTimeout"
+	if rc.fetchTimeout != nil {
+		return *rc.fetchTimeout
+	}
+
+	d, err := time.ParseDuration(viper.GetString(key))
+	if err != nil || d <= 0 {
+		viper.Set(key, defaultFetchTimeout.String())
+		d = defaultFetchTimeout
+	}
+
+	rc.fetchTimeout = &d
+	return *rc.fetchTimeout
+}
+
+// implement SlackChannel method of reportUsecaseConfig interface
+func (rc *reportConfig) SlackChannel() string {
+	var key = "report.slack.channel"
+	if rc.slackChannel == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, defaultSlackChannel)
+		}
+		rc.slackChannel = _string(viper.GetString(key))
+	}
+	return *rc.slackChannel
+}
+
+// not implement any interface, just using in main function for delivery layer injection
+// schedule spec is interval or cron expression with optional jitter, timezone of scheduler is applied to cron expression
+func (rc *reportConfig) DailyDeliverySchedule() string {
+	var key = "report.delivery.channel.schedule.daily"
+	if rc.dailyDeliverySchedule == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, defaultDailyDeliverySchedule)
+		}
+		rc.dailyDeliverySchedule = _string(viper.GetString(key))
+	}
+	return *rc.dailyDeliverySchedule
+}
+
+// not implement any interface, just using in main function for delivery layer injection
+// schedule spec is interval or cron expression with optional jitter, timezone of scheduler is applied to cron expression
+func (rc *reportConfig) WeeklyDeliverySchedule() string {
+	var key = "report.delivery.channel.schedule.weekly"
+	if rc.weeklyDeliverySchedule == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, defaultWeeklyDeliverySchedule)
+		}
+		rc.weeklyDeliverySchedule = _string(viper.GetString(key))
+	}
+	return *rc.weeklyDeliverySchedule
+}
+
+// init function initialize App global variable
+func init() {
+	App = &reportConfig{}
+}
+
+// function returns pointer variable generated from parameter
+func _string(s string) *string      { return &s }
+func _strings(s []string) *[]string { return &s }
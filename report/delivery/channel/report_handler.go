@@ -0,0 +1,51 @@
+// Create file in v.1.1.0
+// in report_handler.go file, define delivery from channel msg to report usecase handler
+// daily & weekly report is published whenever run time is delivered from each scheduler
+
+package channel
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// reportHandler is delivered data handler about digest report using usecase layer
+type reportHandler struct {
+	// handlerCtx is used for handling delivered channel using context
+	handlerCtx reportHandlerContext
+
+	// rUsecase is usecase layer interface which is injected from package outside (maybe, in main)
+	rUsecase domain.ReportUseCase
+}
+
+// NewReportHandler define reportHandler ptr instance & register handling channel msg of daily, weekly scheduler to usecase
+func NewReportHandler(daily, weekly reportScheduler, ru domain.ReportUseCase) {
+	handler := &reportHandler{
+		handlerCtx: globalContext,
+		rUsecase:   ru,
+	}
+
+	go handler.startListening(daily.C(), domain.ReportPeriodDaily)
+	go handler.startListening(weekly.C(), domain.ReportPeriodWeekly)
+	log.Println("START TO LISTEN CHANNEL MSG ABOUT DAILY & WEEKLY REPORT")
+}
+
+// startListening method start listening using handlerCtx field startListening method
+func (rh *reportHandler) startListening(c <-chan time.Time, period string) {
+	rh.handlerCtx.startListening(c, func(t time.Time) {
+		rh.publishReport(t, period)
+	})
+}
+
+// publishReport method set context derived from global context & call usecase PublishReport method, handle error
+func (rh *reportHandler) publishReport(t time.Time, period string) {
+	ctx := rh.handlerCtx.chanCancelCtx
+	ctx = context.WithValue(ctx, "time", t)
+
+	if err := rh.rUsecase.PublishReport(ctx, period); err != nil {
+		log.Printf("error occurs in PublishReport, period: %s, err: %v", period, err)
+	}
+}
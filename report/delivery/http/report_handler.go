@@ -0,0 +1,150 @@
+// Create package in v.1.1.0
+// http package is for delivery layer exposing digest report of report domain to HTTP API endpoint
+// report is responded as JSON or Markdown document, which can be pasted to wiki or email as it is
+
+package http
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// const value represent format of report responded from HTTP API, decided with ?format= in query
+const (
+	formatJSON     = "json"     // represent that report is responded as JSON object (default)
+	formatMarkdown = "markdown" // represent that report is responded as Markdown document
+)
+
+// reportTimeLayout is layout of time range rendered in Markdown document
+const reportTimeLayout = "2006-01-02 15:04"
+
+// reportHandler represent the http handler for report
+type reportHandler struct {
+	rUsecase domain.ReportUseCase
+}
+
+// readerAuthenticator is interface that authenticate request to endpoint reading report
+// you can see implementation in auth package
+type readerAuthenticator interface {
+	// AuthenticateReader return gin middleware which abort request if credential having reader role is not authenticated
+	AuthenticateReader() gin.HandlerFunc
+}
+
+// NewReportHandler initialize the resources of report domain to HTTP API endpoint
+func NewReportHandler(r *gin.Engine, ra readerAuthenticator, ru domain.ReportUseCase) {
+	h := &reportHandler{
+		rUsecase: ru,
+	}
+
+	r.GET("reports/:period", ra.AuthenticateReader(), h.GetReport)
+}
+
+// GetReport method respond report of period in path as JSON or Markdown with ?format= in query
+// report period ends now, or at the time of ?end= in query (RFC3339, Ex, 2021-06-07T09:00:00+09:00)
+func (rh *reportHandler) GetReport(c *gin.Context) {
+	var end time.Time
+	if s := c.Query("end"); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": http.StatusBadRequest, "code": 0,
+				"message": errors.Wrap(err, "end in query must be RFC3339 time").Error(),
+			})
+			return
+		}
+		end = t
+	}
+
+	format := c.DefaultQuery("format", formatJSON)
+	if format != formatJSON && format != formatMarkdown {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": http.StatusBadRequest, "code": 0,
+			"message": fmt.Sprintf("format in query must be %s or %s", formatJSON, formatMarkdown),
+		})
+		return
+	}
+
+	report, err := rh.rUsecase.GenerateReport(c.Request.Context(), c.Param("period"), end)
+	switch err {
+	case nil:
+		break
+	case domain.ErrInvalidReportPeriod:
+		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "code": 0, "message": err.Error()})
+		return
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": http.StatusInternalServerError, "code": 0,
+			"message": errors.Wrap(err, "failed to generate report").Error(),
+		})
+		return
+	}
+
+	if format == formatMarkdown {
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(reportToMarkdown(report)))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": http.StatusOK, "code": 0, "message": fmt.Sprintf("succeed to generate %s report", report.Period),
+		"report": reportToJSON(report),
+	})
+}
+
+// reportToJSON convert domain.Report to JSON object used in response of HTTP API
+func reportToJSON(report domain.Report) gin.H {
+	checks := make([]gin.H, len(report.Checks))
+	for i, check := range report.Checks {
+		checks[i] = gin.H{
+			"domain":            check.Domain,
+			"type":              check.Type,
+			"runs":              check.Runs,
+			"metric":            check.Metric,
+			"unit":              check.Unit,
+			"min":               check.Min,
+			"avg":               check.Avg,
+			"max":               check.Max,
+			"warnings":          check.Warnings,
+			"weak_detections":   check.WeakDetections,
+			"remediations":      check.Remediations,
+			"unhealthy_seconds": check.Unhealthy.Seconds(),
+		}
+	}
+
+	return gin.H{
+		"period": report.Period,
+		"from":   report.From,
+		"to":     report.To,
+		"checks": checks,
+	}
+}
+
+// reportToMarkdown convert domain.Report to Markdown document having table with one row per check
+func reportToMarkdown(report domain.Report) string {
+	b := &strings.Builder{}
+	_, _ = fmt.Fprintf(b, "# %s Health Check Report\n\n", strings.Title(report.Period))
+	_, _ = fmt.Fprintf(b, "%s ~ %s\n\n", report.From.Format(reportTimeLayout), report.To.Format(reportTimeLayout))
+
+	if len(report.Checks) == 0 {
+		b.WriteString("No check history was stored in report period.\n")
+		return b.String()
+	}
+
+	b.WriteString("| Check | Runs | Metric | Min | Avg | Max | Warnings | Weak Detections | Remediations | Unhealthy |\n")
+	b.WriteString("|---|---:|---|---:|---:|---:|---:|---:|---:|---:|\n")
+	for _, check := range report.Checks {
+		min, avg, max := "-", "-", "-"
+		if check.Metric != "" {
+			min, avg, max = check.FormatValue(check.Min), check.FormatValue(check.Avg), check.FormatValue(check.Max)
+		}
+		_, _ = fmt.Fprintf(b, "| %s/%s | %d | %s | %s | %s | %s | %d | %d | %d | %s |\n",
+			check.Domain, check.Type, check.Runs, check.Metric, min, avg, max,
+			check.Warnings, check.WeakDetections, check.Remediations, check.Unhealthy.Round(time.Second))
+	}
+	return b.String()
+}
@@ -0,0 +1,128 @@
+// Create package in v.1.1.0
+// elasticsearch package is for implementations of report domain repository using elasticsearch
+// report repository only read check history stored by syscheck, srvcheck repository, so it doesn't migrate any index
+
+// report_repo.go is file that define implement report repository using elasticsearch
+// check history of report period is fetched with scroll API, because number of history can be more than max result window
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/pkg/errors"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// const value about scrolling check history with scroll API
+const (
+	scrollSize    = 1000            // number of check history fetched in one scroll request
+	scrollTimeout = time.Minute * 1 // duration of keeping search context alive between scroll requests
+)
+
+// esReportRepository is to fetch check history summarised in report using elasticsearch as data store
+type esReportRepository struct {
+	// myCfg is used for get report repository config about elasticsearch
+	myCfg esReportRepositoryConfig
+
+	// esCli is elasticsearch client connection injected from the outside package
+	esCli *elasticsearch.Client
+}
+
+// esReportRepositoryConfig is the config for report repository using elasticsearch
+type esReportRepositoryConfig interface {
+	// HistoryIndices method returns index name or pattern of elasticsearch index including check history
+	HistoryIndices() []string
+
+	// FetchTimeout method returns max duration of fetching check history of report period
+	FetchTimeout() time.Duration
+}
+
+// scrollResponse is binding struct of response body about search & scroll request
+type scrollResponse struct {
+	ScrollID string `json:"_scroll_id"`
+	Hits     struct {
+		Hits []struct {
+			Source map[string]interface{} `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// NewESReportRepository return new object that implement ReportRepository interface
+func NewESReportRepository(cfg esReportRepositoryConfig, cli *elasticsearch.Client) domain.ReportRepository {
+	return &esReportRepository{
+		myCfg: cfg,
+		esCli: cli,
+	}
+}
+
+// Implement FetchHistories method of ReportRepository interface
+func (esr *esReportRepository) FetchHistories(ctx context.Context, from, to time.Time) (histories []map[string]interface{}, err error) {
+	ctx, cancel := context.WithTimeout(ctx, esr.myCfg.FetchTimeout())
+	defer cancel()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"size": scrollSize,
+		"sort": []interface{}{map[string]interface{}{"@timestamp": "asc"}},
+		"query": map[string]interface{}{
+			"range": map[string]interface{}{
+				"@timestamp": map[string]interface{}{
+					"gte": from.Format(time.RFC3339Nano),
+					"lt":  to.Format(time.RFC3339Nano),
+				},
+			},
+		},
+	})
+
+	ignoreUnavailable, allowNoIndices := true, true
+	resp, err := (esapi.SearchRequest{
+		Index:             esr.myCfg.HistoryIndices(),
+		Body:              bytes.NewReader(body),
+		Scroll:            scrollTimeout,
+		IgnoreUnavailable: &ignoreUnavailable,
+		AllowNoIndices:    &allowNoIndices,
+	}).Do(ctx, esr.esCli)
+
+	var scrollID string
+	defer func() {
+		if scrollID != "" {
+			_, _ = (esapi.ClearScrollRequest{ScrollID: []string{scrollID}}).Do(context.Background(), esr.esCli)
+		}
+	}()
+
+	for {
+		if err != nil {
+			err = errors.Wrap(err, "failed to call SearchRequest or ScrollRequest")
+			return
+		}
+
+		result := scrollResponse{}
+		if resp.IsError() {
+			err = errors.Errorf("SearchRequest or ScrollRequest return error code, resp: %+v", resp)
+		} else if decodeErr := json.NewDecoder(resp.Body).Decode(&result); decodeErr != nil {
+			err = errors.Wrap(decodeErr, "failed to decode response body")
+		}
+		_ = resp.Body.Close()
+		if err != nil {
+			return
+		}
+
+		scrollID = result.ScrollID
+		for _, hit := range result.Hits.Hits {
+			histories = append(histories, hit.Source)
+		}
+		if len(result.Hits.Hits) < scrollSize {
+			return
+		}
+
+		resp, err = (esapi.ScrollRequest{
+			ScrollID: scrollID,
+			Scroll:   scrollTimeout,
+		}).Do(ctx, esr.esCli)
+	}
+}
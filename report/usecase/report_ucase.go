@@ -0,0 +1,371 @@
+// Create package in v.1.1.0
+// usecase package declare implementation of usecase interface about report domain
+// all usecase implementation will accept any input from Delivery layer
+// This usecase layer will depends to Repository layer
+
+// report_ucase.go is file that define usecase implementation about digest report summarising check history
+// check history is fetched from repository as dotted map & summarised per check with process level & measured value
+
+package usecase
+
+import (
+	"context"
+	"github.com/inhies/go-bytesize"
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// process level recorded in check history, which is same as const value declared in syscheck, srvcheck usecase
+const (
+	healthyLevel      = "HEALTHY"
+	warningLevel      = "WARNING"
+	weakDetectedLevel = "WEAK_DETECTED"
+	recoveredLevel    = "RECOVERED"
+	unhealthyLevel    = "UNHEALTHY"
+	acknowledgedLevel = "ACKNOWLEDGED"
+	resetLevel        = "RESET"
+	suppressedLevel   = "SUPPRESSED"
+	skippedLevel      = "SKIPPED"
+	escalatedLevel    = "ESCALATED"
+	simulatedLevel    = "SIMULATED"
+)
+
+// reportUsecase implement ReportUseCase interface in domain and used in delivery layer
+type reportUsecase struct {
+	// myCfg is used for getting report usecase config
+	myCfg reportUsecaseConfig
+
+	// historyRepo is used for fetching check history summarised in report and injected from outside
+	historyRepo domain.ReportRepository
+
+	// slackAgency is used as agency about slack API posting report
+	slackAgency slackAgency
+
+	// clock is used for getting current time in configured timezone, which is end of report period by default
+	clock clock
+}
+
+// reportUsecaseConfig is interface get config value for report usecase
+type reportUsecaseConfig interface {
+	// SlackChannel method returns slack channel to post report, chat channel of slack agency is used if empty
+	SlackChannel() string
+}
+
+// slackAgency is interface that agent slack API about posting report
+// you can see implementation in slack package
+type slackAgency interface {
+	// SendReport method post report as formatted message to slack channel
+	SendReport(ctx context.Context, cnl string, report domain.Report) (time.Time, error)
+}
+
+// clock is interface that return current time in configured timezone, so that report period can be faked in test
+// you can see implementation in clock package
+type clock interface {
+	// Now method returns current time in timezone of clock
+	Now() time.Time
+}
+
+// checkMetric is struct that declare value measured in check history of one check type, summarised in report
+type checkMetric struct {
+	// key is key of measured value in dotted map of check history (Ex, total_usage_core)
+	key string
+
+	// unit is unit of measured value, one of domain.ReportUnit const value
+	unit string
+
+	// remediated return if remediation was performed in check history, regardless of success
+	remediated func(history map[string]interface{}) bool
+}
+
+// checkMetrics is checkMetric per check kind, value isn't summarised for check kind not declared
+var checkMetrics = map[string]checkMetric{
+	"DiskCheck": {key: "remaining_capacity", unit: domain.ReportUnitBytes, remediated: func(h map[string]interface{}) bool {
+		v, ok := valueOf(h["reclaimed_capacity"])
+		return ok && v > 0
+	}},
+	"CPUCheck": {key: "total_usage_core", unit: domain.ReportUnitCore, remediated: func(h map[string]interface{}) bool {
+		v, ok := valueOf(h["temporary_free_core"])
+		return ok && v > 0
+	}},
+	"MemoryCheck": {key: "total_usage_memory", unit: domain.ReportUnitBytes, remediated: func(h map[string]interface{}) bool {
+		v, ok := valueOf(h["temporary_free_memory"])
+		return ok && v > 0
+	}},
+	"ElasticsearchCheck": {key: "active_shards", unit: domain.ReportUnitShards, remediated: func(h map[string]interface{}) bool {
+		return h["if_jaeger_index_deleted"] == true
+	}},
+	"SwarmpitCheck": {key: "swarmpit_app_memory_usage", unit: domain.ReportUnitBytes, remediated: func(h map[string]interface{}) bool {
+		return h["if_swarmpit_app_restarted"] == true
+	}},
+	"ConsulCheck": {key: "instances_per_service", unit: domain.ReportUnitInstance, remediated: func(h map[string]interface{}) bool {
+		return h["if_instance_deregistered"] == true || h["if_container_restarted"] == true
+	}},
+}
+
+// NewReportUsecase function return reportUsecase ptr instance after initializing
+func NewReportUsecase(
+	cfg reportUsecaseConfig,
+	hr domain.ReportRepository,
+	sa slackAgency,
+	c clock,
+) domain.ReportUseCase {
+	return &reportUsecase{
+		myCfg:       cfg,
+		historyRepo: hr,
+		slackAgency: sa,
+		clock:       c,
+	}
+}
+
+// GenerateReport summarise check history of period ending at end time (or now if zero) fetched from repository & return report
+// Implement GenerateReport method of domain.ReportUseCase interface
+func (ru *reportUsecase) GenerateReport(ctx context.Context, period string, end time.Time) (report domain.Report, err error) {
+	if end.IsZero() {
+		end = ru.clock.Now()
+	}
+
+	from, err := domain.ReportPeriodRange(period, end)
+	if err != nil {
+		return
+	}
+
+	histories, err := ru.historyRepo.FetchHistories(ctx, from, end)
+	if err != nil {
+		err = errors.Wrap(err, "failed to fetch check history of report period")
+		return
+	}
+
+	summaries := map[string]*checkSummary{}
+	var keys []string
+	for _, history := range histories {
+		_domain, _ := history["domain"].(string)
+		_type, _ := history["type"].(string)
+		timestamp, parseErr := time.Parse(time.RFC3339Nano, stringOf(history["@timestamp"]))
+		if _domain == "" || _type == "" || parseErr != nil {
+			continue
+		}
+
+		// kind is used to find metric of check instance declared with own name, history stored before kind is added hasn't that
+		kind, _ := history["kind"].(string)
+		if kind == "" {
+			kind = _type
+		}
+
+		key := _domain + "/" + _type
+		if _, ok := summaries[key]; !ok {
+			summaries[key] = newCheckSummary(_domain, _type, kind, from)
+			keys = append(keys, key)
+		}
+		summaries[key].add(history, timestamp)
+	}
+	sort.Strings(keys)
+
+	report = domain.Report{Period: period, From: from, To: end, Checks: make([]domain.CheckReport, len(keys))}
+	for i, key := range keys {
+		report.Checks[i] = summaries[key].report(end)
+	}
+	return
+}
+
+// PublishReport generate report of period ending at the time delivered in context (or now) & post that to slack
+// Implement PublishReport method of domain.ReportUseCase interface
+func (ru *reportUsecase) PublishReport(ctx context.Context, period string) error {
+	end, _ := ctx.Value("time").(time.Time)
+	report, err := ru.GenerateReport(ctx, period, end)
+	if err != nil {
+		return errors.Wrapf(err, "failed to generate %s report", period)
+	}
+
+	if _, err := ru.slackAgency.SendReport(ctx, ru.myCfg.SlackChannel(), report); err != nil {
+		return errors.Wrapf(err, "failed to post %s report to slack", period)
+	}
+	return nil
+}
+
+// checkSummary is struct that accumulate check history of one check in order of timestamp & build CheckReport
+type checkSummary struct {
+	// result is CheckReport being built, Avg field has sum of measured value until report method is called
+	result domain.CheckReport
+
+	// measured is number of check history having measured value
+	measured int
+
+	// metric is checkMetric of check kind, zero value if check kind isn't declared in checkMetrics
+	metric checkMetric
+
+	// from is start time of report period, unhealthy duration before that isn't counted
+	from time.Time
+
+	// unhealthy specifies if check is unhealthy at the time of check history added lastly
+	unhealthy bool
+
+	// unhealthySince is the time when check became unhealthy, which is weak detection failed to be recovered
+	unhealthySince time.Time
+
+	// weakDetectedAt is the time when weak of check was detected lastly, zero if check is healthy after that
+	weakDetectedAt time.Time
+}
+
+// newCheckSummary return new checkSummary pointer instance about check of domain & type, metric is decided with kind
+func newCheckSummary(_domain, _type, kind string, from time.Time) *checkSummary {
+	metric := checkMetrics[kind]
+	return &checkSummary{
+		result: domain.CheckReport{Domain: _domain, Type: _type, Kind: kind, Metric: metric.key, Unit: metric.unit},
+		metric: metric,
+		from:   from,
+	}
+}
+
+// add method accumulate check history created at timestamp, it must be called in order of timestamp
+// check is regarded as unhealthy from weak detection followed by unhealthy history to recovered or reset history
+func (cs *checkSummary) add(history map[string]interface{}, timestamp time.Time) {
+	levels := strings.Split(stringOf(history["process_level"]), " | ")
+	simulated := history["simulated"] == true
+
+	switch {
+	case contains(levels, acknowledgedLevel), contains(levels, resetLevel), contains(levels, skippedLevel):
+		// operation history or skipped check process isn't counted as check process run
+	default:
+		cs.result.Runs++
+	}
+
+	if contains(levels, warningLevel) {
+		cs.result.Warnings++
+	}
+	if contains(levels, weakDetectedLevel) {
+		cs.result.WeakDetections++
+		if !simulated {
+			cs.weakDetectedAt = timestamp
+		}
+	}
+	if cs.metric.remediated != nil && !simulated && cs.metric.remediated(history) {
+		cs.result.Remediations++
+	}
+
+	if measuredAt(levels) {
+		if v, ok := valueOf(history[cs.metric.key]); ok {
+			if cs.measured == 0 || v < cs.result.Min {
+				cs.result.Min = v
+			}
+			if cs.measured == 0 || v > cs.result.Max {
+				cs.result.Max = v
+			}
+			cs.result.Avg += v
+			cs.measured++
+		}
+	}
+
+	switch {
+	case contains(levels, unhealthyLevel), contains(levels, escalatedLevel):
+		if !cs.unhealthy {
+			cs.unhealthy = true
+			cs.unhealthySince = timestamp
+			if !cs.weakDetectedAt.IsZero() {
+				cs.unhealthySince = cs.weakDetectedAt
+			}
+		}
+	case contains(levels, healthyLevel), contains(levels, warningLevel), contains(levels, recoveredLevel), contains(levels, resetLevel):
+		if cs.unhealthy {
+			cs.addUnhealthy(timestamp)
+		}
+		cs.unhealthy = false
+		cs.weakDetectedAt = time.Time{}
+	}
+}
+
+// report method return CheckReport accumulated until now, check still unhealthy is counted until end of report period
+func (cs *checkSummary) report(end time.Time) domain.CheckReport {
+	if cs.unhealthy {
+		cs.addUnhealthy(end)
+		cs.unhealthy = false
+	}
+
+	result := cs.result
+	if cs.measured > 0 {
+		result.Avg /= float64(cs.measured)
+	}
+	return result
+}
+
+// addUnhealthy method add duration from unhealthySince (or start of period) to t into Unhealthy field of result
+func (cs *checkSummary) addUnhealthy(t time.Time) {
+	since := cs.unhealthySince
+	if since.Before(cs.from) {
+		since = cs.from
+	}
+	if t.After(since) {
+		cs.result.Unhealthy += t.Sub(since)
+	}
+}
+
+// measuredAt return if value was measured in check process with process levels, which isn't paused, failed, etc.
+func measuredAt(levels []string) bool {
+	for _, level := range []string{healthyLevel, warningLevel, weakDetectedLevel, recoveredLevel, unhealthyLevel, suppressedLevel, simulatedLevel} {
+		if contains(levels, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueOf convert measured value decoded from check history to float64, return false if value isn't measured
+// number is returned as it is, byte size string (Ex, 2.00GB) is parsed and map (Ex, instances per service) is counted
+func valueOf(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case string:
+		return parseByteSize(v)
+	case map[string]interface{}:
+		var count int
+		for _, values := range v {
+			if values, ok := values.([]interface{}); ok {
+				count += len(values)
+			}
+		}
+		return float64(count), len(v) > 0
+	default:
+		return 0, false
+	}
+}
+
+// parseByteSize parse byte size string formatted by bytesize package (Ex, 2.00GB), return false if s isn't byte size
+// bytesize.Parse isn't used directly, because it can't parse size having decimal point
+func parseByteSize(s string) (float64, bool) {
+	i := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' })
+	if i <= 0 {
+		return 0, false
+	}
+
+	n, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, false
+	}
+	unit, err := bytesize.Parse("1" + s[i:])
+	if err != nil {
+		return 0, false
+	}
+	return n * float64(unit), true
+}
+
+// stringOf return v if it's string, or empty string
+func stringOf(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+// contains return if levels contains level
+func contains(levels []string, level string) bool {
+	for _, l := range levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
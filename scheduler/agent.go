@@ -0,0 +1,97 @@
+// Create package in v.1.1.0
+// scheduler package define struct which is implement various interface about scheduling check process
+// scheduler deliver the time when check process should be run with golang channel to channel delivery
+
+// in agent.go file, define struct type of scheduler agent & initializer that are not method.
+// Also if exist, custom type or variable used in common in each of method will declared in this file.
+
+package scheduler
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// schedulerAgent create & manage scheduler of every check process
+type schedulerAgent struct {
+	// clock is used for getting current time & timezone used for calculating next run time of cron expression
+	clock clock
+
+	// schedulers is list of scheduler created from agent
+	schedulers []*checkScheduler
+
+	// random is used for calculating jitter added to next run time
+	random *rand.Rand
+
+	// mutex help to prevent race condition when access schedulers, random field
+	mutex sync.Mutex
+}
+
+// clock is interface that return current time in configured timezone & timer, so that schedule can be driven in test
+// you can see implementation in clock package
+type clock interface {
+	// Now method returns current time in timezone of clock
+	Now() time.Time
+
+	// NewTimer method returns timer firing after duration d
+	NewTimer(d time.Duration) domain.Timer
+}
+
+// NewAgent return new initialized instance of schedulerAgent pointer type with clock, timezone of clock is used for cron
+func NewAgent(c clock) *schedulerAgent {
+	return &schedulerAgent{
+		clock:      c,
+		schedulers: []*checkScheduler{},
+		random:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		mutex:      sync.Mutex{},
+	}
+}
+
+// jitterSeparator is separator of jitter in schedule spec (Ex, 5m ~30s)
+const jitterSeparator = "~"
+
+// parseSpec parse schedule spec to cron schedule & jitter, return error if spec is invalid
+// spec is interval (Ex, 5m), cron expression (Ex, 0 3 * * *) or descriptor (Ex, @every 5m, @daily)
+// and jitter can be added to the end of spec (Ex, 5m ~30s), next run time is delayed randomly within jitter
+func parseSpec(spec string) (schedule cron.Schedule, jitter time.Duration, err error) {
+	expr := strings.TrimSpace(spec)
+	if i := strings.LastIndex(expr, jitterSeparator); i != -1 {
+		if jitter, err = time.ParseDuration(strings.TrimSpace(expr[i+len(jitterSeparator):])); err != nil || jitter < 0 {
+			err = fmt.Errorf("invalid jitter in schedule spec, spec: %s", spec)
+			return
+		}
+		expr = strings.TrimSpace(expr[:i])
+	}
+
+	if interval, parseErr := time.ParseDuration(expr); parseErr == nil {
+		if interval < time.Second {
+			err = fmt.Errorf("interval must be at least 1s, spec: %s", spec)
+			return
+		}
+		schedule = cron.Every(interval)
+		return
+	}
+
+	if schedule, err = cron.ParseStandard(expr); err != nil {
+		err = errors.Wrapf(err, "invalid schedule spec, spec: %s", spec)
+	}
+	return
+}
+
+// randomJitter return random duration in [0, jitter) using random field with mutex Lock & Unlock
+func (sa *schedulerAgent) randomJitter(jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+
+	sa.mutex.Lock()
+	defer sa.mutex.Unlock()
+	return time.Duration(sa.random.Int63n(int64(jitter)))
+}
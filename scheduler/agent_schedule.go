@@ -0,0 +1,53 @@
+// Create file in v.1.1.0
+// agent_schedule.go file define method of schedulerAgent about inquiring & changing schedule of check process
+// implement interface about check schedule manager defined in control delivery
+
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// Schedules return schedule & next run time of every scheduler created from agent
+func (sa *schedulerAgent) Schedules() (schedules []domain.CheckSchedule) {
+	sa.mutex.Lock()
+	schedulers := append([]*checkScheduler{}, sa.schedulers...)
+	sa.mutex.Unlock()
+
+	schedules = make([]domain.CheckSchedule, len(schedulers))
+	for i, cs := range schedulers {
+		schedules[i] = cs.checkSchedule()
+	}
+	return
+}
+
+// Reschedule change schedule of check with domain & type to spec, return changed schedule
+// _type is matched with check type without Check suffix ignoring case (Ex, cpu -> CPUCheck)
+func (sa *schedulerAgent) Reschedule(_domain, _type, spec string) (schedule domain.CheckSchedule, err error) {
+	sa.mutex.Lock()
+	schedulers := append([]*checkScheduler{}, sa.schedulers...)
+	sa.mutex.Unlock()
+
+	for _, cs := range schedulers {
+		if cs.domain != _domain || !strings.EqualFold(strings.TrimSuffix(cs._type, "Check"), strings.TrimSuffix(_type, "Check")) {
+			continue
+		}
+		if err = cs.reschedule(spec); err != nil {
+			return
+		}
+		schedule = cs.checkSchedule()
+		return
+	}
+
+	err = fmt.Errorf("scheduler is not exist, domain: %s, type: %s", _domain, _type)
+	return
+}
+
+// ValidateSpec return error if schedule spec is invalid, so that spec can be validated before it is applied
+func (sa *schedulerAgent) ValidateSpec(spec string) error {
+	_, _, err := parseSpec(spec)
+	return err
+}
@@ -0,0 +1,149 @@
+// Create file in v.1.1.0
+// scheduler.go file define checkScheduler which deliver run time of check process with golang channel
+// implement interface about check scheduler defined in each of channel delivery
+
+package scheduler
+
+import (
+	"context"
+	"github.com/robfig/cron/v3"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// checkScheduler deliver the time when check process should be run to channel with schedule spec
+type checkScheduler struct {
+	// agent is scheduler agent which created this scheduler
+	agent *schedulerAgent
+
+	// domain, _type specifies domain & check type of scheduled check
+	domain, _type string
+
+	// spec, schedule, jitter specifies schedule of check process parsed from spec
+	spec     string
+	schedule cron.Schedule
+	jitter   time.Duration
+
+	// next specifies the time when check process will be run next
+	next time.Time
+
+	// c is channel to deliver the time when check process should be run
+	c chan time.Time
+
+	// reset is channel to notify that schedule is changed to run loop
+	reset chan struct{}
+
+	// mutex help to prevent race condition when access schedule fields
+	mutex sync.Mutex
+}
+
+// NewScheduler create & start scheduler of check with domain, type & spec until ctx is done
+// if initialRun is true, check process is run as soon as scheduler is started
+func (sa *schedulerAgent) NewScheduler(ctx context.Context, _domain, _type, spec string, initialRun bool) (*checkScheduler, error) {
+	schedule, jitter, err := parseSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := &checkScheduler{
+		agent:    sa,
+		domain:   _domain,
+		_type:    _type,
+		spec:     spec,
+		schedule: schedule,
+		jitter:   jitter,
+		c:        make(chan time.Time, 1),
+		reset:    make(chan struct{}, 1),
+	}
+
+	sa.mutex.Lock()
+	sa.schedulers = append(sa.schedulers, cs)
+	sa.mutex.Unlock()
+
+	go cs.run(ctx, initialRun)
+	return cs, nil
+}
+
+// C return channel receiving the time when check process should be run
+func (cs *checkScheduler) C() <-chan time.Time {
+	return cs.c
+}
+
+// run method deliver the time to channel whenever next run time is reached until ctx is done
+func (cs *checkScheduler) run(ctx context.Context, initialRun bool) {
+	if initialRun {
+		cs.deliver(cs.agent.clock.Now())
+	}
+
+	cs.mutex.Lock()
+	cs.updateNext()
+	cs.mutex.Unlock()
+
+	for {
+		cs.mutex.Lock()
+		timer := cs.agent.clock.NewTimer(cs.next.Sub(cs.agent.clock.Now()))
+		cs.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-cs.reset:
+			timer.Stop()
+		case t := <-timer.C():
+			cs.deliver(t)
+			cs.mutex.Lock()
+			cs.updateNext()
+			cs.mutex.Unlock()
+		}
+	}
+}
+
+// updateNext method calculate next run time with schedule & jitter, it must be called while holding mutex
+func (cs *checkScheduler) updateNext() {
+	cs.next = cs.schedule.Next(cs.agent.clock.Now()).Add(cs.agent.randomJitter(cs.jitter))
+}
+
+// deliver method send the time to channel, drop if previous time is not received yet like time.Ticker
+func (cs *checkScheduler) deliver(t time.Time) {
+	select {
+	case cs.c <- t:
+	default:
+		log.Printf("scheduled time of %s %s is dropped because previous one is not received yet", cs.domain, cs._type)
+	}
+}
+
+// reschedule method change schedule with spec & notify to run loop, return error if spec is invalid
+func (cs *checkScheduler) reschedule(spec string) error {
+	schedule, jitter, err := parseSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	cs.mutex.Lock()
+	cs.spec, cs.schedule, cs.jitter = spec, schedule, jitter
+	cs.updateNext()
+	cs.mutex.Unlock()
+
+	select {
+	case cs.reset <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// checkSchedule method return domain.CheckSchedule with current schedule & next run time
+func (cs *checkScheduler) checkSchedule() domain.CheckSchedule {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	return domain.CheckSchedule{
+		Domain:      cs.domain,
+		Type:        cs._type,
+		Spec:        cs.spec,
+		NextRunTime: cs.next,
+	}
+}
@@ -7,7 +7,13 @@
 
 package slack
 
-import "github.com/slack-go/slack"
+import (
+	"github.com/slack-go/slack"
+	"sync"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
 
 // slackAgent agent various slack API(chat, conversations, admin, etc ...) as implementation
 type slackAgent struct {
@@ -16,12 +22,51 @@ type slackAgent struct {
 
 	// chatChannel is having channel ID value to send chat in SendMessage method
 	chatChannel string
+
+	// signingSecret is secret used for verifying signature of request sent from slack (Ex, slash command)
+	signingSecret string
+
+	// kibanaURLTemplate is URL template of kibana deep link in alarm message, link is not rendered if empty
+	kibanaURLTemplate string
+
+	// threads is slack thread of incident not resolved yet per channel & check (channel/domain/type), alarm about incident is replied in that
+	threads map[string]alarmThread
+
+	// mutex is used for preventing race condition in threads, because every check send alarm concurrently
+	mutex sync.Mutex
+
+	// clock is used for converting timestamp of message sent to configured timezone
+	clock clock
+}
+
+// clock is interface that return configured timezone, so that time of alarm is recorded in that timezone
+// you can see implementation in clock package
+type clock interface {
+	// Location method returns timezone of clock
+	Location() *time.Location
+}
+
+// alarmThread is slack thread started with first alarm of incident, which later alarms about incident are replied in
+type alarmThread struct {
+	// incident specifies incident of alarms posted in this thread
+	incident string
+
+	// channel, ts specifies channel ID & timestamp of parent message, used for replying & updating parent message
+	channel, ts string
+
+	// parent specifies first alarm of incident posted as parent message
+	parent domain.Alarm
 }
 
-// NewAgent return new initialized instance of slackAgent pointer type with slack client & chat channel
-func NewAgent(token, cnl string) *slackAgent {
+// NewAgent return new initialized instance of slackAgent pointer type with slack client, chat channel, signing secret
+// and URL template of kibana deep link rendered in alarm message, clock is used for timezone of send time
+func NewAgent(token, cnl, secret, kibanaURL string, c clock) *slackAgent {
 	return &slackAgent{
-		slkCli:      slack.New(token),
-		chatChannel: cnl,
+		slkCli:            slack.New(token),
+		chatChannel:       cnl,
+		signingSecret:     secret,
+		kibanaURLTemplate: kibanaURL,
+		threads:           map[string]alarmThread{},
+		clock:             c,
 	}
 }
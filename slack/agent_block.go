@@ -0,0 +1,162 @@
+// Create file in v.1.1.0
+// agent_block.go file define method of slackAgent building block kit message of alarm with template per check type
+// block kit message has header, fields of alarm (measured value, threshold, target, node) & context with uuid, kibana link
+
+package slack
+
+import (
+	"fmt"
+	"github.com/slack-go/slack"
+	"strings"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// alarmTemplate is template of block kit message about alarm, which is defined per check type
+type alarmTemplate struct {
+	// title is title of check rendered in header block with level of alarm (Ex, CPU Check)
+	title string
+
+	// fields is list of alarm field rendered in section block in order, field not set in alarm is skipped
+	fields []templateField
+}
+
+// templateField is field of alarm rendered in section block with label
+type templateField struct {
+	// key is key of field in domain.Alarm Fields (Ex, domain.AlarmFieldMeasured)
+	key string
+
+	// label is label of field rendered above value of field (Ex, CPU Usage)
+	label string
+}
+
+// alarmTemplates is block kit message template per check kind, defaultAlarmTemplate is used for check kind not declared
+var alarmTemplates = map[string]alarmTemplate{
+	"DiskCheck": {title: "Disk Check", fields: []templateField{
+		{key: domain.AlarmFieldMeasured, label: "Remaining Capacity"},
+		{key: domain.AlarmFieldThreshold, label: "Threshold"},
+		{key: domain.AlarmFieldNode, label: "Node"},
+	}},
+	"CPUCheck": {title: "CPU Check", fields: []templateField{
+		{key: domain.AlarmFieldMeasured, label: "CPU Usage"},
+		{key: domain.AlarmFieldThreshold, label: "Threshold"},
+		{key: domain.AlarmFieldTarget, label: "Container"},
+		{key: domain.AlarmFieldNode, label: "Node"},
+	}},
+	"MemoryCheck": {title: "Memory Check", fields: []templateField{
+		{key: domain.AlarmFieldMeasured, label: "Memory Usage"},
+		{key: domain.AlarmFieldThreshold, label: "Threshold"},
+		{key: domain.AlarmFieldTarget, label: "Container"},
+		{key: domain.AlarmFieldNode, label: "Node"},
+	}},
+	"ElasticsearchCheck": {title: "Elasticsearch Check", fields: []templateField{
+		{key: domain.AlarmFieldMeasured, label: "Total Shards"},
+		{key: domain.AlarmFieldThreshold, label: "Threshold"},
+		{key: domain.AlarmFieldTarget, label: "Jaeger Indices"},
+		{key: domain.AlarmFieldNode, label: "Node"},
+	}},
+	"SwarmpitCheck": {title: "Swarmpit Check", fields: []templateField{
+		{key: domain.AlarmFieldMeasured, label: "Memory Usage"},
+		{key: domain.AlarmFieldThreshold, label: "Threshold"},
+		{key: domain.AlarmFieldTarget, label: "Service"},
+		{key: domain.AlarmFieldNode, label: "Node"},
+	}},
+	"ConsulCheck": {title: "Consul Check", fields: []templateField{
+		{key: domain.AlarmFieldMeasured, label: "Registered Instances"},
+		{key: domain.AlarmFieldThreshold, label: "Threshold"},
+		{key: domain.AlarmFieldTarget, label: "Instances"},
+		{key: domain.AlarmFieldNode, label: "Node"},
+	}},
+}
+
+// defaultAlarmTemplate is block kit message template used for check type not declared in alarmTemplates
+var defaultAlarmTemplate = alarmTemplate{fields: []templateField{
+	{key: domain.AlarmFieldMeasured, label: "Measured"},
+	{key: domain.AlarmFieldThreshold, label: "Threshold"},
+	{key: domain.AlarmFieldTarget, label: "Target"},
+	{key: domain.AlarmFieldNode, label: "Node"},
+}}
+
+// alarmBlocks return blocks of alarm message rendered with template of check type sending alarm
+// header has emoji, title & level, section has text & fields and context has check, uuid, incident & kibana link
+func (sa *slackAgent) alarmBlocks(alarm domain.Alarm) []slack.Block {
+	template := templateOf(alarm.Kind, alarm.Type)
+	header := fmt.Sprintf("%s · %s", template.title, alarm.Level)
+	if alarm.Emoji != "" {
+		header = fmt.Sprintf(":%s: %s", alarm.Emoji, header)
+	}
+
+	var fields []*slack.TextBlockObject
+	for _, field := range template.fields {
+		if value, ok := alarm.Fields[field.key]; ok {
+			fields = append(fields, slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*%s*\n%s", field.label, value), false, false))
+		}
+	}
+
+	context := []string{fmt.Sprintf("%s/%s", alarm.Domain, alarm.Type), fmt.Sprintf("uuid: `%s`", alarm.UUID)}
+	if alarm.Incident != "" {
+		context = append(context, fmt.Sprintf("incident: `%s`", alarm.Incident))
+	}
+	if url := sa.kibanaURL(alarm); url != "" {
+		context = append(context, fmt.Sprintf("<%s|View history in Kibana>", url))
+	}
+
+	return []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, header, true, false)),
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, alarm.Text, false, false), fields, nil),
+		slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, strings.Join(context, " | "), false, false)),
+	}
+}
+
+// incidentBlocks return blocks of parent message of incident thread, updated with outcome of latest alarm in thread
+// header has emoji & level of latest alarm with state of incident, and context about latest alarm is added to parent
+func (sa *slackAgent) incidentBlocks(parent, latest domain.Alarm) []slack.Block {
+	state := "ongoing"
+	if latest.Resolved {
+		state = "resolved"
+	}
+
+	header := fmt.Sprintf("%s · %s (%s)", templateOf(parent.Kind, parent.Type).title, latest.Level, state)
+	if latest.Emoji != "" {
+		header = fmt.Sprintf(":%s: %s", latest.Emoji, header)
+	}
+	outcome := fmt.Sprintf("*Latest* %s (uuid: `%s`), see thread for detail", latest.Text, latest.UUID)
+
+	blocks := sa.alarmBlocks(parent)
+	blocks[0] = slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, header, true, false))
+	return append(blocks, slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, outcome, false, false)))
+}
+
+// templateOf return alarm template of check kind, default template with title of check type is returned if not declared
+// type is looked up if kind is empty, and name of check instance is added to title if type is different from kind
+func templateOf(kind, _type string) alarmTemplate {
+	if kind == "" {
+		kind = _type
+	}
+
+	template, ok := alarmTemplates[kind]
+	switch {
+	case !ok:
+		template = defaultAlarmTemplate
+		template.title = _type
+	case kind != _type:
+		template.title = fmt.Sprintf("%s (%s)", template.title, _type)
+	}
+	return template
+}
+
+// kibanaURL return deep link of kibana about alarm built from URL template, return empty string if template is not set
+// {domain}, {type}, {level}, {uuid} and {incident} in template is replaced with value of alarm
+func (sa *slackAgent) kibanaURL(alarm domain.Alarm) string {
+	if sa.kibanaURLTemplate == "" {
+		return ""
+	}
+
+	return strings.NewReplacer(
+		"{domain}", alarm.Domain,
+		"{type}", alarm.Type,
+		"{level}", alarm.Level,
+		"{uuid}", alarm.UUID,
+		"{incident}", alarm.Incident,
+	).Replace(sa.kibanaURLTemplate)
+}
@@ -1,36 +1,94 @@
 // Create file in v.1.0.0
 // agent_chat.go file define method of slackAgent about slack chat API
-// implement agency interface about slack chat defined in each of domain
+// implement agency interface about slack chat defined in each of domain & alarm sender in notify package
 
 package slack
 
 import (
+	"context"
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/slack-go/slack"
+	"log"
 	"strconv"
 	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
 )
 
 // SendMessage send message with text & emoji using slack API and return send time & text & error
-func (sa *slackAgent) SendMessage(emoji, text, uuid string, opts ...slack.MsgOption) (t time.Time, _text string, err error) {
+func (sa *slackAgent) SendMessage(ctx context.Context, emoji, text, uuid string, opts ...slack.MsgOption) (t time.Time, _text string, err error) {
+	_, _, t, _text, err = sa.sendMessage(ctx, sa.chatChannel, emoji, text, uuid, opts...)
+	return
+}
+
+// sendMessage send message same as SendMessage to channel received from parameter
+// it also return channel ID & timestamp of message, which is used for replying in thread of message
+func (sa *slackAgent) sendMessage(ctx context.Context, cnl, emoji, text, uuid string, opts ...slack.MsgOption) (channel, ts string, t time.Time, _text string, err error) {
 	if emoji != "" {
 		_text = fmt.Sprintf(":%s: %s (%s)", emoji, text, uuid)
 	}
 
 	opts = append(opts, slack.MsgOptionText(_text, false))
-	_, _time, _, err := sa.slkCli.SendMessage(sa.chatChannel, opts...)
+	channel, ts, _, err = sa.slkCli.SendMessageContext(ctx, cnl, opts...)
 	if err != nil {
 		err = errors.Wrap(err, "failed to send message with slack API")
 		return
 	}
 
-	if len(_time) >= 10 {
-		i, _ := strconv.ParseInt(_time[:10], 10, 64)
-		t = time.Unix(i, 0)
-		if t.Location().String() == time.UTC.String() {
-			t = t.Add(time.Hour * 9)
-		}
+	if len(ts) >= 10 {
+		i, _ := strconv.ParseInt(ts[:10], 10, 64)
+		t = time.Unix(i, 0).In(sa.clock.Location())
 	}
 	return
 }
+
+// SendAlarm send alarm as block kit message rendered with template of check type to channel of alarm or chat channel
+// text with emoji & uuid of alarm is also sent as fallback of notification, implement alarmSender of notify package
+// if mention of alarm is set by routing rule (Ex, user group), message is started with that mention
+// first alarm of incident is posted as parent message, and later alarms about that is replied in thread of parent
+// whenever alarm is replied, parent message is updated with outcome of incident (Ex, recovered, unhealthy)
+func (sa *slackAgent) SendAlarm(ctx context.Context, alarm domain.Alarm) (time.Time, string, error) {
+	cnl := sa.chatChannel
+	if alarm.Channel != "" {
+		cnl = alarm.Channel
+	}
+	if alarm.Mention != "" {
+		alarm.Text = alarm.Mention + " " + alarm.Text
+	}
+
+	key := cnl + "/" + alarm.Domain + "/" + alarm.Type
+	blocks := slack.MsgOptionBlocks(sa.alarmBlocks(alarm)...)
+
+	sa.mutex.Lock()
+	thread, ok := sa.threads[key]
+	sa.mutex.Unlock()
+
+	if !ok || alarm.Incident == "" || thread.incident != alarm.Incident {
+		channel, ts, t, text, err := sa.sendMessage(ctx, cnl, alarm.Emoji, alarm.Text, alarm.UUID, blocks)
+		if err == nil && alarm.Incident != "" && !alarm.Resolved {
+			sa.mutex.Lock()
+			sa.threads[key] = alarmThread{incident: alarm.Incident, channel: channel, ts: ts, parent: alarm}
+			sa.mutex.Unlock()
+		}
+		return t, text, err
+	}
+
+	_, _, t, text, err := sa.sendMessage(ctx, cnl, alarm.Emoji, alarm.Text, alarm.UUID, blocks, slack.MsgOptionTS(thread.ts))
+	if err != nil {
+		return t, text, err
+	}
+
+	if alarm.Resolved {
+		sa.mutex.Lock()
+		delete(sa.threads, key)
+		sa.mutex.Unlock()
+	}
+
+	parentText := fmt.Sprintf(":%s: %s (%s)", thread.parent.Emoji, thread.parent.Text, thread.parent.UUID)
+	opts := []slack.MsgOption{slack.MsgOptionBlocks(sa.incidentBlocks(thread.parent, alarm)...), slack.MsgOptionText(parentText, false)}
+	if _, _, _, err := sa.slkCli.UpdateMessageContext(ctx, thread.channel, thread.ts, opts...); err != nil {
+		log.Println(errors.Wrapf(err, "failed to update parent message of incident thread, incident: %s", alarm.Incident))
+	}
+	return t, text, nil
+}
@@ -0,0 +1,83 @@
+// Create file in v.1.1.0
+// agent_command.go file define method of slackAgent about slack slash command
+// implement agency interface about slash command defined in control delivery
+
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+	"io/ioutil"
+	"log"
+	"net/http"
+)
+
+// inChannelResponseType is response type of slash command response which is visible to every member in channel
+const inChannelResponseType = "in_channel"
+
+// AuthenticateCommand return gin middleware which verify signature of slash command request with signing secret
+// request is aborted with 401 status code if signature is invalid or signing secret is not set
+func (sa *slackAgent) AuthenticateCommand() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := sa.verifyRequest(c.Request); err != nil {
+			log.Printf("slack command authentication denied, path: %s, client: %s, err: %v", c.Request.URL.Path, c.ClientIP(), err)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"status": http.StatusUnauthorized, "code": 0, "message": "slack request signature is invalid",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// verifyRequest verify signature in header with request body & restore body so that handler can read that again
+func (sa *slackAgent) verifyRequest(r *http.Request) error {
+	if sa.signingSecret == "" {
+		return errors.New("slack signing secret is not set")
+	}
+
+	verifier, err := slack.NewSecretsVerifier(r.Header, sa.signingSecret)
+	if err != nil {
+		return errors.Wrap(err, "failed to create secrets verifier")
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read request body")
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if _, err = verifier.Write(body); err != nil {
+		return errors.Wrap(err, "failed to write body to secrets verifier")
+	}
+	return errors.Wrap(verifier.Ensure(), "failed to ensure request signature")
+}
+
+// RespondCommand send delayed response of slash command to response url, response is visible to every member in channel
+func (sa *slackAgent) RespondCommand(ctx context.Context, responseURL, text string) error {
+	b, err := json.Marshal(map[string]string{"response_type": inChannelResponseType, "text": text})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal slash command response")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, responseURL, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request to response url")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request to response url")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("slash command response is rejected, status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Create file in v.1.1.0
+// agent_report.go file define method of slackAgent posting digest report of check history as block kit message
+// implement slack agency interface defined in report usecase, title & label of check is shared with alarm template
+
+package slack
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// reportTimeLayout is layout of time range rendered in report message
+const reportTimeLayout = "2006-01-02 15:04"
+
+// SendReport post report as block kit message to channel received from parameter (or chat channel if empty)
+// every check in report is rendered as section with measured value (min, avg, max), counts & unhealthy duration
+func (sa *slackAgent) SendReport(ctx context.Context, cnl string, report domain.Report) (t time.Time, err error) {
+	if cnl == "" {
+		cnl = sa.chatChannel
+	}
+
+	title := fmt.Sprintf("%s Health Check Report", strings.Title(report.Period))
+	text := fmt.Sprintf(":bar_chart: %s (%s ~ %s)", title, report.From.Format(reportTimeLayout), report.To.Format(reportTimeLayout))
+	_, ts, _, err := sa.slkCli.SendMessageContext(ctx, cnl, slack.MsgOptionText(text, false), slack.MsgOptionBlocks(reportBlocks(title, report)...))
+	if err != nil {
+		err = errors.Wrap(err, "failed to send report message with slack API")
+		return
+	}
+
+	if len(ts) >= 10 {
+		i, _ := strconv.ParseInt(ts[:10], 10, 64)
+		t = time.Unix(i, 0).In(sa.clock.Location())
+	}
+	return
+}
+
+// reportBlocks return blocks of report message, header has title & context has time range of report
+// each check is rendered as section block with fields, and separated with divider block
+func reportBlocks(title string, report domain.Report) []slack.Block {
+	period := fmt.Sprintf("%s ~ %s | %d checks", report.From.Format(reportTimeLayout), report.To.Format(reportTimeLayout), len(report.Checks))
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, ":bar_chart: "+title, true, false)),
+		slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, period, false, false)),
+	}
+
+	if len(report.Checks) == 0 {
+		text := "no check history was stored in report period"
+		return append(blocks, slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil))
+	}
+
+	for _, check := range report.Checks {
+		template := templateOf(check.Kind, check.Type)
+		text := fmt.Sprintf("*%s* (%s) · %d runs", template.title, check.Domain, check.Runs)
+
+		var fields []*slack.TextBlockObject
+		field := func(label, value string) {
+			fields = append(fields, slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*%s*\n%s", label, value), false, false))
+		}
+		if check.Metric != "" {
+			label := "Measured"
+			for _, f := range template.fields {
+				if f.key == domain.AlarmFieldMeasured {
+					label = f.label
+				}
+			}
+			field(label, fmt.Sprintf("min %s · avg %s · max %s", check.FormatValue(check.Min), check.FormatValue(check.Avg), check.FormatValue(check.Max)))
+		}
+		field("Warnings", strconv.Itoa(check.Warnings))
+		field("Weak Detections", strconv.Itoa(check.WeakDetections))
+		field("Remediations", strconv.Itoa(check.Remediations))
+		field("Unhealthy", check.Unhealthy.Round(time.Second).String())
+
+		blocks = append(blocks, slack.NewDividerBlock(), slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), fields, nil))
+	}
+	return blocks
+}
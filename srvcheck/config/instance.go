@@ -0,0 +1,399 @@
+// Create file in v.1.1.0
+// instance.go is file that define CheckInstance type which is config of check instance declared in srvcheck.checks
+// every check instance has own name, type tag & schedule, and params of instance override config value of srvcheckConfig
+
+package config
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// CheckInstance is config of check instance, which implement config interface of srvcheck usecase by embedding App
+// value declared in params of instance is returned first, and value of App reloaded at runtime is returned if not declared
+type CheckInstance struct {
+	*srvcheckConfig
+
+	// name represent name of check instance, which is used as type tag of check instance if different from kind
+	name string
+
+	// kind represent kind of check run by check instance (elasticsearch, swarmpit, consul)
+	kind string
+
+	// enabled represent if check instance is run, disabled check instance isn't scheduled & exposed to API
+	enabled bool
+
+	// schedule represent delivery schedule spec of check instance, schedule of kind is used if empty
+	schedule string
+
+	// params represent parameter of check instance overriding config value of App (Ex, swarmpitAppServiceName)
+	params *viper.Viper
+}
+
+// checkDeclaration is struct decoded from each item of srvcheck.checks in config file
+type checkDeclaration struct {
+	Name     string
+	Kind     string
+	Enabled  *bool
+	Schedule string
+	Params   map[string]interface{}
+}
+
+// checkKind is struct having information about kind of check which can be declared in srvcheck.checks
+type checkKind struct {
+	// _type is type tag of check instance whose name is same with kind (Ex, SwarmpitCheck)
+	_type string
+
+	// section is config key having config value of kind in srvcheck, which can be overridden with params of instance
+	section string
+
+	// params is list of key which can be declared in params of check instance
+	params []string
+
+	// schedule is getter of delivery schedule used in check instance not declaring own schedule
+	schedule func(sc *srvcheckConfig) string
+
+	// validate is function validating config value of kind whose key start with section
+	validate func(r *reader, section string)
+}
+
+// checkKinds is map of every kind of check in srvcheck domain, kindOrder is order of that used in default instances
+var (
+	checkKinds = map[string]checkKind{
+		"elasticsearch": {
+			_type:    "ElasticsearchCheck",
+			section:  "srvcheck.elasticsearch",
+			params:   []string{"address", "maximumShardsNumber", "jaegerIndexPattern", "jaegerIndexMinLifeCycle"},
+			schedule: (*srvcheckConfig).ESCheckDeliverySchedule,
+			validate: validateElasticsearchInstanceParams,
+		},
+		"swarmpit": {
+			_type:    "SwarmpitCheck",
+			section:  "srvcheck.swarmpit",
+			params:   []string{"swarmpitAppServiceName", "swarmpitAppMaxMemoryUsage"},
+			schedule: (*srvcheckConfig).SwarmpitCheckDeliverySchedule,
+			validate: validateSwarmpitParams,
+		},
+		"consul": {
+			_type:    "ConsulCheck",
+			section:  "srvcheck.consul",
+			params:   []string{"checkTargetServices", "consulServiceNameSpace", "dockerServiceNameSpace", "connCheckPingTimeOut"},
+			schedule: (*srvcheckConfig).ConsulCheckDeliverySchedule,
+			validate: validateConsulParams,
+		},
+	}
+	kindOrder = []string{"elasticsearch", "swarmpit", "consul"}
+)
+
+// instanceNamePattern is pattern of check instance name, which is used in HTTP API path & slash command
+var instanceNamePattern = regexp.MustCompile("^[a-z][a-z0-9_-]*$")
+
+// CheckInstances return every check instance declared in srvcheck.checks, disabled instance is also returned
+// if srvcheck.checks is not declared, one instance named with kind is returned for every kind of check
+func (sc *srvcheckConfig) CheckInstances() []*CheckInstance {
+	var key = "srvcheck.checks"
+	if sc.checkInstances != nil {
+		return *sc.checkInstances
+	}
+
+	decls := checkDeclarations(viper.GetViper(), key)
+	instances := make([]*CheckInstance, 0, len(decls))
+	for _, decl := range decls {
+		if _, ok := checkKinds[decl.kind()]; !ok {
+			continue
+		}
+
+		instance := &CheckInstance{
+			srvcheckConfig: sc,
+			name:           decl.Name,
+			kind:           decl.kind(),
+			enabled:        decl.Enabled == nil || *decl.Enabled,
+			schedule:       decl.Schedule,
+			params:         viper.New(),
+		}
+		_ = instance.params.MergeConfigMap(decl.Params)
+		instances = append(instances, instance)
+	}
+
+	sc.checkInstances = &instances
+	return *sc.checkInstances
+}
+
+// validateCheckInstances validate every check instance declared in srvcheck.checks if that is declared
+// params of instance is validated after merged to config value of kind, so that cross-field condition is also validated
+func validateCheckInstances(r *reader, sv specValidator) {
+	var key = "srvcheck.checks"
+	if !r.v.IsSet(key) {
+		return
+	}
+
+	var decls []checkDeclaration
+	if err := r.v.UnmarshalKey(key, &decls); err != nil {
+		r.fail(key, "invalid format, %s", err.Error())
+		return
+	}
+
+	names := map[string]bool{}
+	for i, decl := range decls {
+		prefix := fmt.Sprintf("%s[%d].", key, i)
+		if _, ok := checkKinds[decl.Name]; ok && decl.Name != decl.kind() {
+			r.fail(prefix+"name", "must not be same with another kind of check, value: %s", decl.Name)
+		} else if !instanceNamePattern.MatchString(decl.Name) {
+			r.fail(prefix+"name", "must start with lower case letter followed by lower case letter, digit, - or _, value: %q", decl.Name)
+		} else if names[decl.Name] {
+			r.fail(prefix+"name", "must be unique in %s, value: %s", key, decl.Name)
+		}
+		names[decl.Name] = true
+
+		kind, ok := checkKinds[decl.kind()]
+		if !ok {
+			r.fail(prefix+"kind", "must be one of %v, value: %s", kindOrder, decl.kind())
+			continue
+		}
+
+		if decl.Schedule != "" {
+			if err := sv.ValidateSpec(decl.Schedule); err != nil {
+				r.fail(prefix+"schedule", "must be valid schedule spec, %s", err.Error())
+			}
+		}
+
+		for param := range decl.Params {
+			if !kind.hasParam(param) {
+				r.fail(prefix+"params."+param, "must be one of %v in %s check", kind.params, decl.kind())
+			}
+		}
+
+		merged := viper.New()
+		_ = merged.MergeConfigMap(r.v.GetStringMap(kind.section))
+		_ = merged.MergeConfigMap(decl.Params)
+		pr := &reader{v: merged, prefix: prefix + "params."}
+		kind.validate(pr, "")
+		r.errs = append(r.errs, pr.errs...)
+	}
+}
+
+// validateElasticsearchInstanceParams validate params of elasticsearch check instance, including address of target cluster
+func validateElasticsearchInstanceParams(r *reader, section string) {
+	validateElasticsearchParams(r, section)
+	if !r.v.IsSet(section + "address") {
+		return
+	}
+
+	address := r.string(section+"address", "")
+	if u, err := url.Parse(address); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		r.fail(section+"address", "must be http or https URL of elasticsearch cluster, value: %s", address)
+	}
+}
+
+// checkDeclarations return every check declaration in key of v, one declaration named with kind is returned for every kind
+// of check if key is not declared, so that check instances are same with those before check instance is added
+func checkDeclarations(v *viper.Viper, key string) []checkDeclaration {
+	var decls []checkDeclaration
+	if err := v.UnmarshalKey(key, &decls); err != nil || !v.IsSet(key) {
+		decls = make([]checkDeclaration, len(kindOrder))
+		for i, kind := range kindOrder {
+			decls[i] = checkDeclaration{Name: kind}
+		}
+	}
+	return decls
+}
+
+// reloadCheckInstances read check declarations in srvcheck.checks again from v and return commit function returning changed
+// values & apply function, which replace params & schedule of every running check instance declared again with same name &
+// kind and must be called while holding mutex, list of check instance is returned as change applied after restart
+func (sc *srvcheckConfig) reloadCheckInstances(v *viper.Viper) (commit func() ([]domain.ConfigChange, func())) {
+	var key = "srvcheck.checks"
+	decls := checkDeclarations(v, key)
+	instances := sc.CheckInstances()
+
+	return func() (changes []domain.ConfigChange, apply func()) {
+		olds, news := make([]string, len(instances)), make([]string, len(decls))
+		for i, instance := range instances {
+			olds[i] = instanceSummary(instance.name, instance.kind, instance.enabled)
+		}
+		for i, decl := range decls {
+			news[i] = instanceSummary(decl.Name, decl.kind(), decl.Enabled == nil || *decl.Enabled)
+		}
+		if o, n := strings.Join(olds, ", "), strings.Join(news, ", "); o != n {
+			changes = append(changes, domain.ConfigChange{Key: key, Old: o, New: n, RestartRequired: true})
+		}
+
+		var applies []func()
+		for i, decl := range decls {
+			var instance *CheckInstance
+			for _, ci := range instances {
+				if ci.name == decl.Name && ci.kind == decl.kind() {
+					instance = ci
+				}
+			}
+			if instance == nil {
+				continue
+			}
+
+			params := viper.New()
+			_ = params.MergeConfigMap(decl.Params)
+			prefix := fmt.Sprintf("%s[%d].", key, i)
+			for _, param := range checkKinds[instance.kind].params {
+				changes = appendParamChange(changes, prefix+"params."+param, instance.reader().v, params, param)
+			}
+			changes = appendChange(changes, prefix+"schedule", declaredValue(instance.schedule), declaredValue(decl.Schedule))
+
+			schedule := decl.Schedule
+			applies = append(applies, func() { instance.params, instance.schedule = params, schedule })
+		}
+
+		apply = func() {
+			for _, a := range applies {
+				a()
+			}
+		}
+		return
+	}
+}
+
+// instanceSummary return summary of check instance used in change about list of check instance (Ex, memory-strict(memory))
+func instanceSummary(name, kind string, enabled bool) string {
+	summary := name
+	if name != kind {
+		summary += "(" + kind + ")"
+	}
+	if !enabled {
+		summary += "(disabled)"
+	}
+	return summary
+}
+
+// appendParamChange append change of param in check instance to changes if value declared in old & new params is different
+func appendParamChange(changes []domain.ConfigChange, key string, old, new *viper.Viper, param string) []domain.ConfigChange {
+	n := len(changes)
+	changes = appendChange(changes, key, declaredValue(old.Get(param)), declaredValue(new.Get(param)))
+
+	// client of elasticsearch cluster checked in instance is created only in main function, so address is applied after restart
+	if len(changes) > n && param == "address" {
+		changes[n].RestartRequired = true
+	}
+	return changes
+}
+
+// declaredValue return value in string format, or text representing that value is not declared if that is empty
+func declaredValue(value interface{}) string {
+	if value == nil || value == "" {
+		return "(not declared)"
+	}
+	return fmt.Sprint(value)
+}
+
+// kind method return kind of check declaration, name is used as kind if not declared
+func (cd checkDeclaration) kind() string {
+	if cd.Kind == "" {
+		return cd.Name
+	}
+	return cd.Kind
+}
+
+// hasParam method return if param can be declared in params of check instance, param is compared ignoring case
+func (ck checkKind) hasParam(param string) bool {
+	for _, p := range ck.params {
+		if strings.EqualFold(p, param) {
+			return true
+		}
+	}
+	return false
+}
+
+// reader method return reader of params in check instance, params is replaced with new one when config file is reloaded
+func (ci *CheckInstance) reader() *reader {
+	ci.mutex.Lock()
+	defer ci.mutex.Unlock()
+	return &reader{v: ci.params}
+}
+
+// Name method return name of check instance, which is used in path of HTTP API (Ex, service-check/types/consul)
+func (ci *CheckInstance) Name() string { return ci.name }
+
+// Kind method return kind of check run by check instance (elasticsearch, swarmpit, consul)
+func (ci *CheckInstance) Kind() string { return ci.kind }
+
+// Enabled method return if check instance is run, disabled check instance isn't scheduled & exposed to API
+func (ci *CheckInstance) Enabled() bool { return ci.enabled }
+
+// implement CheckType method of serviceCheckUsecaseComponentConfig interface
+// type tag of kind is returned if name is same with kind (Ex, SwarmpitCheck), or name of instance is returned
+func (ci *CheckInstance) CheckType() string {
+	if ci.name == ci.kind {
+		return checkKinds[ci.kind]._type
+	}
+	return ci.name
+}
+
+// not implement any interface, just using in main function for delivery layer injection
+// schedule declared in check instance is returned first, or delivery schedule of kind is returned
+// it is read again whenever config file is reloaded, so that check instance is rescheduled if that is changed
+func (ci *CheckInstance) DeliverySchedule() string {
+	ci.mutex.Lock()
+	schedule := ci.schedule
+	ci.mutex.Unlock()
+
+	if schedule != "" {
+		return schedule
+	}
+	return checkKinds[ci.kind].schedule(ci.srvcheckConfig)
+}
+
+// not implement any interface, just using in main function to connect elasticsearch cluster checked in instance
+// empty string is returned if address isn't declared, which means cluster of ES_ADDRESS is checked
+func (ci *CheckInstance) ESAddress() string {
+	return ci.reader().string("address", "")
+}
+
+// implement ElasticsearchCheckThresholds method of elasticsearchCheckUsecaseConfig interface
+// params of instance & thresholds snapshot of App are read in one lock, so that values reloaded together aren't mixed
+func (ci *CheckInstance) ElasticsearchCheckThresholds() domain.ElasticsearchCheckThresholds {
+	ci.mutex.Lock()
+	defer ci.mutex.Unlock()
+	r, t := &reader{v: ci.params}, ci.snapshot()
+
+	return domain.ElasticsearchCheckThresholds{
+		MaximumShardsNumber:     r.int("maximumShardsNumber", t.maximumShardsNumber, 1),
+		JaegerIndexPattern:      r.string("jaegerIndexPattern", t.jaegerIndexPattern),
+		JaegerIndexMinLifeCycle: r.duration("jaegerIndexMinLifeCycle", t.jaegerIndexMinLifeCycle, false),
+	}
+}
+
+// implement SwarmpitCheckThresholds method of swarmpitCheckUsecaseConfig interface
+// params of instance & thresholds snapshot of App are read in one lock, so that values reloaded together aren't mixed
+func (ci *CheckInstance) SwarmpitCheckThresholds() domain.SwarmpitCheckThresholds {
+	ci.mutex.Lock()
+	defer ci.mutex.Unlock()
+	r, t := &reader{v: ci.params}, ci.snapshot()
+
+	return domain.SwarmpitCheckThresholds{
+		AppServiceName:    r.string("swarmpitAppServiceName", t.swarmpitAppServiceName),
+		AppMaxMemoryUsage: r.byteSize("swarmpitAppMaxMemoryUsage", t.swarmpitAppMaxMemoryUsage),
+	}
+}
+
+// implement ConsulCheckThresholds method of consulCheckUsecaseConfig interface
+// params of instance & thresholds snapshot of App are read in one lock, so that values reloaded together aren't mixed
+func (ci *CheckInstance) ConsulCheckThresholds() domain.ConsulCheckThresholds {
+	ci.mutex.Lock()
+	defer ci.mutex.Unlock()
+	r, t := &reader{v: ci.params}, ci.snapshot()
+
+	services := t.checkTargetServices
+	if r.v.IsSet("checkTargetServices") {
+		services = strings.Split(r.v.GetString("checkTargetServices"), ",")
+	}
+
+	return domain.ConsulCheckThresholds{
+		CheckTargetServices:    services,
+		ConsulServiceNameSpace: r.string("consulServiceNameSpace", t.consulServiceNameSpace),
+		DockerServiceNameSpace: r.string("dockerServiceNameSpace", t.dockerServiceNameSpace),
+		ConnCheckPingTimeOut:   r.duration("connCheckPingTimeOut", t.connCheckPingTimeOut, false),
+	}
+}
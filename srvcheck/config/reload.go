@@ -0,0 +1,101 @@
+// Create file in v.1.1.0
+// reload.go is file that define method of srvcheckConfig reloading execution, threshold & delivery schedule from config file
+// every value read again is validated with Validate method first, and applied at once only if every value is valid
+
+package config
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"strings"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// Reload method read execution, threshold, target list, delivery ping cycle & schedule of srvcheck domain again from v and
+// validate them, it returns commit function applying every value at once & returning changed values, value not set in v is
+// reset to default, and if any of config value is invalid, ConfigErrors is returned and old values are kept
+func (sc *srvcheckConfig) Reload(v *viper.Viper, sv specValidator) (commit func() []domain.ConfigChange, err error) {
+	if errs := sc.Validate(v, sv); len(errs) > 0 {
+		err = errors.Wrap(errs, "invalid srvcheck config value to reload")
+		return
+	}
+
+	r := &reader{v: v}
+	overlapPolicy := r.oneOf("srvcheck.execution.overlapPolicy", defaultOverlapPolicy, "skip", "queue", "wait")
+	runTimeout := r.duration("srvcheck.execution.runTimeout", defaultRunTimeout, true)
+	dryRun := r.bool("srvcheck.execution.dryRun", r.bool("dryRun", false))
+	escalationPolicy := defaultEscalationPolicy
+	r.unmarshal("srvcheck.escalation", &escalationPolicy)
+	thresholds := readThresholds(r)
+	esCycle := r.duration("srvcheck.delivery.channel.pingCycle.elasticsearchCheck", defaultESCheckDeliveryPingCycle, false)
+	swarmpitCycle := r.duration("srvcheck.delivery.channel.pingCycle.swarmpitCheck", defaultSwarmpitCheckDeliveryPingCycle, false)
+	consulCycle := r.duration("srvcheck.delivery.channel.pingCycle.consulCheck", defaultConsulCheckDeliveryPingCycle, false)
+	esSchedule := r.schedule("srvcheck.delivery.channel.schedule.elasticsearchCheck", esCycle, sv)
+	swarmpitSchedule := r.schedule("srvcheck.delivery.channel.schedule.swarmpitCheck", swarmpitCycle, sv)
+	consulSchedule := r.schedule("srvcheck.delivery.channel.schedule.consulCheck", consulCycle, sv)
+
+	commitInstances := sc.reloadCheckInstances(v)
+
+	commit = func() (changes []domain.ConfigChange) {
+		changes = appendChange(changes, "srvcheck.execution.overlapPolicy", sc.OverlapPolicy(), overlapPolicy)
+		changes = appendChange(changes, "srvcheck.execution.runTimeout", sc.RunTimeout(), runTimeout)
+		changes = appendChange(changes, "srvcheck.execution.dryRun", sc.DryRun(), dryRun)
+		changes = appendChange(changes, "srvcheck.escalation", fmt.Sprintf("%+v", sc.EscalationPolicy()), fmt.Sprintf("%+v", escalationPolicy))
+		changes = appendChange(changes, "srvcheck.elasticsearch.maximumShardsNumber", sc.MaximumShardsNumber(), thresholds.maximumShardsNumber)
+		changes = appendChange(changes, "srvcheck.elasticsearch.jaegerIndexPattern", sc.JaegerIndexPattern(), thresholds.jaegerIndexPattern)
+		changes = appendChange(changes, "srvcheck.elasticsearch.jaegerIndexMinLifeCycle", sc.JaegerIndexMinLifeCycle(), thresholds.jaegerIndexMinLifeCycle)
+		changes = appendChange(changes, "srvcheck.swarmpit.swarmpitAppServiceName", sc.SwarmpitAppServiceName(), thresholds.swarmpitAppServiceName)
+		changes = appendChange(changes, "srvcheck.swarmpit.swarmpitAppMaxMemoryUsage", sc.SwarmpitAppMaxMemoryUsage(), thresholds.swarmpitAppMaxMemoryUsage)
+		changes = appendChange(changes, "srvcheck.consul.checkTargetServices", strings.Join(sc.CheckTargetServices(), ","), strings.Join(thresholds.checkTargetServices, ","))
+		changes = appendChange(changes, "srvcheck.consul.consulServiceNameSpace", sc.ConsulServiceNameSpace(), thresholds.consulServiceNameSpace)
+		changes = appendChange(changes, "srvcheck.consul.dockerServiceNameSpace", sc.DockerServiceNameSpace(), thresholds.dockerServiceNameSpace)
+		changes = appendChange(changes, "srvcheck.consul.connCheckPingTimeOut", sc.ConnCheckPingTimeOut(), thresholds.connCheckPingTimeOut)
+		changes = appendChange(changes, "srvcheck.delivery.channel.pingCycle.elasticsearchCheck", sc.ESCheckDeliveryPingCycle(), esCycle)
+		changes = appendChange(changes, "srvcheck.delivery.channel.pingCycle.swarmpitCheck", sc.SwarmpitCheckDeliveryPingCycle(), swarmpitCycle)
+		changes = appendChange(changes, "srvcheck.delivery.channel.pingCycle.consulCheck", sc.ConsulCheckDeliveryPingCycle(), consulCycle)
+		changes = appendChange(changes, "srvcheck.delivery.channel.schedule.elasticsearchCheck", sc.ESCheckDeliverySchedule(), esSchedule)
+		changes = appendChange(changes, "srvcheck.delivery.channel.schedule.swarmpitCheck", sc.SwarmpitCheckDeliverySchedule(), swarmpitSchedule)
+		changes = appendChange(changes, "srvcheck.delivery.channel.schedule.consulCheck", sc.ConsulCheckDeliverySchedule(), consulSchedule)
+
+		instanceChanges, applyInstances := commitInstances()
+		changes = append(changes, instanceChanges...)
+
+		// every value is applied in one lock, so that check process taking thresholds snapshot doesn't mix old & new values
+		sc.mutex.Lock()
+		defer sc.mutex.Unlock()
+
+		sc.overlapPolicy, sc.runTimeout, sc.dryRun, sc.escalationPolicy = &overlapPolicy, &runTimeout, &dryRun, &escalationPolicy
+		sc.thresholds = thresholds
+		sc.esCheckDeliveryPingCycle, sc.swarmpitCheckDeliveryPingCycle, sc.consulCheckDeliveryPingCycle = &esCycle, &swarmpitCycle, &consulCycle
+		sc.esCheckDeliverySchedule, sc.swarmpitCheckDeliverySchedule, sc.consulCheckDeliverySchedule = &esSchedule, &swarmpitSchedule, &consulSchedule
+		applyInstances()
+		return
+	}
+	return
+}
+
+// readThresholds read every threshold & target list of srvcheck domain from r and return new thresholds snapshot
+// default value is used for value which is not set or invalid in config file
+func readThresholds(r *reader) *thresholds {
+	return &thresholds{
+		maximumShardsNumber:       r.int("srvcheck.elasticsearch.maximumShardsNumber", defaultMaximumShardsNumber, 1),
+		jaegerIndexPattern:        r.string("srvcheck.elasticsearch.jaegerIndexPattern", defaultJaegerIndexPattern),
+		jaegerIndexMinLifeCycle:   r.duration("srvcheck.elasticsearch.jaegerIndexMinLifeCycle", defaultJaegerIndexMinLifeCycle, false),
+		swarmpitAppServiceName:    r.string("srvcheck.swarmpit.swarmpitAppServiceName", defaultSwarmpitAppServiceName),
+		swarmpitAppMaxMemoryUsage: r.byteSize("srvcheck.swarmpit.swarmpitAppMaxMemoryUsage", defaultSwarmpitAppMaxMemoryUsage),
+		checkTargetServices:       strings.Split(r.string("srvcheck.consul.checkTargetServices", defaultCheckTargetServices), ","),
+		consulServiceNameSpace:    r.string("srvcheck.consul.consulServiceNameSpace", defaultConsulServiceNameSpace),
+		dockerServiceNameSpace:    r.string("srvcheck.consul.dockerServiceNameSpace", defaultDockerServiceNameSpace),
+		connCheckPingTimeOut:      r.duration("srvcheck.consul.connCheckPingTimeOut", defaultConnCheckPingTimeOut, false),
+	}
+}
+
+// appendChange append change of config value with key to changes if value is changed, and return that
+func appendChange(changes []domain.ConfigChange, key string, old, new interface{}) []domain.ConfigChange {
+	if o, n := fmt.Sprint(old), fmt.Sprint(new); o != n {
+		changes = append(changes, domain.ConfigChange{Key: key, Old: o, New: n})
+	}
+	return changes
+}
@@ -10,8 +10,10 @@ package config
 import (
 	"github.com/inhies/go-bytesize"
 	"github.com/spf13/viper"
-	"strings"
+	"sync"
 	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
 )
 
 // App is the application config about srvcheck domain
@@ -19,7 +21,7 @@ var App *srvcheckConfig
 
 // srvcheckConfig having config value and implement various interface about Config by declaring method
 type srvcheckConfig struct {
-	// fields about index information in elasticsearch (implement esRepositoryComponentConfig)
+	// fields about index information in elasticsearch (implement config interface in esindex package)
 	// indexName represent name of elasticsearch index including srvcheck history document
 	indexName *string
 
@@ -29,41 +31,35 @@ type srvcheckConfig struct {
 	// indexReplicaNum represent replica number of elasticsearch index to replace index when node become unable
 	indexReplicaNum *int
 
-	// ---
-
-	// fields using in elasticsearch health checking (implement elasticsearchCheckUsecaseConfig)
-	// maximumShardsNumber represent maximum shards number of elasticsearch target cluster
-	maximumShardsNumber *int
+	// indexRollover represent how to roll over srvcheck history index (none, daily, monthly, alias)
+	indexRollover *string
 
-	// jaegerIndexPattern represent jaeger index pattern to deliver to elasticsearch agency
-	jaegerIndexPattern *string
+	// indexRolloverMaxAge represent max age of write index before rolling over when index rollover is alias
+	indexRolloverMaxAge *time.Duration
 
-	// jaegerIndexMinLifeCycle represent minimum life cycle of jaeger index in elasticsearch
-	jaegerIndexMinLifeCycle *time.Duration
+	// indexRetention represent retention period of srvcheck history index, history index is never deleted if zero
+	indexRetention *time.Duration
 
 	// ---
 
-	// fields using in swarmpit health checking (implement swarmpitCheckUsecaseConfig)
-	// swarmpitAppServiceName represent swarmpit app service name in docker swarm
-	swarmpitAppServiceName *string
-
-	// swarmpitAppMaxMemoryUsage represent maximum memory usage of swarmpit app container
-	swarmpitAppMaxMemoryUsage *bytesize.ByteSize
+	// fields using in every srvcheck usecase to execute check process (implement serviceCheckUsecaseComponentConfig)
+	// overlapPolicy represent policy about check process overlapped with running one (skip, queue, wait)
+	overlapPolicy *string
 
-	// ---
+	// runTimeout represent max duration of check process run, check process is canceled if exceeded
+	runTimeout *time.Duration
 
-	// fields using in consul health checking (implement consulCheckUsecaseConfig)
-	// checkTargetServices represent check target services in check usecase
-	checkTargetServices *[]string
+	// dryRun represent if remediation in check process is simulated without executing
+	dryRun *bool
 
-	// consulServiceNameSpace represent consul service name space saved in consul
-	consulServiceNameSpace *string
+	// escalationPolicy represent policy about sending reminder of check staying unhealthy without acknowledgement
+	escalationPolicy *domain.EscalationPolicy
 
-	// dockerServiceNameSpace represent docker service name space saved in docker
-	dockerServiceNameSpace *string
+	// ---
 
-	// ConnCheckPingTimeOut represent connection ping time out when check connection
-	connCheckPingTimeOut *time.Duration
+	// fields using in elasticsearch, swarmpit & consul health checking (implement elasticsearchCheckUsecaseConfig, ...)
+	// thresholds represent immutable snapshot of threshold & target list in srvcheck domain, which is replaced at once by reload
+	thresholds *thresholds
 
 	// ---
 
@@ -76,12 +72,68 @@ type srvcheckConfig struct {
 
 	// consulCheckDeliveryPingCycle represent consul check delivery ping cycle
 	consulCheckDeliveryPingCycle *time.Duration
+
+	// esCheckDeliverySchedule represent es check delivery schedule spec (interval or cron expression with jitter)
+	esCheckDeliverySchedule *string
+
+	// swarmpitCheckDeliverySchedule represent swarmpit check delivery schedule spec (interval or cron expression with jitter)
+	swarmpitCheckDeliverySchedule *string
+
+	// consulCheckDeliverySchedule represent consul check delivery schedule spec (interval or cron expression with jitter)
+	consulCheckDeliverySchedule *string
+
+	// deliveryInitialRun represent if every check in srvcheck is run as soon as delivery is started
+	deliveryInitialRun *bool
+
+	// checkInstances represent every check instance declared in srvcheck.checks, which is not reloaded at runtime
+	checkInstances *[]*CheckInstance
+
+	// ---
+
+	// mutex help to prevent race condition when access execution, threshold, target list & delivery fields reloaded at runtime
+	mutex sync.Mutex
+}
+
+// thresholds is immutable snapshot of threshold & target list in srvcheck domain, new one is created by reload instead
+type thresholds struct {
+	// maximumShardsNumber represent maximum shards number of elasticsearch target cluster
+	maximumShardsNumber int
+
+	// jaegerIndexPattern represent jaeger index pattern to deliver to elasticsearch agency
+	jaegerIndexPattern string
+
+	// jaegerIndexMinLifeCycle represent minimum life cycle of jaeger index in elasticsearch
+	jaegerIndexMinLifeCycle time.Duration
+
+	// swarmpitAppServiceName represent swarmpit app service name in docker swarm
+	swarmpitAppServiceName string
+
+	// swarmpitAppMaxMemoryUsage represent maximum memory usage of swarmpit app container
+	swarmpitAppMaxMemoryUsage bytesize.ByteSize
+
+	// checkTargetServices represent check target services in check usecase
+	checkTargetServices []string
+
+	// consulServiceNameSpace represent consul service name space saved in consul
+	consulServiceNameSpace string
+
+	// dockerServiceNameSpace represent docker service name space saved in docker
+	dockerServiceNameSpace string
+
+	// connCheckPingTimeOut represent connection ping time out when check connection
+	connCheckPingTimeOut time.Duration
 }
 
 const (
-	defaultIndexName       = "sms-service-check" // default const string for indexName
-	defaultIndexShardNum   = 2                   // default const int for indexShardNum
-	defaultIndexReplicaNum = 0                   // default const int for indexReplicaNum
+	defaultIndexName           = "sms-service-check" // default const string for indexName
+	defaultIndexShardNum       = 2                   // default const int for indexShardNum
+	defaultIndexReplicaNum     = 0                   // default const int for indexReplicaNum
+	defaultIndexRollover       = "none"              // default const string for indexRollover
+	defaultIndexRolloverMaxAge = time.Hour * 24 * 30 // default const duration for indexRolloverMaxAge
+	defaultIndexRetention      = time.Duration(0)    // default const duration for indexRetention
+
+	defaultOverlapPolicy = "skip"          // default const string for overlapPolicy
+	defaultRunTimeout    = time.Minute * 5 // default const Duration for runTimeout
 
 	defaultMaximumShardsNumber     = 900             // default const int for MaximumShardsNumber
 	defaultJaegerIndexMinLifeCycle = time.Hour * 720 // default const duration for JaegerIndexMinLifeCycle
@@ -98,9 +150,18 @@ const (
 	defaultESCheckDeliveryPingCycle       = time.Hour * 12  // default const Duration for esCheckDeliveryPingCycle
 	defaultSwarmpitCheckDeliveryPingCycle = time.Hour * 6   // default const Duration for swarmpitCheckDeliveryPingCycle
 	defaultConsulCheckDeliveryPingCycle   = time.Minute * 1 // default const Duration for consulCheckDeliveryPingCycle
+	defaultDeliveryInitialRun             = false           // default const bool for deliveryInitialRun
 )
 
-// implement IndexName method of esRepositoryComponentConfig interface
+// default value about escalationPolicy field, reminder is sent after 30m & repeated at 30m, 1h, 2h, 4h, 4h, ...
+var defaultEscalationPolicy = domain.EscalationPolicy{
+	After:       time.Minute * 30,
+	Interval:    time.Minute * 30,
+	Multiplier:  2,
+	MaxInterval: time.Hour * 4,
+}
+
+// implement IndexName method of config interface in esindex package
 func (sc *srvcheckConfig) IndexName() string {
 	var key = "srvcheck.repository.elasticsearch.index.name"
 	if sc.indexName == nil {
@@ -112,7 +173,7 @@ func (sc *srvcheckConfig) IndexName() string {
 	return *sc.indexName
 }
 
-// implement IndexShardNum method of esRepositoryComponentConfig interface
+// implement IndexShardNum method of config interface in esindex package
 func (sc *srvcheckConfig) IndexShardNum() int {
 	var key = "srvcheck.repository.elasticsearch.index.shardNum"
 	if sc.indexShardNum == nil {
@@ -124,7 +185,7 @@ func (sc *srvcheckConfig) IndexShardNum() int {
 	return *sc.indexShardNum
 }
 
-// implement IndexReplicaNum method of esRepositoryComponentConfig interface
+// implement IndexReplicaNum method of config interface in esindex package
 func (sc *srvcheckConfig) IndexReplicaNum() int {
 	var key = "srvcheck.repository.elasticsearch.index.replicaNum"
 	if sc.indexReplicaNum == nil {
@@ -136,133 +197,201 @@ func (sc *srvcheckConfig) IndexReplicaNum() int {
 	return *sc.indexReplicaNum
 }
 
-// implement MaximumShardsNumber method of elasticsearchCheckUsecaseConfig interface
-func (sc *srvcheckConfig) MaximumShardsNumber() int {
-	var key = "srvcheck.elasticsearch.maximumShardsNumber"
-	if sc.maximumShardsNumber == nil {
-		if _, ok := viper.Get(key).(int); !ok {
-			viper.Set(key, defaultMaximumShardsNumber)
+// implement IndexRollover method of config interface in esindex package
+func (sc *srvcheckConfig) IndexRollover() string {
+	var key = "srvcheck.repository.elasticsearch.index.rollover"
+	if sc.indexRollover == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, defaultIndexRollover)
 		}
-		sc.maximumShardsNumber = _int(viper.GetInt(key))
+		sc.indexRollover = _string(viper.GetString(key))
 	}
-	return *sc.maximumShardsNumber
+	return *sc.indexRollover
 }
 
-// implement JaegerIndexMinLifeCycle method of elasticsearchCheckUsecaseConfig interface
-func (sc *srvcheckConfig) JaegerIndexMinLifeCycle() time.Duration {
-	var key = "srvcheck.elasticsearch.jaegerIndexMinLifeCycle"
-	if sc.jaegerIndexMinLifeCycle != nil {
-		return *sc.jaegerIndexMinLifeCycle
+// implement IndexRolloverMaxAge method of config interface in esindex package
+func (sc *srvcheckConfig) IndexRolloverMaxAge() time.Duration {
+	var key = "srvcheck.repository.elasticsearch.index.rolloverMaxAge"
+	if sc.indexRolloverMaxAge != nil {
+		return *sc.indexRolloverMaxAge
 	}
 
 	d, err := time.ParseDuration(viper.GetString(key))
 	if err != nil {
-		viper.Set(key, defaultJaegerIndexMinLifeCycle.String())
-		d = defaultJaegerIndexMinLifeCycle
+		viper.Set(key, defaultIndexRolloverMaxAge.String())
+		d = defaultIndexRolloverMaxAge
 	}
 
-	sc.jaegerIndexMinLifeCycle = &d
-	return *sc.jaegerIndexMinLifeCycle
+	sc.indexRolloverMaxAge = &d
+	return *sc.indexRolloverMaxAge
 }
 
-// implement JaegerIndexPattern method of elasticsearchCheckUsecaseConfig interface
-func (sc *srvcheckConfig) JaegerIndexPattern() string {
-	var key = "srvcheck.elasticsearch.jaegerIndexPattern"
-	if sc.jaegerIndexPattern == nil {
-		if _, ok := viper.Get(key).(string); !ok {
-			viper.Set(key, defaultJaegerIndexPattern)
-		}
-		sc.jaegerIndexPattern = _string(viper.GetString(key))
+// implement IndexRetention method of config interface in esindex package
+func (sc *srvcheckConfig) IndexRetention() time.Duration {
+	var key = "srvcheck.repository.elasticsearch.index.retention"
+	if sc.indexRetention != nil {
+		return *sc.indexRetention
+	}
+
+	d, err := time.ParseDuration(viper.GetString(key))
+	if err != nil {
+		viper.Set(key, defaultIndexRetention.String())
+		d = defaultIndexRetention
 	}
-	return *sc.jaegerIndexPattern
+
+	sc.indexRetention = &d
+	return *sc.indexRetention
 }
 
-// implement SwarmpitAppServiceName method of swarmpitCheckUsecaseConfig interface
-func (sc *srvcheckConfig) SwarmpitAppServiceName() string {
-	var key = "srvcheck.swarmpit.swarmpitAppServiceName"
-	if sc.swarmpitAppServiceName == nil {
-		if _, ok := viper.Get(key).(string); !ok {
-			viper.Set(key, defaultSwarmpitAppServiceName)
+// implement OverlapPolicy method of config interface in executor package
+func (sc *srvcheckConfig) OverlapPolicy() string {
+	var key = "srvcheck.execution.overlapPolicy"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.overlapPolicy == nil {
+		switch viper.GetString(key) {
+		case "skip", "queue", "wait":
+			break
+		default:
+			viper.Set(key, defaultOverlapPolicy)
 		}
-		sc.swarmpitAppServiceName = _string(viper.GetString(key))
+		sc.overlapPolicy = _string(viper.GetString(key))
 	}
-	return *sc.swarmpitAppServiceName
+	return *sc.overlapPolicy
 }
 
-// implement DiskMinCapacity method of swarmpitCheckUsecaseConfig interface
-func (sc *srvcheckConfig) SwarmpitAppMaxMemoryUsage() bytesize.ByteSize {
-	var key = "srvcheck.swarmpit.swarmpitAppMaxMemoryUsage"
-	if sc.swarmpitAppMaxMemoryUsage != nil {
-		return *sc.swarmpitAppMaxMemoryUsage
+// implement RunTimeout method of config interface in executor package
+func (sc *srvcheckConfig) RunTimeout() time.Duration {
+	var key = "srvcheck.execution.runTimeout"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.runTimeout != nil {
+		return *sc.runTimeout
 	}
 
-	size, err := bytesize.Parse(viper.GetString(key))
+	d, err := time.ParseDuration(viper.GetString(key))
 	if err != nil {
-		viper.Set(key, defaultSwarmpitAppMaxMemoryUsage.String())
-		size = defaultSwarmpitAppMaxMemoryUsage
+		viper.Set(key, defaultRunTimeout.String())
+		d = defaultRunTimeout
 	}
 
-	sc.swarmpitAppMaxMemoryUsage = &size
-	return *sc.swarmpitAppMaxMemoryUsage
+	sc.runTimeout = &d
+	return *sc.runTimeout
 }
 
-// implement CheckTargetServices method of consulCheckUsecaseConfig interface
-func (sc *srvcheckConfig) CheckTargetServices() []string {
-	var key = "srvcheck.consul.checkTargetServices"
-	if sc.checkTargetServices == nil {
-		if _, ok := viper.Get(key).(string); !ok {
-			viper.Set(key, defaultCheckTargetServices)
-		}
-		sep := strings.Split(viper.GetString(key), ",")
-		sc.checkTargetServices = &sep
+// implement DryRun method of serviceCheckUsecaseComponentConfig interface
+// global dryRun value is used if dryRun is not set in execution of srvcheck domain
+func (sc *srvcheckConfig) DryRun() bool {
+	var key = "srvcheck.execution.dryRun"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.dryRun != nil {
+		return *sc.dryRun
 	}
-	return *sc.checkTargetServices
-}
 
-// implement ConsulServiceNameSpace method of consulCheckUsecaseConfig interface
-func (sc *srvcheckConfig) ConsulServiceNameSpace() string {
-	var key = "srvcheck.consul.consulServiceNameSpace"
-	if sc.consulServiceNameSpace == nil {
-		if _, ok := viper.Get(key).(string); !ok {
-			viper.Set(key, defaultConsulServiceNameSpace)
-		}
-		sc.consulServiceNameSpace = _string(viper.GetString(key))
+	if !viper.IsSet(key) {
+		viper.Set(key, viper.GetBool("dryRun"))
 	}
-	return *sc.consulServiceNameSpace
+	sc.dryRun = _bool(viper.GetBool(key))
+	return *sc.dryRun
 }
 
-// implement DockerServiceNameSpace method of consulCheckUsecaseConfig interface
-func (sc *srvcheckConfig) DockerServiceNameSpace() string {
-	var key = "srvcheck.consul.dockerServiceNameSpace"
-	if sc.dockerServiceNameSpace == nil {
-		if _, ok := viper.Get(key).(string); !ok {
-			viper.Set(key, defaultDockerServiceNameSpace)
-		}
-		sc.dockerServiceNameSpace = _string(viper.GetString(key))
+// implement EscalationPolicy method of serviceCheckUsecaseComponentConfig interface
+// default value is used for each field of policy not set in config file, escalation is disabled if after & afterRuns are 0
+func (sc *srvcheckConfig) EscalationPolicy() domain.EscalationPolicy {
+	var key = "srvcheck.escalation"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.escalationPolicy != nil {
+		return *sc.escalationPolicy
 	}
-	return *sc.dockerServiceNameSpace
-}
 
-// implement ConnCheckPingTimeOut method of consulCheckUsecaseConfig interface
-func (sc *srvcheckConfig) ConnCheckPingTimeOut() time.Duration {
-	var key = "srvcheck.consul.connCheckPingTimeOut"
-	if sc.connCheckPingTimeOut != nil {
-		return *sc.connCheckPingTimeOut
+	policy := defaultEscalationPolicy
+	if err := viper.UnmarshalKey(key, &policy); err != nil {
+		policy = defaultEscalationPolicy
 	}
 
-	d, err := time.ParseDuration(viper.GetString(key))
-	if err != nil {
-		viper.Set(key, defaultConnCheckPingTimeOut.String())
-		d = defaultConnCheckPingTimeOut
+	sc.escalationPolicy = &policy
+	return *sc.escalationPolicy
+}
+
+// snapshot method return thresholds snapshot, which is read from config file at first call, mutex must be locked by caller
+// value not set or invalid in config file is replaced with default value like other getter
+func (sc *srvcheckConfig) snapshot() *thresholds {
+	if sc.thresholds == nil {
+		sc.thresholds = readThresholds(&reader{v: viper.GetViper()})
 	}
+	return sc.thresholds
+}
 
-	sc.connCheckPingTimeOut = &d
-	return *sc.connCheckPingTimeOut
+// MaximumShardsNumber method returns int represent maximum shards number of thresholds snapshot
+func (sc *srvcheckConfig) MaximumShardsNumber() int {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().maximumShardsNumber
 }
 
-// not implement any interface, just using in main function for delivery layer injection
+// JaegerIndexPattern method returns string represent jaeger index pattern of thresholds snapshot
+func (sc *srvcheckConfig) JaegerIndexPattern() string {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().jaegerIndexPattern
+}
+
+// JaegerIndexMinLifeCycle method returns duration represent jaeger index min life cycle of thresholds snapshot
+func (sc *srvcheckConfig) JaegerIndexMinLifeCycle() time.Duration {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().jaegerIndexMinLifeCycle
+}
+
+// SwarmpitAppServiceName method returns string represent swarmpit app service name of thresholds snapshot
+func (sc *srvcheckConfig) SwarmpitAppServiceName() string {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().swarmpitAppServiceName
+}
+
+// SwarmpitAppMaxMemoryUsage method returns byte size represent swarmpit app max memory usage of thresholds snapshot
+func (sc *srvcheckConfig) SwarmpitAppMaxMemoryUsage() bytesize.ByteSize {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().swarmpitAppMaxMemoryUsage
+}
+
+// CheckTargetServices method returns string slice containing target services to check of thresholds snapshot
+func (sc *srvcheckConfig) CheckTargetServices() []string {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().checkTargetServices
+}
+
+// ConsulServiceNameSpace method returns name space of consul service of thresholds snapshot
+func (sc *srvcheckConfig) ConsulServiceNameSpace() string {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().consulServiceNameSpace
+}
+
+// DockerServiceNameSpace method returns name space of docker service of thresholds snapshot
+func (sc *srvcheckConfig) DockerServiceNameSpace() string {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().dockerServiceNameSpace
+}
+
+// ConnCheckPingTimeOut method returns timeout duration in ping to check connection of thresholds snapshot
+func (sc *srvcheckConfig) ConnCheckPingTimeOut() time.Duration {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().connCheckPingTimeOut
+}
+
+// not implement any interface, just using as interval of delivery schedule getter when schedule is not set
 func (sc *srvcheckConfig) ESCheckDeliveryPingCycle() time.Duration {
 	var key = "srvcheck.delivery.channel.pingCycle.elasticsearchCheck"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
 	if sc.esCheckDeliveryPingCycle != nil {
 		return *sc.esCheckDeliveryPingCycle
 	}
@@ -277,9 +406,11 @@ func (sc *srvcheckConfig) ESCheckDeliveryPingCycle() time.Duration {
 	return *sc.esCheckDeliveryPingCycle
 }
 
-// not implement any interface, just using in main function for delivery layer injection
+// not implement any interface, just using as interval of delivery schedule getter when schedule is not set
 func (sc *srvcheckConfig) SwarmpitCheckDeliveryPingCycle() time.Duration {
 	var key = "srvcheck.delivery.channel.pingCycle.swarmpitCheck"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
 	if sc.swarmpitCheckDeliveryPingCycle != nil {
 		return *sc.swarmpitCheckDeliveryPingCycle
 	}
@@ -294,9 +425,11 @@ func (sc *srvcheckConfig) SwarmpitCheckDeliveryPingCycle() time.Duration {
 	return *sc.swarmpitCheckDeliveryPingCycle
 }
 
-// not implement any interface, just using in main function for delivery layer injection
+// not implement any interface, just using as interval of delivery schedule getter when schedule is not set
 func (sc *srvcheckConfig) ConsulCheckDeliveryPingCycle() time.Duration {
 	var key = "srvcheck.delivery.channel.pingCycle.consulCheck"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
 	if sc.consulCheckDeliveryPingCycle != nil {
 		return *sc.consulCheckDeliveryPingCycle
 	}
@@ -311,6 +444,66 @@ func (sc *srvcheckConfig) ConsulCheckDeliveryPingCycle() time.Duration {
 	return *sc.consulCheckDeliveryPingCycle
 }
 
+// not implement any interface, just using in main function for delivery layer injection
+// schedule spec is interval or cron expression with optional jitter, ping cycle is used as interval if not set
+func (sc *srvcheckConfig) ESCheckDeliverySchedule() string {
+	var key = "srvcheck.delivery.channel.schedule.elasticsearchCheck"
+	cycle := sc.ESCheckDeliveryPingCycle()
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.esCheckDeliverySchedule == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, cycle.String())
+		}
+		sc.esCheckDeliverySchedule = _string(viper.GetString(key))
+	}
+	return *sc.esCheckDeliverySchedule
+}
+
+// not implement any interface, just using in main function for delivery layer injection
+// schedule spec is interval or cron expression with optional jitter, ping cycle is used as interval if not set
+func (sc *srvcheckConfig) SwarmpitCheckDeliverySchedule() string {
+	var key = "srvcheck.delivery.channel.schedule.swarmpitCheck"
+	cycle := sc.SwarmpitCheckDeliveryPingCycle()
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.swarmpitCheckDeliverySchedule == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, cycle.String())
+		}
+		sc.swarmpitCheckDeliverySchedule = _string(viper.GetString(key))
+	}
+	return *sc.swarmpitCheckDeliverySchedule
+}
+
+// not implement any interface, just using in main function for delivery layer injection
+// schedule spec is interval or cron expression with optional jitter, ping cycle is used as interval if not set
+func (sc *srvcheckConfig) ConsulCheckDeliverySchedule() string {
+	var key = "srvcheck.delivery.channel.schedule.consulCheck"
+	cycle := sc.ConsulCheckDeliveryPingCycle()
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.consulCheckDeliverySchedule == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, cycle.String())
+		}
+		sc.consulCheckDeliverySchedule = _string(viper.GetString(key))
+	}
+	return *sc.consulCheckDeliverySchedule
+}
+
+// not implement any interface, just using in main function for delivery layer injection
+func (sc *srvcheckConfig) DeliveryInitialRun() bool {
+	var key = "srvcheck.delivery.channel.initialRun"
+	if sc.deliveryInitialRun == nil {
+		if _, ok := viper.Get(key).(bool); !ok {
+			viper.Set(key, defaultDeliveryInitialRun)
+		}
+		sc.deliveryInitialRun = _bool(viper.GetBool(key))
+	}
+	return *sc.deliveryInitialRun
+}
+
 // init function initialize App global variable
 func init() {
 	App = &srvcheckConfig{}
@@ -318,4 +511,5 @@ func init() {
 
 // function returns pointer variable generated from parameter
 func _string(s string) *string { return &s }
+func _bool(b bool) *bool       { return &b }
 func _int(i int) *int          { return &i }
@@ -0,0 +1,211 @@
+// Create file in v.1.1.0
+// validate.go is file that define method of srvcheckConfig validating every config value of srvcheck domain
+// unlike getter of srvcheckConfig, invalid value is not replaced with default value but reported with key path
+
+package config
+
+import (
+	"fmt"
+	"github.com/inhies/go-bytesize"
+	"github.com/spf13/viper"
+	"strings"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// specValidator is interface that validate schedule spec of check delivery before applying it
+// you can see implementation in scheduler package
+type specValidator interface {
+	// ValidateSpec method return error if schedule spec is invalid
+	ValidateSpec(spec string) error
+}
+
+// Validate method validate every config value of srvcheck domain in v and return all of problems at once
+// cross-field condition is also validated (Ex, every check target service must not be empty), value not set is valid
+func (sc *srvcheckConfig) Validate(v *viper.Viper, sv specValidator) domain.ConfigErrors {
+	r := &reader{v: v}
+
+	r.string("srvcheck.repository.elasticsearch.index.name", defaultIndexName)
+	r.int("srvcheck.repository.elasticsearch.index.shardNum", defaultIndexShardNum, 1)
+	r.int("srvcheck.repository.elasticsearch.index.replicaNum", defaultIndexReplicaNum, 0)
+	r.oneOf("srvcheck.repository.elasticsearch.index.rollover", defaultIndexRollover, "none", "daily", "monthly", "alias")
+	r.duration("srvcheck.repository.elasticsearch.index.rolloverMaxAge", defaultIndexRolloverMaxAge, false)
+	r.duration("srvcheck.repository.elasticsearch.index.retention", defaultIndexRetention, true)
+
+	r.oneOf("srvcheck.execution.overlapPolicy", defaultOverlapPolicy, "skip", "queue", "wait")
+	r.duration("srvcheck.execution.runTimeout", defaultRunTimeout, true)
+	r.bool("srvcheck.execution.dryRun", false)
+	r.unmarshal("srvcheck.escalation", &domain.EscalationPolicy{})
+
+	validateElasticsearchParams(r, "srvcheck.elasticsearch.")
+	validateSwarmpitParams(r, "srvcheck.swarmpit.")
+	validateConsulParams(r, "srvcheck.consul.")
+
+	// ping cycle is only fallback interval of check whose schedule is not set, but still validated because it can be used
+	esCycle := r.duration("srvcheck.delivery.channel.pingCycle.elasticsearchCheck", defaultESCheckDeliveryPingCycle, false)
+	swarmpitCycle := r.duration("srvcheck.delivery.channel.pingCycle.swarmpitCheck", defaultSwarmpitCheckDeliveryPingCycle, false)
+	consulCycle := r.duration("srvcheck.delivery.channel.pingCycle.consulCheck", defaultConsulCheckDeliveryPingCycle, false)
+	r.schedule("srvcheck.delivery.channel.schedule.elasticsearchCheck", esCycle, sv)
+	r.schedule("srvcheck.delivery.channel.schedule.swarmpitCheck", swarmpitCycle, sv)
+	r.schedule("srvcheck.delivery.channel.schedule.consulCheck", consulCycle, sv)
+	r.bool("srvcheck.delivery.channel.initialRun", defaultDeliveryInitialRun)
+
+	validateCheckInstances(r, sv)
+	return r.errs
+}
+
+// validateElasticsearchParams validate config value about elasticsearch check whose key start with section
+func validateElasticsearchParams(r *reader, section string) {
+	r.int(section+"maximumShardsNumber", defaultMaximumShardsNumber, 1)
+	if pattern := r.string(section+"jaegerIndexPattern", defaultJaegerIndexPattern); pattern == "" {
+		r.fail(section+"jaegerIndexPattern", "must not be empty")
+	}
+	r.duration(section+"jaegerIndexMinLifeCycle", defaultJaegerIndexMinLifeCycle, false)
+}
+
+// validateSwarmpitParams validate config value about swarmpit check whose key start with section
+func validateSwarmpitParams(r *reader, section string) {
+	if name := r.string(section+"swarmpitAppServiceName", defaultSwarmpitAppServiceName); name == "" {
+		r.fail(section+"swarmpitAppServiceName", "must not be empty")
+	}
+	if usage := r.byteSize(section+"swarmpitAppMaxMemoryUsage", defaultSwarmpitAppMaxMemoryUsage); usage <= 0 {
+		r.fail(section+"swarmpitAppMaxMemoryUsage", "must be positive byte size, value: %s", usage)
+	}
+}
+
+// validateConsulParams validate config value about consul check whose key start with section
+func validateConsulParams(r *reader, section string) {
+	services := r.string(section+"checkTargetServices", defaultCheckTargetServices)
+	for _, srv := range strings.Split(services, ",") {
+		if strings.TrimSpace(srv) == "" {
+			r.fail(section+"checkTargetServices", "must be comma separated list of non-empty service, value: %s", services)
+			break
+		}
+	}
+	r.string(section+"consulServiceNameSpace", defaultConsulServiceNameSpace)
+	r.string(section+"dockerServiceNameSpace", defaultDockerServiceNameSpace)
+	r.duration(section+"connCheckPingTimeOut", defaultConnCheckPingTimeOut, false)
+}
+
+// reader read config value from viper with validation, every problem found while reading value is kept in errs
+// if value is invalid, default value received from parameter is returned so that reading can be continued
+type reader struct {
+	v    *viper.Viper
+	errs domain.ConfigErrors
+
+	// prefix is added to key of problem, it is used when v has only part of config (Ex, params of check instance)
+	prefix string
+}
+
+// fail method keep problem of config value with key, message is formatted with parameter
+func (r *reader) fail(key, format string, args ...interface{}) {
+	r.errs = append(r.errs, domain.ConfigError{Key: r.prefix + key, Message: fmt.Sprintf(format, args...)})
+}
+
+// string method return string value with key, or def if not set
+func (r *reader) string(key string, def string) string {
+	if !r.v.IsSet(key) {
+		return def
+	}
+
+	s, ok := r.v.Get(key).(string)
+	if !ok {
+		r.fail(key, "must be string, value: %v", r.v.Get(key))
+		return def
+	}
+	return s
+}
+
+// oneOf method return string value with key which must be one of values, or def if not set
+func (r *reader) oneOf(key string, def string, values ...string) string {
+	s := r.string(key, def)
+	for _, value := range values {
+		if s == value {
+			return s
+		}
+	}
+
+	r.fail(key, "must be one of %v, value: %s", values, s)
+	return def
+}
+
+// bool method return bool value with key, or def if not set
+func (r *reader) bool(key string, def bool) bool {
+	if !r.v.IsSet(key) {
+		return def
+	}
+
+	b, ok := r.v.Get(key).(bool)
+	if !ok {
+		r.fail(key, "must be true or false, value: %v", r.v.Get(key))
+		return def
+	}
+	return b
+}
+
+// int method return int value with key which must not be less than min, or def if not set
+func (r *reader) int(key string, def, min int) int {
+	if !r.v.IsSet(key) {
+		return def
+	}
+
+	i, ok := r.v.Get(key).(int)
+	if !ok || i < min {
+		r.fail(key, "must be integer not less than %d, value: %v", min, r.v.Get(key))
+		return def
+	}
+	return i
+}
+
+// byteSize method return byte size value with key, or def if not set
+func (r *reader) byteSize(key string, def bytesize.ByteSize) bytesize.ByteSize {
+	if !r.v.IsSet(key) {
+		return def
+	}
+
+	size, err := bytesize.Parse(r.v.GetString(key))
+	if err != nil {
+		r.fail(key, "must be byte size (Ex, 2GB), value: %v", r.v.Get(key))
+		return def
+	}
+	return size
+}
+
+// duration method return positive duration value with key, or def if not set, zero is also valid if allowZero is true
+func (r *reader) duration(key string, def time.Duration, allowZero bool) time.Duration {
+	if !r.v.IsSet(key) {
+		return def
+	}
+
+	d, err := time.ParseDuration(r.v.GetString(key))
+	if err != nil || d < 0 || (d == 0 && !allowZero) {
+		if allowZero {
+			r.fail(key, "must be zero or positive duration (Ex, 0s, 5m), value: %v", r.v.Get(key))
+			return def
+		}
+		r.fail(key, "must be positive duration (Ex, 5m), value: %v", r.v.Get(key))
+		return def
+	}
+	return d
+}
+
+// schedule method return schedule spec with key validated with sv, or interval of cycle if not set
+func (r *reader) schedule(key string, cycle time.Duration, sv specValidator) string {
+	spec := cycle.String()
+	if r.v.IsSet(key) {
+		spec = r.v.GetString(key)
+	}
+
+	if err := sv.ValidateSpec(spec); err != nil {
+		r.fail(key, "must be valid schedule spec, %s", err.Error())
+	}
+	return spec
+}
+
+// unmarshal method decode value with key into out, and keep problem if value can't be decoded
+func (r *reader) unmarshal(key string, out interface{}) {
+	if err := r.v.UnmarshalKey(key, out); err != nil {
+		r.fail(key, "invalid format, %s", err.Error())
+	}
+}
@@ -26,6 +26,13 @@ type systemCheckHandlerContext struct {
 	chanWaitGroup *sync.WaitGroup
 }
 
+// checkScheduler is interface that schedule check process & deliver the run time with golang channel
+// you can see implementation in scheduler package
+type checkScheduler interface {
+	// C return channel receiving the time when check process should be run
+	C() <-chan time.Time
+}
+
 // SetGlobalContext method set globalContext variable using in all handler defined in this package
 // context received from parameter must be WithCancel context & have *sync.WaitGroup value
 // the panic will be raised if parameter context is not valid to above constraints.
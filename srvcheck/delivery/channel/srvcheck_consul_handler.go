@@ -22,13 +22,13 @@ type consulCheckHandler struct {
 }
 
 // NewConsulCheckHandler define consulCheckHandler ptr instance & register handling channel msg to usecase
-func NewConsulCheckHandler(c <-chan time.Time, cu domain.ConsulCheckUseCase) {
+func NewConsulCheckHandler(cs checkScheduler, cu domain.ConsulCheckUseCase) {
 	handler := &consulCheckHandler{
 		handlerCtx: globalContext,
 		cUsecase:   cu,
 	}
 
-	go handler.startListening(c)
+	go handler.startListening(cs.C())
 	log.Println("START TO LISTEN CHANNEL MSG ABOUT SERVICE CONSUL CHECK")
 }
 
@@ -39,7 +39,7 @@ func (ch *consulCheckHandler) startListening(c <-chan time.Time) {
 
 // checkConsul method set context & call CheckConsul usecase method, handle error
 func (ch *consulCheckHandler) checkConsul(t time.Time) {
-	ctx := context.Background()
+	ctx := ch.handlerCtx.chanCancelCtx
 	ctx = context.WithValue(ctx, "time", t)
 
 	if err := ch.cUsecase.CheckConsul(ctx); err != nil {
@@ -22,13 +22,13 @@ type elasticsearchCheckHandler struct {
 }
 
 // NewElasticsearchCheckHandler define elasticsearchCheckHandler ptr instance & register handling channel msg to usecase
-func NewElasticsearchCheckHandler(c <-chan time.Time, eu domain.ElasticsearchCheckUseCase) {
+func NewElasticsearchCheckHandler(cs checkScheduler, eu domain.ElasticsearchCheckUseCase) {
 	handler := &elasticsearchCheckHandler{
 		handlerCtx: globalContext,
 		eUsecase:   eu,
 	}
 
-	go handler.startListening(c)
+	go handler.startListening(cs.C())
 	log.Println("START TO LISTEN CHANNEL MSG ABOUT SERVICE ELASTICSEARCH CHECK")
 }
 
@@ -39,7 +39,7 @@ func (eh *elasticsearchCheckHandler) startListening(c <-chan time.Time) {
 
 // checkElasticsearch method set context & call CheckElasticsearch usecase method, handle error
 func (eh *elasticsearchCheckHandler) checkElasticsearch(t time.Time) {
-	ctx := context.Background()
+	ctx := eh.handlerCtx.chanCancelCtx
 	ctx = context.WithValue(ctx, "time", t)
 
 	if err := eh.eUsecase.CheckElasticsearch(ctx); err != nil {
@@ -22,13 +22,13 @@ type swarmpitCheckHandler struct {
 }
 
 // NewSwarmpitCheckHandler define swarmpitCheckHandler ptr instance & register handling channel msg to usecase
-func NewSwarmpitCheckHandler(c <-chan time.Time, su domain.SwarmpitCheckUseCase) {
+func NewSwarmpitCheckHandler(cs checkScheduler, su domain.SwarmpitCheckUseCase) {
 	handler := &swarmpitCheckHandler{
 		handlerCtx: globalContext,
 		sUsecase:   su,
 	}
 
-	go handler.startListening(c)
+	go handler.startListening(cs.C())
 	log.Println("START TO LISTEN CHANNEL MSG ABOUT SERVICE SWARMPIT CHECK")
 }
 
@@ -39,7 +39,7 @@ func (sh *swarmpitCheckHandler) startListening(c <-chan time.Time) {
 
 // checkSwarmpit method set context & call CheckSwarmpit usecase method, handle error
 func (sh *swarmpitCheckHandler) checkSwarmpit(t time.Time) {
-	ctx := context.Background()
+	ctx := sh.handlerCtx.chanCancelCtx
 	ctx = context.WithValue(ctx, "time", t)
 
 	if err := sh.sUsecase.CheckSwarmpit(ctx); err != nil {
@@ -1,68 +1,106 @@
 package http
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	"net/http"
+	"strings"
 
 	"github.com/DMS-SMS/v1-health-check/domain"
 )
 
 // srvcheckHandler represent the http handler for srvcheck
 type srvcheckHandler struct {
-	cUsecase domain.ConsulCheckUseCase
-	eUsecase domain.ElasticsearchCheckUseCase
-	sUsecase domain.SwarmpitCheckUseCase
+	cUsecases []domain.ConsulCheckUseCase
+	eUsecases []domain.ElasticsearchCheckUseCase
+	sUsecases []domain.SwarmpitCheckUseCase
+}
+
+// triggerAuthenticator is interface that authenticate request to endpoint triggering check process
+// you can see implementation in auth package
+type triggerAuthenticator interface {
+	// AuthenticateTrigger return gin middleware which abort request if credential having trigger role is not authenticated
+	AuthenticateTrigger() gin.HandlerFunc
 }
 
 // NewSrvcheckHandler initialize the resources of srvcheck domain to HTTP API endpoint
-func NewSrvcheckHandler(r *gin.Engine, cu domain.ConsulCheckUseCase, eu domain.ElasticsearchCheckUseCase, su domain.SwarmpitCheckUseCase) {
+// every endpoint needs trigger role, because check process may run remediation such as removing container
+// endpoint is registered for every check instance with type of that (Ex, service-check/types/consul, service-check/types/consul-secondary)
+func NewSrvcheckHandler(r *gin.Engine, ta triggerAuthenticator, cus []domain.ConsulCheckUseCase, eus []domain.ElasticsearchCheckUseCase, sus []domain.SwarmpitCheckUseCase) {
 	h := &srvcheckHandler{
-		cUsecase: cu,
-		eUsecase: eu,
-		sUsecase: su,
+		cUsecases: cus,
+		eUsecases: eus,
+		sUsecases: sus,
+	}
+
+	for _, cu := range h.cUsecases {
+		r.POST("service-check/types/"+routeType(cu), ta.AuthenticateTrigger(), h.CheckConsul(cu))
+	}
+	for _, eu := range h.eUsecases {
+		r.POST("service-check/types/"+routeType(eu), ta.AuthenticateTrigger(), h.CheckElasticsearch(eu))
 	}
+	for _, su := range h.sUsecases {
+		r.POST("service-check/types/"+routeType(su), ta.AuthenticateTrigger(), h.CheckSwarmpit(su))
+	}
+}
 
-	r.POST("service-check/types/consul", h.CheckConsul)
-	r.POST("service-check/types/elasticsearch", h.CheckElasticsearch)
-	r.POST("service-check/types/swarmpit", h.CheckSwarmpit)
+// CheckConsul method return handler delivering HTTP request to CheckConsul method of domain.ConsulCheckUseCase received from parameter
+func (sh *srvcheckHandler) CheckConsul(cu domain.ConsulCheckUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch err := cu.CheckConsul(checkContext(c)); err {
+		case nil:
+			c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "finished to check consul status"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": http.StatusInternalServerError, "code": 0,
+				"message": errors.Wrap(err, "failed to check consul status").Error(),
+			})
+		}
+	}
 }
 
-// CheckConsul method deliver HTTP request to CheckConsul method of domain.ConsulCheckUseCase
-func (sh *srvcheckHandler) CheckConsul(c *gin.Context) {
-	switch err := sh.cUsecase.CheckConsul(c.Request.Context()); err {
-	case nil:
-		c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "finished to check consul status"})
-	default:
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": http.StatusInternalServerError, "code": 0,
-			"message": errors.Wrap(err, "failed to check consul status").Error(),
-		})
+// CheckElasticsearch method return handler delivering HTTP request to CheckElasticsearch method of domain.ElasticsearchCheckUseCase received from parameter
+func (sh *srvcheckHandler) CheckElasticsearch(eu domain.ElasticsearchCheckUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch err := eu.CheckElasticsearch(checkContext(c)); err {
+		case nil:
+			c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "finished to check elasticsearch status"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": http.StatusInternalServerError, "code": 0,
+				"message": errors.Wrap(err, "failed to check elasticsearch status").Error(),
+			})
+		}
 	}
 }
 
-// CheckElasticsearch method deliver HTTP request to CheckElasticsearch method of domain.ElasticsearchCheckUseCase
-func (sh *srvcheckHandler) CheckElasticsearch(c *gin.Context) {
-	switch err := sh.eUsecase.CheckElasticsearch(c.Request.Context()); err {
-	case nil:
-		c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "finished to check elasticsearch status"})
-	default:
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": http.StatusInternalServerError, "code": 0,
-			"message": errors.Wrap(err, "failed to check elasticsearch status").Error(),
-		})
+// CheckSwarmpit method return handler delivering HTTP request to CheckSwarmpit method of domain.SwarmpitCheckUseCase received from parameter
+func (sh *srvcheckHandler) CheckSwarmpit(su domain.SwarmpitCheckUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch err := su.CheckSwarmpit(checkContext(c)); err {
+		case nil:
+			c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "finished to check swarmpit status"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": http.StatusInternalServerError, "code": 0,
+				"message": errors.Wrap(err, "failed to check swarmpit status").Error(),
+			})
+		}
 	}
 }
 
-// CheckSwarmpit method deliver HTTP request to CheckSwarmpit method of domain.SwarmpitCheckUseCase
-func (sh *srvcheckHandler) CheckSwarmpit(c *gin.Context) {
-	switch err := sh.sUsecase.CheckSwarmpit(c.Request.Context()); err {
-	case nil:
-		c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "finished to check swarmpit status"})
-	default:
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": http.StatusInternalServerError, "code": 0,
-			"message": errors.Wrap(err, "failed to check swarmpit status").Error(),
-		})
+// checkContext return context of request used in check process, which is derived with dry-run mode if ?dryRun=true in query
+// check process run with dry-run mode compute & record remediation without executing that
+func checkContext(c *gin.Context) context.Context {
+	if c.Query("dryRun") == "true" {
+		return domain.WithDryRun(c.Request.Context())
 	}
+	return c.Request.Context()
+}
+
+// routeType return type of check used in path of HTTP API, which is check type without Check suffix in lower case
+// (Ex, DiskCheck -> disk, swarmpit-sidecar -> swarmpit-sidecar)
+func routeType(reporter domain.CheckStatusReporter) string {
+	return strings.ToLower(strings.TrimSuffix(reporter.Status().Type, "Check"))
 }
@@ -20,11 +20,8 @@ import (
 
 // esConsulCheckHistoryRepository is to handle ConsulCheckHistoryRepository model using elasticsearch as data store
 type esConsulCheckHistoryRepository struct {
-	// esMigrator is used for migrate elasticsearch repository in Migrate method
-	esMigrator esRepositoryMigrator
-
-	// myCfg is used for get consul history repository config about elasticsearch
-	myCfg esConsulCheckHistoryRepoConfig
+	// esIndexManager is used for migrating index & deciding index to write history, injected from the outside package
+	esIndexManager indexManager
 
 	// esCli is elasticsearch client connection injected from the outside package
 	esCli *elasticsearch.Client
@@ -33,22 +30,16 @@ type esConsulCheckHistoryRepository struct {
 	reqBodyWriter reqBodyWriter
 }
 
-// esConsulCheckHistoryRepoConfig is the config for consul check history repository using elasticsearch
-type esConsulCheckHistoryRepoConfig interface {
-	// get common method from embedding esRepositoryComponentConfig
-	esRepositoryComponentConfig
-}
-
 // NewESConsulCheckHistoryRepository return new object that implement ConsulCheckHistoryRepository interface
 func NewESConsulCheckHistoryRepository(
-	cfg esConsulCheckHistoryRepoConfig,
+	im indexManager,
 	cli *elasticsearch.Client,
 	w reqBodyWriter,
 ) domain.ConsulCheckHistoryRepository {
 	repo := &esConsulCheckHistoryRepository{
-		myCfg:         cfg,
-		esCli:         cli,
-		reqBodyWriter: w,
+		esIndexManager: im,
+		esCli:          cli,
+		reqBodyWriter:  w,
 	}
 
 	if err := repo.Migrate(); err != nil {
@@ -60,7 +51,7 @@ func NewESConsulCheckHistoryRepository(
 
 // Migrate implement Migrate method of domain.ConsulCheckHistoryRepository
 func (ecr *esConsulCheckHistoryRepository) Migrate() error {
-	return ecr.esMigrator.Migrate(ecr.myCfg, ecr.esCli, ecr.reqBodyWriter)
+	return ecr.esIndexManager.Migrate()
 }
 
 // Store implement Store method of domain.ConsulCheckHistoryRepository
@@ -78,7 +69,7 @@ func (ecr *esConsulCheckHistoryRepository) Store(history *domain.ConsulCheckHist
 	}
 
 	resp, err := (esapi.IndexRequest{
-		Index:   ecr.myCfg.IndexName(),
+		Index:   ecr.esIndexManager.WriteIndex(history.Timestamp()),
 		Body:    bytes.NewReader(buf.Bytes()),
 		Timeout: time.Second * 5,
 	}).Do(context.Background(), ecr.esCli)
@@ -94,5 +85,6 @@ func (ecr *esConsulCheckHistoryRepository) Store(history *domain.ConsulCheckHist
 	result := map[string]interface{}{}
 	_ = json.NewDecoder(resp.Body).Decode(&result)
 	b, _ = json.Marshal(result)
+
 	return
 }
@@ -20,11 +20,8 @@ import (
 
 // esElasticsearchCheckHistoryRepository is to handle ElasticsearchCheckHistoryRepository model using elasticsearch as data store
 type esElasticsearchCheckHistoryRepository struct {
-	// esMigrator is used for migrate elasticsearch repository in Migrate method
-	esMigrator esRepositoryMigrator
-
-	// myCfg is used for get elasticsearch history repository config about elasticsearch
-	myCfg esElasticsearchCheckHistoryRepoConfig
+	// esIndexManager is used for migrating index & deciding index to write history, injected from the outside package
+	esIndexManager indexManager
 
 	// esCli is elasticsearch client connection injected from the outside package
 	esCli *elasticsearch.Client
@@ -33,22 +30,16 @@ type esElasticsearchCheckHistoryRepository struct {
 	reqBodyWriter reqBodyWriter
 }
 
-// esElasticsearchCheckHistoryRepoConfig is the config for elasticsearch check history repository using elasticsearch
-type esElasticsearchCheckHistoryRepoConfig interface {
-	// get common method from embedding esRepositoryComponentConfig
-	esRepositoryComponentConfig
-}
-
 // NewESElasticsearchCheckHistoryRepository return new object that implement ElasticsearchCheckHistoryRepository interface
 func NewESElasticsearchCheckHistoryRepository(
-	cfg esElasticsearchCheckHistoryRepoConfig,
+	im indexManager,
 	cli *elasticsearch.Client,
 	w reqBodyWriter,
 ) domain.ElasticsearchCheckHistoryRepository {
 	repo := &esElasticsearchCheckHistoryRepository{
-		myCfg:         cfg,
-		esCli:         cli,
-		reqBodyWriter: w,
+		esIndexManager: im,
+		esCli:          cli,
+		reqBodyWriter:  w,
 	}
 
 	if err := repo.Migrate(); err != nil {
@@ -60,7 +51,7 @@ func NewESElasticsearchCheckHistoryRepository(
 
 // Migrate Implement Migrate method of ElasticsearchCheckHistoryRepository interface
 func (eer *esElasticsearchCheckHistoryRepository) Migrate() error {
-	return eer.esMigrator.Migrate(eer.myCfg, eer.esCli, eer.reqBodyWriter)
+	return eer.esIndexManager.Migrate()
 }
 
 // Store Implement Store method of ElasticsearchCheckHistoryRepository interface
@@ -78,7 +69,7 @@ func (eer *esElasticsearchCheckHistoryRepository) Store(history *domain.Elastics
 	}
 
 	resp, err := (esapi.IndexRequest{
-		Index:   eer.myCfg.IndexName(),
+		Index:   eer.esIndexManager.WriteIndex(history.Timestamp()),
 		Body:    bytes.NewReader(buf.Bytes()),
 		Timeout: time.Second * 5,
 	}).Do(context.Background(), eer.esCli)
@@ -94,5 +85,6 @@ func (eer *esElasticsearchCheckHistoryRepository) Store(history *domain.Elastics
 	result := map[string]interface{}{}
 	_ = json.NewDecoder(resp.Body).Decode(&result)
 	b, _ = json.Marshal(result)
+
 	return
 }
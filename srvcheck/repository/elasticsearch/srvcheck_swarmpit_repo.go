@@ -20,11 +20,8 @@ import (
 
 // esSwarmpitCheckHistoryRepository is to handle SwarmpitCheckHistoryRepository model using elasticsearch as data store
 type esSwarmpitCheckHistoryRepository struct {
-	// esMigrator is used for migrate elasticsearch repository in Migrate method
-	esMigrator esRepositoryMigrator
-
-	// myCfg is used for get swarmpit history repository config about elasticsearch
-	myCfg esSwarmpitCheckHistoryRepoConfig
+	// esIndexManager is used for migrating index & deciding index to write history, injected from the outside package
+	esIndexManager indexManager
 
 	// esCli is elasticsearch client connection injected from the outside package
 	esCli *elasticsearch.Client
@@ -33,22 +30,16 @@ type esSwarmpitCheckHistoryRepository struct {
 	reqBodyWriter reqBodyWriter
 }
 
-// esSwarmpitCheckHistoryRepoConfig is the config for swarmpit check history repository using elasticsearch
-type esSwarmpitCheckHistoryRepoConfig interface {
-	// get common method from embedding esRepositoryComponentConfig
-	esRepositoryComponentConfig
-}
-
 // NewESSwarmpitCheckHistoryRepository return new object that implement SwarmpitCheckHistoryRepository interface
 func NewESSwarmpitCheckHistoryRepository(
-	cfg esSwarmpitCheckHistoryRepoConfig,
+	im indexManager,
 	cli *elasticsearch.Client,
 	w reqBodyWriter,
 ) domain.SwarmpitCheckHistoryRepository {
 	repo := &esSwarmpitCheckHistoryRepository{
-		myCfg:         cfg,
-		esCli:         cli,
-		reqBodyWriter: w,
+		esIndexManager: im,
+		esCli:          cli,
+		reqBodyWriter:  w,
 	}
 
 	if err := repo.Migrate(); err != nil {
@@ -60,7 +51,7 @@ func NewESSwarmpitCheckHistoryRepository(
 
 // Implement Migrate method of SwarmpitCheckHistoryRepository interface
 func (esr *esSwarmpitCheckHistoryRepository) Migrate() error {
-	return esr.esMigrator.Migrate(esr.myCfg, esr.esCli, esr.reqBodyWriter)
+	return esr.esIndexManager.Migrate()
 }
 
 // Implement Store method of SwarmpitCheckHistoryRepository interface
@@ -78,7 +69,7 @@ func (esr *esSwarmpitCheckHistoryRepository) Store(history *domain.SwarmpitCheck
 	}
 
 	resp, err := (esapi.IndexRequest{
-		Index:   esr.myCfg.IndexName(),
+		Index:   esr.esIndexManager.WriteIndex(history.Timestamp()),
 		Body:    bytes.NewReader(buf.Bytes()),
 		Timeout: time.Second * 5,
 	}).Do(context.Background(), esr.esCli)
@@ -94,5 +85,6 @@ func (esr *esSwarmpitCheckHistoryRepository) Store(history *domain.SwarmpitCheck
 	result := map[string]interface{}{}
 	_ = json.NewDecoder(resp.Body).Decode(&result)
 	b, _ = json.Marshal(result)
+
 	return
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,6 +27,20 @@ const (
 	consulStatusUnhealthy                           // represent consul check status is unhealthy
 )
 
+// String method return domain.CheckState const value matched with consul check status
+func (s consulCheckStatus) String() string {
+	switch s {
+	case consulStatusHealthy:
+		return domain.CheckStateHealthy
+	case consulStatusRecovering:
+		return domain.CheckStateRecovering
+	case consulStatusUnhealthy:
+		return domain.CheckStateUnhealthy
+	default:
+		return "UNKNOWN"
+	}
+}
+
 // consulCheckUsecase implement ConsulCheckUsecase interface in domain and used in delivery layer
 type consulCheckUsecase struct {
 	// myCfg is used for getting consul check usecase config
@@ -34,8 +49,20 @@ type consulCheckUsecase struct {
 	// historyRepo is used for store consul check history and injected from outside
 	historyRepo domain.ConsulCheckHistoryRepository
 
-	// slackChat is used for agent slack API about chatting
-	slackChatAgency slackChatAgency
+	// incidentRepo is used for store incident of consul check and injected from outside
+	incidentRepo domain.IncidentRepository
+
+	// historyObserver is used for observing check history after check process (ex, recording metrics)
+	historyObserver historyObserver
+
+	// maintenanceAgency is used as agency about maintenance window pausing check or suppressing remediation
+	maintenanceAgency maintenanceAgency
+
+	// notifyAgency is used as agency about notifier backends sending alarm (Ex, slack, webhook, email)
+	notifyAgency notifyAgency
+
+	// clock is used for getting current time in configured timezone, which is set to timestamp of check history
+	clock clock
 
 	// consulAgency is used as agency about consul API
 	consulAgency consulAgency
@@ -49,6 +76,15 @@ type consulCheckUsecase struct {
 	// status represent current process status of consul health check
 	status consulCheckStatus
 
+	// executor is used for running check process with single flight policy & run timeout
+	executor checkExecutor
+
+	// thresholds represent thresholds snapshot taken at the start of latest check process, which is shown in alarm
+	thresholds domain.ConsulCheckThresholds
+
+	// record represent status transition & latest check process of consul health check
+	record checkRecord
+
 	// mutex help to prevent race condition when set status field value
 	mutex sync.Mutex
 }
@@ -56,13 +92,13 @@ type consulCheckUsecase struct {
 // consulAgency is agency that agent various command about consul API
 type consulAgency interface {
 	// GetServices method get services in consul & return services interface implement
-	GetServices(srv string) (srvIter interface {
+	GetServices(ctx context.Context, srv string) (srvIter interface {
 		HasNext() bool           // HasNext method return if srvIter has next element
 		Next() (id, addr string) // Next method return next service id, address
 	}, err error)
 
 	// DeregisterInstance method deregister service in consul with received id
-	DeregisterInstance(id string) (err error)
+	DeregisterInstance(ctx context.Context, id string) (err error)
 }
 
 // gRPCAgency is agency that agent various command about gRPC
@@ -76,53 +112,69 @@ type consulCheckUsecaseConfig interface {
 	// get common config method from embedding serviceCheckUsecaseComponentConfig
 	serviceCheckUsecaseComponentConfig
 
-	// CheckTargetServices method returns string slice containing target services to check in usecase
-	CheckTargetServices() []string
-
-	// ConsulServiceNameSpace method returns name space of consul service
-	ConsulServiceNameSpace() string
-
-	// DockerServiceNameSpace method returns name space of docker service
-	DockerServiceNameSpace() string
-
-	// ConnCheckPingTimeOut method returns timeout duration in ping to check connection
-	ConnCheckPingTimeOut() time.Duration
+	// ConsulCheckThresholds method returns target list & threshold snapshot of consul check, which is read at once in every check process
+	ConsulCheckThresholds() domain.ConsulCheckThresholds
 }
 
 // NewConsulCheckUsecase function return ConsulCheckUseCase implementation after initializing
 func NewConsulCheckUsecase(
 	cfg consulCheckUsecaseConfig,
 	shr domain.ConsulCheckHistoryRepository,
-	sca slackChatAgency,
+	ir domain.IncidentRepository,
+	ho historyObserver,
+	ma maintenanceAgency,
+	na notifyAgency,
+	c clock,
+	ce checkExecutor,
 	ca consulAgency,
 	ga gRPCAgency,
 	da dockerAgency,
 ) domain.ConsulCheckUseCase {
 	return &consulCheckUsecase{
 		// initialize field with parameter received from caller
-		myCfg:           cfg,
-		historyRepo:     shr,
-		slackChatAgency: sca,
-		consulAgency:    ca,
-		gRPCAgency:      ga,
-		dockerAgency:    da,
+		myCfg:             cfg,
+		historyRepo:       shr,
+		incidentRepo:      ir,
+		historyObserver:   ho,
+		maintenanceAgency: ma,
+		notifyAgency:      na,
+		clock:             c,
+		executor:          ce,
+		consulAgency:      ca,
+		gRPCAgency:        ga,
+		dockerAgency:      da,
 
 		// initialize field with default value
-		status: consulStatusHealthy,
-		mutex:  sync.Mutex{},
+		status:     consulStatusHealthy,
+		thresholds: cfg.ConsulCheckThresholds(),
+		record:     newCheckRecord("srvcheck", "ConsulCheck", cfg.CheckType(), c),
+		mutex:      sync.Mutex{},
 	}
 }
 
-// CheckConsul check consul health with checkConsul method & store check history in repository
+// CheckConsul check consul health with checkConsul method in executor & store check history in repository
 // Implement CheckConsul method of ConsulCheckUseCase interface
-func (ccu *consulCheckUsecase) CheckConsul(ctx context.Context) (err error) {
-	history := ccu.checkConsul(ctx)
+func (ccu *consulCheckUsecase) CheckConsul(ctx context.Context) error {
+	return ccu.executor.Execute(ctx, func(ctx context.Context) domain.CheckHistory {
+		return ccu.checkConsul(ctx)
+	}, func(level, message string) domain.CheckHistory {
+		history := ccu.newOperationHistory(level, "", "")
+		history.Message = message
+		return history
+	}, ccu.handleHistory)
+}
+
+// handleHistory observe consul check history with duration, set to record & store in repository
+func (ccu *consulCheckUsecase) handleHistory(history domain.CheckHistory, duration time.Duration) error {
+	incidentErr := ccu.trackIncident(history.(*domain.ConsulCheckHistory))
+	ccu.historyObserver.ObserveHistory(history, duration)
+	ccu.setLastHistory(history)
 
-	if b, err := ccu.historyRepo.Store(history); err != nil {
+	if b, err := ccu.historyRepo.Store(history.(*domain.ConsulCheckHistory)); err != nil {
 		return errors.Wrapf(err, "failed to store consul check history, response: %s", string(b))
 	}
 
-	return
+	return incidentErr
 }
 
 // method processed with below logic about consul health check according to current check status
@@ -134,12 +186,21 @@ func (ccu *consulCheckUsecase) CheckConsul(ctx context.Context) (err error) {
 // 2 : 관리자가 직접 확인해야함 (상태 확인 수행 X)
 // 2 -> 0 : 관리자 직접 상태 회복 완료 (상태 회복 알림 발행)
 func (ccu *consulCheckUsecase) checkConsul(ctx context.Context) (history *domain.ConsulCheckHistory) {
+	thresholds := ccu.takeThresholds()
+
 	_uuid := uuid.New().String()
 	history = new(domain.ConsulCheckHistory)
-	history.FillPrivateComponent()
+	history.FillPrivateComponent(ccu.clock.Now())
+	history.SetType(ccu.record._type)
 	history.UUID = _uuid
 	history.InstancesPerService = map[string][]string{}
 
+	if paused, reason := ccu.maintenanceAgency.IsPaused(ccu.record.domain, ccu.record._type); paused {
+		history.ProcessLevel.Set(pausedLevel)
+		history.Message = "consul check is paused by maintenance window, reason: " + reason
+		return
+	}
+
 	switch ccu.status {
 	case consulStatusHealthy:
 		break
@@ -150,18 +211,22 @@ func (ccu *consulCheckUsecase) checkConsul(ctx context.Context) (history *domain
 	case consulStatusUnhealthy:
 		history.ProcessLevel.Set(unhealthyLevel)
 		history.Message = "consul check is unhealthy now"
+		if alarm, ok := ccu.reminder(history); ok {
+			history.ProcessLevel.Append(escalatedLevel)
+			history.AddAlarmResults(ccu.notifyAgency.Notify(ctx, alarm))
+		}
 		return
 	}
 
 	srvM := map[string][]struct{ id, addr string }{}
-	for _, srv := range ccu.myCfg.CheckTargetServices() {
-		cslSrv := ccu.myCfg.ConsulServiceNameSpace() + srv
-		iter, err := ccu.consulAgency.GetServices(cslSrv)
+	for _, srv := range thresholds.CheckTargetServices {
+		cslSrv := thresholds.ConsulServiceNameSpace + srv
+		iter, err := ccu.consulAgency.GetServices(ctx, cslSrv)
 		if err != nil {
 			history.ProcessLevel.Set(errorLevel)
 			history.SetError(errors.Wrap(err, "failed to get services in consul"))
 			msg := "!consul check error occurred! unable to get services in consul"
-			history.SetAlarmResult(ccu.slackChatAgency.SendMessage("x", msg, _uuid))
+			history.AddAlarmResults(ccu.notifyAgency.Notify(ctx, ccu.alarm(history, errorLevel, "x", msg)))
 			return
 		}
 
@@ -183,9 +248,15 @@ func (ccu *consulCheckUsecase) checkConsul(ctx context.Context) (history *domain
 	var unableSrvIDs []string
 	for _, srvs := range srvM {
 		for _, srv := range srvs {
-			toCtx, _ := context.WithTimeout(context.Background(), ccu.myCfg.ConnCheckPingTimeOut())
+			toCtx, cancel := context.WithTimeout(ctx, thresholds.ConnCheckPingTimeOut)
 			err := ccu.gRPCAgency.PingToCheckConn(toCtx, srv.addr, grpc.WithInsecure(), grpc.WithBlock())
-			if toCtx.Err() != nil {
+			timedOut := toCtx.Err() != nil // read before cancel, because context is always done after cancel
+			cancel()
+			if ctx.Err() != nil {
+				history.ProcessLevel.Set(errorLevel)
+				history.SetError(errors.Wrap(ctx.Err(), "context is done while pinging to check connection"))
+				return
+			} else if timedOut {
 				unableSrvIDs = append(unableSrvIDs, srv.id)
 			} else if err != nil {
 				history.ProcessLevel.Set(errorLevel)
@@ -197,20 +268,38 @@ func (ccu *consulCheckUsecase) checkConsul(ctx context.Context) (history *domain
 
 	// recover(deregister) if any connection unable service is exist
 	if len(unableSrvIDs) > 0 {
+		if suppressed, reason := ccu.maintenanceAgency.IsRemediationSuppressed(ccu.record.domain, ccu.record._type); suppressed {
+			history.ProcessLevel.Set(suppressedLevel)
+			history.Message = "consul weak is detected, but remediation is suppressed by maintenance window, reason: " + reason
+			return
+		}
+
+		if isDryRun(ctx, ccu.myCfg) {
+			history.SetSimulated()
+			history.ProcessLevel.Set(simulatedLevel)
+			history.DeregisteredInstances = unableSrvIDs
+			history.Message = fmt.Sprintf("deregistering unable services in consul is simulated in dry-run mode, instances: %v", unableSrvIDs)
+			if ccu.simulate() {
+				msg := fmt.Sprintf("!consul check dry-run! %d unable services would be deregistered, but not executed", len(unableSrvIDs))
+				history.AddAlarmResults(ccu.notifyAgency.Notify(ctx, ccu.alarm(history, simulatedLevel, "test_tube", msg)))
+			}
+			return
+		}
+
 		ccu.setStatus(consulStatusRecovering)
 		history.ProcessLevel.Set(weakDetectedLevel)
 		history.Message = "deregistered services in consul which is unable to check connection pick"
 		msg := "!consul check weak detected! start to deregister unable services"
-		history.SetAlarmResult(ccu.slackChatAgency.SendMessage("pill", msg, _uuid))
+		history.AddAlarmResults(ccu.notifyAgency.Notify(ctx, ccu.alarm(history, weakDetectedLevel, "pill", msg)))
 		history.IfInstanceDeregistered = true
 
 		var successIDs, failIDs []string
 		for _, srvID := range unableSrvIDs {
-			if err := ccu.consulAgency.DeregisterInstance(srvID); err != nil {
+			if err := ccu.consulAgency.DeregisterInstance(ctx, srvID); err != nil {
 				failIDs = append(failIDs, srvID)
 				history.ProcessLevel.Append(errorLevel)
 				msg := fmt.Sprintf("!consul check error occurred! failed to deregister service, id: %s, err: %v", srvID, err)
-				_, _, _ = ccu.slackChatAgency.SendMessage("broken_heart", msg, _uuid)
+				history.AddAlarmResults(ccu.notifyAgency.Notify(ctx, ccu.alarm(history, unhealthyLevel, "broken_heart", msg)))
 				history.SetError(errors.Wrap(err, "failed to deregister service"))
 			} else {
 				successIDs = append(successIDs, srvID)
@@ -225,38 +314,56 @@ func (ccu *consulCheckUsecase) checkConsul(ctx context.Context) (history *domain
 
 	// check services that don't have any instances registered in consul
 	var unableSrvs []string
-	for _, srv := range ccu.myCfg.CheckTargetServices() {
-		if len(srvM[ccu.myCfg.ConsulServiceNameSpace()+srv]) == 0 {
-			unableSrvs = append(unableSrvs, ccu.myCfg.DockerServiceNameSpace()+srv)
+	for _, srv := range thresholds.CheckTargetServices {
+		if len(srvM[thresholds.ConsulServiceNameSpace+srv]) == 0 {
+			unableSrvs = append(unableSrvs, thresholds.DockerServiceNameSpace+srv)
 		}
 	}
 
 	// restart(registered when start) if any service don't have any instances
 	if len(unableSrvs) > 0 {
+		if suppressed, reason := ccu.maintenanceAgency.IsRemediationSuppressed(ccu.record.domain, ccu.record._type); suppressed {
+			history.ProcessLevel.Set(suppressedLevel)
+			history.Message = "consul weak is detected, but remediation is suppressed by maintenance window, reason: " + reason
+			return
+		}
+
+		if isDryRun(ctx, ccu.myCfg) {
+			history.SetSimulated()
+			history.ProcessLevel.Set(simulatedLevel)
+			history.DeregisteredInstances = unableSrvs
+			history.Message = fmt.Sprintf("restarting container of services in docker is simulated in dry-run mode, services: %v", unableSrvs)
+			if ccu.simulate() {
+				msg := fmt.Sprintf("!consul check dry-run! containers of %d services would be restarted, but not executed", len(unableSrvs))
+				history.AddAlarmResults(ccu.notifyAgency.Notify(ctx, ccu.alarm(history, simulatedLevel, "test_tube", msg)))
+			}
+			return
+		}
+
 		ccu.setStatus(consulStatusRecovering)
 		history.ProcessLevel.Set(weakDetectedLevel)
 		history.Message = "restart container in docker which is don't have any instances in consul"
 		msg := "!consul check weak detected! start to restart container"
-		history.SetAlarmResult(ccu.slackChatAgency.SendMessage("pill", msg, _uuid))
+		history.AddAlarmResults(ccu.notifyAgency.Notify(ctx, ccu.alarm(history, weakDetectedLevel, "pill", msg)))
 		history.IfContainerRestarted = true
 
 		var successSrvs, failSrvs []string
 		for _, srv := range unableSrvs {
-			container, err := ccu.dockerAgency.GetContainerWithServiceName(srv)
+			container, err := ccu.dockerAgency.GetContainerWithServiceName(ctx, srv)
 			if err != nil {
 				failSrvs = append(failSrvs, srv)
 				history.ProcessLevel.Append(errorLevel)
 				msg := fmt.Sprintf("!consul check error occurred! failed to get container, srv: %s, err: %v", srv, err)
-				_, _, _ = ccu.slackChatAgency.SendMessage("broken_heart", msg, _uuid)
+				history.AddAlarmResults(ccu.notifyAgency.Notify(ctx, ccu.alarm(history, unhealthyLevel, "broken_heart", msg)))
 				history.SetError(errors.Wrap(err, "failed to get container"))
 				continue
 			}
 
-			if err := ccu.dockerAgency.RemoveContainer(container.ID(), types.ContainerRemoveOptions{Force: true}); err != nil {
+			if err := ccu.dockerAgency.RemoveContainer(ctx, container.ID(), types.ContainerRemoveOptions{Force: true}); err != nil {
 				failSrvs = append(failSrvs, srv)
 				history.ProcessLevel.Append(errorLevel)
 				msg := fmt.Sprintf("!consul check error occurred! failed to restart container, id: %s, err: %v", container.ID(), err)
-				_, _, _ = ccu.slackChatAgency.SendMessage("broken_heart", msg, _uuid)
+				history.AddAlarmResults(ccu.notifyAgency.Notify(ctx, ccu.alarm(history, unhealthyLevel, "broken_heart", msg)))
 				history.SetError(errors.Wrap(err, "failed to restart container"))
 			} else {
 				successSrvs = append(successSrvs, srv)
@@ -276,5 +383,148 @@ func (ccu *consulCheckUsecase) checkConsul(ctx context.Context) (history *domain
 func (ccu *consulCheckUsecase) setStatus(status consulCheckStatus) {
 	ccu.mutex.Lock()
 	defer ccu.mutex.Unlock()
+	if ccu.status != status {
+		ccu.record.statusChanged(status == consulStatusHealthy)
+	}
 	ccu.status = status
 }
+
+// setLastHistory set check history created in latest check process to record using mutex Lock & Unlock
+func (ccu *consulCheckUsecase) setLastHistory(history domain.CheckHistory) {
+	ccu.mutex.Lock()
+	defer ccu.mutex.Unlock()
+	ccu.record.lastHistory = history
+}
+
+// Status return current status of consul check with record field using mutex Lock & Unlock
+// Implement Status method of domain.CheckStatusReporter interface
+func (ccu *consulCheckUsecase) Status() domain.CheckStatus {
+	ccu.mutex.Lock()
+	defer ccu.mutex.Unlock()
+	return ccu.record.status(ccu.status.String())
+}
+
+// Acknowledge acknowledge unhealthy status of consul check by operator & handle operation history
+// Implement Acknowledge method of domain.CheckController interface
+func (ccu *consulCheckUsecase) Acknowledge(ctx context.Context, operator, reason string) (err error) {
+	ccu.mutex.Lock()
+	if ccu.status != consulStatusUnhealthy {
+		ccu.mutex.Unlock()
+		return domain.ErrCheckNotUnhealthy
+	}
+	ccu.record.acknowledgedBy = operator
+	ccu.mutex.Unlock()
+
+	history := ccu.newOperationHistory(acknowledgedLevel, operator, reason)
+	history.Message = "unhealthy consul check status is acknowledged by operator"
+	msg := fmt.Sprintf("!consul check acknowledged! unhealthy status is acknowledged by %s, reason: %s", operator, reason)
+	history.AddAlarmResults(ccu.notifyAgency.Notify(ctx, ccu.alarm(history, acknowledgedLevel, "eyes", msg)))
+
+	return ccu.handleHistory(history, 0)
+}
+
+// Reset reset status of consul check to healthy by operator & handle operation history
+// Implement Reset method of domain.CheckController interface
+func (ccu *consulCheckUsecase) Reset(ctx context.Context, operator, reason string) (err error) {
+	ccu.mutex.Lock()
+	if ccu.status == consulStatusRecovering {
+		ccu.mutex.Unlock()
+		return domain.ErrCheckRecovering
+	}
+	before := ccu.status
+	if ccu.status != consulStatusHealthy {
+		ccu.record.statusChanged(true)
+	}
+	ccu.status = consulStatusHealthy
+	ccu.mutex.Unlock()
+
+	history := ccu.newOperationHistory(resetLevel, operator, reason)
+	history.Message = fmt.Sprintf("consul check status is reset from %s to healthy by operator", before.String())
+	msg := fmt.Sprintf("!consul check reset! status is reset from %s to healthy by %s, reason: %s", before.String(), operator, reason)
+	history.AddAlarmResults(ccu.notifyAgency.Notify(ctx, ccu.alarm(history, resetLevel, "wrench", msg)))
+
+	return ccu.handleHistory(history, 0)
+}
+
+// Rerun reset status of consul check by operator & run consul check process immediately
+// Implement Rerun method of domain.CheckController interface
+func (ccu *consulCheckUsecase) Rerun(ctx context.Context, operator, reason string) (err error) {
+	if err = ccu.Reset(ctx, operator, reason); err != nil {
+		return
+	}
+	return ccu.CheckConsul(ctx)
+}
+
+// newOperationHistory return new consul check history about operation by operator with process level
+func (ccu *consulCheckUsecase) newOperationHistory(level, operator, reason string) (history *domain.ConsulCheckHistory) {
+	history = new(domain.ConsulCheckHistory)
+	history.FillPrivateComponent(ccu.clock.Now())
+	history.SetType(ccu.record._type)
+	history.UUID = uuid.New().String()
+	history.InstancesPerService = map[string][]string{}
+	history.ProcessLevel.Set(level)
+	history.SetOperation(operator, reason)
+	return
+}
+
+// alarmFields return fields of alarm about consul check with registered instances, ping timeout & deregistered instances
+func (ccu *consulCheckUsecase) alarmFields(history *domain.ConsulCheckHistory) (fields map[string]string) {
+	fields = map[string]string{
+		domain.AlarmFieldThreshold: "ping timeout " + ccu.thresholds.ConnCheckPingTimeOut.String(),
+		domain.AlarmFieldTarget:    strings.Join(history.DeregisteredInstances, ", "),
+	}
+	if len(history.InstancesPerService) > 0 {
+		var instances int
+		for _, ids := range history.InstancesPerService {
+			instances += len(ids)
+		}
+		fields[domain.AlarmFieldMeasured] = fmt.Sprintf("%d instances of %d services", instances, len(history.InstancesPerService))
+	}
+	return
+}
+
+// alarm return alarm about consul check with fields from history, incident of record is updated using mutex Lock & Unlock
+func (ccu *consulCheckUsecase) alarm(history *domain.ConsulCheckHistory, level, emoji, text string) domain.Alarm {
+	ccu.mutex.Lock()
+	defer ccu.mutex.Unlock()
+	return ccu.record.alarm(level, emoji, text, history.UUID, ccu.alarmFields(history))
+}
+
+// reminder return reminder alarm about unhealthy consul check by escalation policy, record is updated using mutex Lock & Unlock
+func (ccu *consulCheckUsecase) reminder(history *domain.ConsulCheckHistory) (domain.Alarm, bool) {
+	ccu.mutex.Lock()
+	defer ccu.mutex.Unlock()
+	return ccu.record.reminder(ccu.myCfg.EscalationPolicy(), history.UUID, ccu.alarmFields(history))
+}
+
+// takeThresholds take thresholds snapshot of consul check from config & set to thresholds field using mutex Lock & Unlock
+// every threshold compared in check process & shown in alarm is read from this snapshot, though config is reloaded meanwhile
+func (ccu *consulCheckUsecase) takeThresholds() domain.ConsulCheckThresholds {
+	ccu.mutex.Lock()
+	defer ccu.mutex.Unlock()
+	ccu.thresholds = ccu.myCfg.ConsulCheckThresholds()
+	return ccu.thresholds
+}
+
+// simulate mark remediation of consul check as simulated in record using mutex Lock & Unlock, and return if it should be alarmed
+func (ccu *consulCheckUsecase) simulate() bool {
+	ccu.mutex.Lock()
+	defer ccu.mutex.Unlock()
+	return ccu.record.simulate()
+}
+
+// trackIncident track consul check history into incident of record using mutex Lock & Unlock, and store updated incident
+func (ccu *consulCheckUsecase) trackIncident(history *domain.ConsulCheckHistory) error {
+	ccu.mutex.Lock()
+	incident, ok := ccu.record.track(history)
+	ccu.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+
+	history.SetIncident(incident.ID)
+	if b, err := ccu.incidentRepo.Store(incident); err != nil {
+		return errors.Wrapf(err, "failed to store incident of consul check, response: %s", string(b))
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,6 +25,20 @@ const (
 	elasticsearchStatusUnhealthy                                  // represent elasticsearch check status is unhealthy
 )
 
+// String method return domain.CheckState const value matched with elasticsearch check status
+func (s elasticsearchCheckStatus) String() string {
+	switch s {
+	case elasticsearchStatusHealthy:
+		return domain.CheckStateHealthy
+	case elasticsearchStatusRecovering:
+		return domain.CheckStateRecovering
+	case elasticsearchStatusUnhealthy:
+		return domain.CheckStateUnhealthy
+	default:
+		return "UNKNOWN"
+	}
+}
+
 // elasticsearchCheckUsecase implement ElasticsearchCheckUsecase interface in domain and used in delivery layer
 type elasticsearchCheckUsecase struct {
 	// myCfg is used for getting elasticsearch check usecase config
@@ -32,8 +47,20 @@ type elasticsearchCheckUsecase struct {
 	// historyRepo is used for store elasticsearch check history and injected from outside
 	historyRepo domain.ElasticsearchCheckHistoryRepository
 
-	// slackChat is used for agent slack API about chatting
-	slackChatAgency slackChatAgency
+	// incidentRepo is used for store incident of elasticsearch check and injected from outside
+	incidentRepo domain.IncidentRepository
+
+	// historyObserver is used for observing check history after check process (ex, recording metrics)
+	historyObserver historyObserver
+
+	// maintenanceAgency is used as agency about maintenance window pausing check or suppressing remediation
+	maintenanceAgency maintenanceAgency
+
+	// notifyAgency is used as agency about notifier backends sending alarm (Ex, slack, webhook, email)
+	notifyAgency notifyAgency
+
+	// clock is used for getting current time in configured timezone, which is set to timestamp of check history
+	clock clock
 
 	// elasticsearchAgency is used as agency about elasticsearch API
 	elasticsearchAgency elasticsearchAgency
@@ -41,6 +68,15 @@ type elasticsearchCheckUsecase struct {
 	// status represent current process status of elasticsearch health check
 	status elasticsearchCheckStatus
 
+	// executor is used for running check process with single flight policy & run timeout
+	executor checkExecutor
+
+	// thresholds represent thresholds snapshot taken at the start of latest check process, which is shown in alarm
+	thresholds domain.ElasticsearchCheckThresholds
+
+	// record represent status transition & latest check process of elasticsearch health check
+	record checkRecord
+
 	// mutex help to prevent race condition when set status field value
 	mutex sync.Mutex
 }
@@ -50,20 +86,14 @@ type elasticsearchCheckUsecaseConfig interface {
 	// get common config method from embedding serviceCheckUsecaseComponentConfig
 	serviceCheckUsecaseComponentConfig
 
-	// MaximumShardsNumber method returns int represent maximum shards number
-	MaximumShardsNumber() int
-
-	// JaegerIndexPattern method returns string represent jaeger index pattern
-	JaegerIndexPattern() string
-
-	// JaegerIndexMinLifeCycle JaegerIndexLifeCycle method returns duration represent jaeger index life cycle
-	JaegerIndexMinLifeCycle() time.Duration
+	// ElasticsearchCheckThresholds method returns thresholds snapshot of elasticsearch check, which is read at once in every check process
+	ElasticsearchCheckThresholds() domain.ElasticsearchCheckThresholds
 }
 
 // elasticsearchAgency is interface that agent elasticsearch with HTTP API
 type elasticsearchAgency interface {
 	// GetClusterHealth return interface have various get method about cluster health inform
-	GetClusterHealth() (cluster interface {
+	GetClusterHealth(ctx context.Context) (cluster interface {
 		ActivePrimaryShards() int     // get active primary shards number of cluster
 		ActiveShards() int            // get active shards number of cluster
 		UnassignedShards() int        // get unassigned shards number of cluster
@@ -71,45 +101,70 @@ type elasticsearchAgency interface {
 	}, err error)
 
 	// GetIndicesWithPatterns return indices list with regexp pattern
-	GetIndicesWithPatterns(patterns []string) (indices interface {
+	GetIndicesWithPatterns(ctx context.Context, patterns []string) (indices interface {
 		SetMinLifeCycle(cycle time.Duration) // set min life cycle of index of indices
 		IndexNames() []string                // get index name list of indices
 	}, err error)
 
 	// DeleteIndices method delete indices in list received from parameter
-	DeleteIndices(indices []string) (err error)
+	DeleteIndices(ctx context.Context, indices []string) (err error)
 }
 
 // NewElasticsearchCheckUsecase function return elasticsearchCheckUseCase ptr instance after initializing
 func NewElasticsearchCheckUsecase(
 	cfg elasticsearchCheckUsecaseConfig,
 	chr domain.ElasticsearchCheckHistoryRepository,
-	sca slackChatAgency,
+	ir domain.IncidentRepository,
+	ho historyObserver,
+	ma maintenanceAgency,
+	na notifyAgency,
+	c clock,
+	ce checkExecutor,
 	ea elasticsearchAgency,
 ) domain.ElasticsearchCheckUseCase {
 	return &elasticsearchCheckUsecase{
 		// initialize field with parameter received from caller
 		myCfg:               cfg,
 		historyRepo:         chr,
-		slackChatAgency:     sca,
+		incidentRepo:        ir,
+		historyObserver:     ho,
+		maintenanceAgency:   ma,
+		notifyAgency:        na,
+		clock:               c,
+		executor:            ce,
 		elasticsearchAgency: ea,
 
 		// initialize field with default value
-		status: elasticsearchStatusHealthy,
-		mutex:  sync.Mutex{},
+		status:     elasticsearchStatusHealthy,
+		thresholds: cfg.ElasticsearchCheckThresholds(),
+		record:     newCheckRecord("srvcheck", "ElasticsearchCheck", cfg.CheckType(), c),
+		mutex:      sync.Mutex{},
 	}
 }
 
-// CheckElasticsearch check elasticsearch health with checkElasticsearch method & store check history in repository
+// CheckElasticsearch check elasticsearch health with checkElasticsearch method in executor & store check history in repository
 // Implement CheckElasticsearch method of ElasticsearchCheckUseCase interface
-func (ecu *elasticsearchCheckUsecase) CheckElasticsearch(ctx context.Context) (err error) {
-	history := ecu.checkElasticsearch(ctx)
+func (ecu *elasticsearchCheckUsecase) CheckElasticsearch(ctx context.Context) error {
+	return ecu.executor.Execute(ctx, func(ctx context.Context) domain.CheckHistory {
+		return ecu.checkElasticsearch(ctx)
+	}, func(level, message string) domain.CheckHistory {
+		history := ecu.newOperationHistory(level, "", "")
+		history.Message = message
+		return history
+	}, ecu.handleHistory)
+}
+
+// handleHistory observe elasticsearch check history with duration, set to record & store in repository
+func (ecu *elasticsearchCheckUsecase) handleHistory(history domain.CheckHistory, duration time.Duration) error {
+	incidentErr := ecu.trackIncident(history.(*domain.ElasticsearchCheckHistory))
+	ecu.historyObserver.ObserveHistory(history, duration)
+	ecu.setLastHistory(history)
 
-	if b, err := ecu.historyRepo.Store(history); err != nil {
+	if b, err := ecu.historyRepo.Store(history.(*domain.ElasticsearchCheckHistory)); err != nil {
 		return errors.Wrapf(err, "failed to store elasticsearch check history, response: %s", string(b))
 	}
 
-	return
+	return incidentErr
 }
 
 // method processed with below logic about elasticsearch health check according to current check status
@@ -121,17 +176,26 @@ func (ecu *elasticsearchCheckUsecase) CheckElasticsearch(ctx context.Context) (e
 // 2 : 관리자가 직접 확인해야함 (상태 확인 수행 X)
 // 2 -> 0 : 관리자 직접 상태 회복 완료 (상태 회복 알림 발행)
 func (ecu *elasticsearchCheckUsecase) checkElasticsearch(ctx context.Context) (history *domain.ElasticsearchCheckHistory) {
+	thresholds := ecu.takeThresholds()
+
 	_uuid := uuid.New().String()
 	history = new(domain.ElasticsearchCheckHistory)
-	history.FillPrivateComponent()
+	history.FillPrivateComponent(ecu.clock.Now())
+	history.SetType(ecu.record._type)
 	history.UUID = _uuid
 
-	cluster, err := ecu.elasticsearchAgency.GetClusterHealth()
+	if paused, reason := ecu.maintenanceAgency.IsPaused(ecu.record.domain, ecu.record._type); paused {
+		history.ProcessLevel.Set(pausedLevel)
+		history.Message = "elasticsearch check is paused by maintenance window, reason: " + reason
+		return
+	}
+
+	cluster, err := ecu.elasticsearchAgency.GetClusterHealth(ctx)
 	if err != nil {
 		history.ProcessLevel.Set(errorLevel)
 		history.SetError(errors.Wrap(err, "failed to get cluster health"))
 		msg := "!elasticsearch check error occurred! unable to get cluster health"
-		history.SetAlarmResult(ecu.slackChatAgency.SendMessage("x", msg, _uuid))
+		history.AddAlarmResults(ecu.notifyAgency.Notify(ctx, ecu.alarm(history, errorLevel, "x", msg)))
 		return
 	}
 	history.SetClusterHealth(cluster)
@@ -145,41 +209,56 @@ func (ecu *elasticsearchCheckUsecase) checkElasticsearch(ctx context.Context) (h
 		history.Message = "recovering elasticsearch health is already on process"
 		return
 	case elasticsearchStatusUnhealthy:
-		if totalShards.isLessThan(ecu.myCfg.MaximumShardsNumber()) {
+		if totalShards.isLessThan(thresholds.MaximumShardsNumber) {
 			ecu.setStatus(elasticsearchStatusHealthy)
 			history.ProcessLevel.Set(recoveredLevel)
 			history.Message = "elasticsearch check is recovered to be healthy"
 			msg := fmt.Sprintf("!elasticsearch check recovered to health! total shards - %d", totalShards.V)
-			_, _, _ = ecu.slackChatAgency.SendMessage("heart", msg, _uuid)
+			history.AddAlarmResults(ecu.notifyAgency.Notify(ctx, ecu.alarm(history, recoveredLevel, "heart", msg)))
 		} else {
 			history.ProcessLevel.Set(unhealthyLevel)
 			history.Message = "elasticsearch check is unhealthy now"
+			if alarm, ok := ecu.reminder(history); ok {
+				history.ProcessLevel.Append(escalatedLevel)
+				history.AddAlarmResults(ecu.notifyAgency.Notify(ctx, alarm))
+			}
 		}
 		return
 	}
 
-	if totalShards.isMoreThan(ecu.myCfg.MaximumShardsNumber()) {
+	if totalShards.isMoreThan(thresholds.MaximumShardsNumber) {
+		if suppressed, reason := ecu.maintenanceAgency.IsRemediationSuppressed(ecu.record.domain, ecu.record._type); suppressed {
+			history.ProcessLevel.Set(suppressedLevel)
+			history.Message = "elasticsearch weak is detected, but remediation is suppressed by maintenance window, reason: " + reason
+			return
+		}
+
+		if isDryRun(ctx, ecu.myCfg) {
+			ecu.simulateDeletion(ctx, history, thresholds)
+			return
+		}
+
 		ecu.setStatus(elasticsearchStatusRecovering)
 		history.ProcessLevel.Set(weakDetectedLevel)
 		msg := "!elasticsearch check weak detected! start to delete jaeger index"
-		history.SetAlarmResult(ecu.slackChatAgency.SendMessage("pill", msg, _uuid))
+		history.AddAlarmResults(ecu.notifyAgency.Notify(ctx, ecu.alarm(history, weakDetectedLevel, "pill", msg)))
 
-		indices, err := ecu.elasticsearchAgency.GetIndicesWithPatterns([]string{ecu.myCfg.JaegerIndexPattern()})
+		indices, err := ecu.elasticsearchAgency.GetIndicesWithPatterns(ctx, []string{thresholds.JaegerIndexPattern})
 		if err != nil {
 			ecu.setStatus(elasticsearchStatusUnhealthy)
 			history.ProcessLevel.Append(errorLevel)
 			msg := "!elasticsearch check error occurred! failed to get indices, please check for yourself"
-			_, _, _ = ecu.slackChatAgency.SendMessage("broken_heart", msg, _uuid)
+			history.AddAlarmResults(ecu.notifyAgency.Notify(ctx, ecu.alarm(history, unhealthyLevel, "broken_heart", msg)))
 			history.SetError(errors.Wrap(err, "failed to get indices with pattern"))
 			return
 		}
-		indices.SetMinLifeCycle(ecu.myCfg.JaegerIndexMinLifeCycle())
+		indices.SetMinLifeCycle(thresholds.JaegerIndexMinLifeCycle)
 
-		if err := ecu.elasticsearchAgency.DeleteIndices(indices.IndexNames()); err != nil {
+		if err := ecu.elasticsearchAgency.DeleteIndices(ctx, indices.IndexNames()); err != nil {
 			ecu.setStatus(elasticsearchStatusUnhealthy)
 			history.ProcessLevel.Append(errorLevel)
 			msg := "!elasticsearch check error occurred! failed to delete indices, please check for yourself"
-			_, _, _ = ecu.slackChatAgency.SendMessage("anger", msg, _uuid)
+			history.AddAlarmResults(ecu.notifyAgency.Notify(ctx, ecu.alarm(history, errorLevel, "anger", msg)))
 			history.SetError(errors.Wrap(err, "failed to delete indices"))
 			return
 		} else {
@@ -188,26 +267,26 @@ func (ecu *elasticsearchCheckUsecase) checkElasticsearch(ctx context.Context) (h
 			history.Message = "pruned docker system as current disk capacity is less than the minimum"
 		}
 
-		againCluster, err := ecu.elasticsearchAgency.GetClusterHealth()
+		againCluster, err := ecu.elasticsearchAgency.GetClusterHealth(ctx)
 		if err != nil {
 			ecu.setStatus(elasticsearchStatusUnhealthy)
 			history.ProcessLevel.Append(errorLevel)
 			msg := "!elasticsearch check error occurred! failed to again get cluster health, please check for yourself"
-			_, _, _ = ecu.slackChatAgency.SendMessage("broken_heart", msg, _uuid)
+			history.AddAlarmResults(ecu.notifyAgency.Notify(ctx, ecu.alarm(history, unhealthyLevel, "broken_heart", msg)))
 			history.SetError(errors.Wrap(err, "failed to again get cluster health again"))
 			return
 		}
 		history.SetClusterHealth(againCluster)
 		var againTotalShards = intComparator{V: againCluster.ActiveShards() + againCluster.UnassignedShards()}
 
-		if againTotalShards.isLessThan(ecu.myCfg.MaximumShardsNumber()) {
+		if againTotalShards.isLessThan(thresholds.MaximumShardsNumber) {
 			ecu.setStatus(elasticsearchStatusHealthy)
 			msg := fmt.Sprintf("!elasticsearch check is recovered! total shards - %d", againTotalShards.V)
-			_, _, _ = ecu.slackChatAgency.SendMessage("heart", msg, _uuid)
+			history.AddAlarmResults(ecu.notifyAgency.Notify(ctx, ecu.alarm(history, recoveredLevel, "heart", msg)))
 		} else {
 			ecu.setStatus(elasticsearchStatusUnhealthy)
 			msg := "!elasticsearch check has deteriorated! please check for yourself"
-			_, _, _ = ecu.slackChatAgency.SendMessage("broken_heart", msg, _uuid)
+			history.AddAlarmResults(ecu.notifyAgency.Notify(ctx, ecu.alarm(history, unhealthyLevel, "broken_heart", msg)))
 		}
 	} else {
 		history.ProcessLevel.Set(healthyLevel)
@@ -217,9 +296,169 @@ func (ecu *elasticsearchCheckUsecase) checkElasticsearch(ctx context.Context) (h
 	return
 }
 
+// simulateDeletion simulate deleting jaeger indices in dry-run mode, without changing status of elasticsearch check
+// simulation is recorded in history of every check run, but alarm about that is sent once until elasticsearch check is healthy again
+func (ecu *elasticsearchCheckUsecase) simulateDeletion(ctx context.Context, history *domain.ElasticsearchCheckHistory, thresholds domain.ElasticsearchCheckThresholds) {
+	history.SetSimulated()
+	history.ProcessLevel.Set(simulatedLevel)
+
+	indices, err := ecu.elasticsearchAgency.GetIndicesWithPatterns(ctx, []string{thresholds.JaegerIndexPattern})
+	if err != nil {
+		history.ProcessLevel.Append(errorLevel)
+		history.SetError(errors.Wrap(err, "failed to get indices with pattern"))
+		return
+	}
+	indices.SetMinLifeCycle(thresholds.JaegerIndexMinLifeCycle)
+
+	history.DeletedJaegerIndices = indices.IndexNames()
+	history.Message = fmt.Sprintf("deleting jaeger indices is simulated in dry-run mode, indices: %v", indices.IndexNames())
+	if ecu.simulate() {
+		msg := fmt.Sprintf("!elasticsearch check dry-run! %d jaeger indices would be deleted, but not executed", len(indices.IndexNames()))
+		history.AddAlarmResults(ecu.notifyAgency.Notify(ctx, ecu.alarm(history, simulatedLevel, "test_tube", msg)))
+	}
+}
+
 // setStatus set status field value using mutex Lock & Unlock
 func (ecu *elasticsearchCheckUsecase) setStatus(status elasticsearchCheckStatus) {
 	ecu.mutex.Lock()
 	defer ecu.mutex.Unlock()
+	if ecu.status != status {
+		ecu.record.statusChanged(status == elasticsearchStatusHealthy)
+	}
 	ecu.status = status
 }
+
+// setLastHistory set check history created in latest check process to record using mutex Lock & Unlock
+func (ecu *elasticsearchCheckUsecase) setLastHistory(history domain.CheckHistory) {
+	ecu.mutex.Lock()
+	defer ecu.mutex.Unlock()
+	ecu.record.lastHistory = history
+}
+
+// Status return current status of elasticsearch check with record field using mutex Lock & Unlock
+// Implement Status method of domain.CheckStatusReporter interface
+func (ecu *elasticsearchCheckUsecase) Status() domain.CheckStatus {
+	ecu.mutex.Lock()
+	defer ecu.mutex.Unlock()
+	return ecu.record.status(ecu.status.String())
+}
+
+// Acknowledge acknowledge unhealthy status of elasticsearch check by operator & handle operation history
+// Implement Acknowledge method of domain.CheckController interface
+func (ecu *elasticsearchCheckUsecase) Acknowledge(ctx context.Context, operator, reason string) (err error) {
+	ecu.mutex.Lock()
+	if ecu.status != elasticsearchStatusUnhealthy {
+		ecu.mutex.Unlock()
+		return domain.ErrCheckNotUnhealthy
+	}
+	ecu.record.acknowledgedBy = operator
+	ecu.mutex.Unlock()
+
+	history := ecu.newOperationHistory(acknowledgedLevel, operator, reason)
+	history.Message = "unhealthy elasticsearch check status is acknowledged by operator"
+	msg := fmt.Sprintf("!elasticsearch check acknowledged! unhealthy status is acknowledged by %s, reason: %s", operator, reason)
+	history.AddAlarmResults(ecu.notifyAgency.Notify(ctx, ecu.alarm(history, acknowledgedLevel, "eyes", msg)))
+
+	return ecu.handleHistory(history, 0)
+}
+
+// Reset reset status of elasticsearch check to healthy by operator & handle operation history
+// Implement Reset method of domain.CheckController interface
+func (ecu *elasticsearchCheckUsecase) Reset(ctx context.Context, operator, reason string) (err error) {
+	ecu.mutex.Lock()
+	if ecu.status == elasticsearchStatusRecovering {
+		ecu.mutex.Unlock()
+		return domain.ErrCheckRecovering
+	}
+	before := ecu.status
+	if ecu.status != elasticsearchStatusHealthy {
+		ecu.record.statusChanged(true)
+	}
+	ecu.status = elasticsearchStatusHealthy
+	ecu.mutex.Unlock()
+
+	history := ecu.newOperationHistory(resetLevel, operator, reason)
+	history.Message = fmt.Sprintf("elasticsearch check status is reset from %s to healthy by operator", before.String())
+	msg := fmt.Sprintf("!elasticsearch check reset! status is reset from %s to healthy by %s, reason: %s", before.String(), operator, reason)
+	history.AddAlarmResults(ecu.notifyAgency.Notify(ctx, ecu.alarm(history, resetLevel, "wrench", msg)))
+
+	return ecu.handleHistory(history, 0)
+}
+
+// Rerun reset status of elasticsearch check by operator & run elasticsearch check process immediately
+// Implement Rerun method of domain.CheckController interface
+func (ecu *elasticsearchCheckUsecase) Rerun(ctx context.Context, operator, reason string) (err error) {
+	if err = ecu.Reset(ctx, operator, reason); err != nil {
+		return
+	}
+	return ecu.CheckElasticsearch(ctx)
+}
+
+// newOperationHistory return new elasticsearch check history about operation by operator with process level
+func (ecu *elasticsearchCheckUsecase) newOperationHistory(level, operator, reason string) (history *domain.ElasticsearchCheckHistory) {
+	history = new(domain.ElasticsearchCheckHistory)
+	history.FillPrivateComponent(ecu.clock.Now())
+	history.SetType(ecu.record._type)
+	history.UUID = uuid.New().String()
+	history.ProcessLevel.Set(level)
+	history.SetOperation(operator, reason)
+	return
+}
+
+// alarmFields return fields of alarm about elasticsearch check with total shards number, maximum & deleted jaeger indices
+func (ecu *elasticsearchCheckUsecase) alarmFields(history *domain.ElasticsearchCheckHistory) (fields map[string]string) {
+	fields = map[string]string{
+		domain.AlarmFieldThreshold: fmt.Sprintf("maximum %d shards", ecu.thresholds.MaximumShardsNumber),
+		domain.AlarmFieldTarget:    strings.Join(history.DeletedJaegerIndices, ", "),
+	}
+	if shards := history.ActiveShards + history.UnassignedShards; shards > 0 {
+		fields[domain.AlarmFieldMeasured] = fmt.Sprintf("%d shards", shards)
+	}
+	return
+}
+
+// alarm return alarm about elasticsearch check with fields from history, incident of record is updated using mutex Lock & Unlock
+func (ecu *elasticsearchCheckUsecase) alarm(history *domain.ElasticsearchCheckHistory, level, emoji, text string) domain.Alarm {
+	ecu.mutex.Lock()
+	defer ecu.mutex.Unlock()
+	return ecu.record.alarm(level, emoji, text, history.UUID, ecu.alarmFields(history))
+}
+
+// reminder return reminder alarm about unhealthy elasticsearch check by escalation policy, record is updated using mutex Lock & Unlock
+func (ecu *elasticsearchCheckUsecase) reminder(history *domain.ElasticsearchCheckHistory) (domain.Alarm, bool) {
+	ecu.mutex.Lock()
+	defer ecu.mutex.Unlock()
+	return ecu.record.reminder(ecu.myCfg.EscalationPolicy(), history.UUID, ecu.alarmFields(history))
+}
+
+// takeThresholds take thresholds snapshot of elasticsearch check from config & set to thresholds field using mutex Lock & Unlock
+// every threshold compared in check process & shown in alarm is read from this snapshot, though config is reloaded meanwhile
+func (ecu *elasticsearchCheckUsecase) takeThresholds() domain.ElasticsearchCheckThresholds {
+	ecu.mutex.Lock()
+	defer ecu.mutex.Unlock()
+	ecu.thresholds = ecu.myCfg.ElasticsearchCheckThresholds()
+	return ecu.thresholds
+}
+
+// simulate mark remediation of elasticsearch check as simulated in record using mutex Lock & Unlock, and return if it should be alarmed
+func (ecu *elasticsearchCheckUsecase) simulate() bool {
+	ecu.mutex.Lock()
+	defer ecu.mutex.Unlock()
+	return ecu.record.simulate()
+}
+
+// trackIncident track elasticsearch check history into incident of record using mutex Lock & Unlock, and store updated incident
+func (ecu *elasticsearchCheckUsecase) trackIncident(history *domain.ElasticsearchCheckHistory) error {
+	ecu.mutex.Lock()
+	incident, ok := ecu.record.track(history)
+	ecu.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+
+	history.SetIncident(incident.ID)
+	if b, err := ecu.incidentRepo.Store(incident); err != nil {
+		return errors.Wrapf(err, "failed to store incident of elasticsearch check, response: %s", string(b))
+	}
+	return nil
+}
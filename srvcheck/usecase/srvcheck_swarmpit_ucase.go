@@ -9,9 +9,9 @@ import (
 	"fmt"
 	"github.com/docker/docker/api/types"
 	"github.com/google/uuid"
-	"github.com/inhies/go-bytesize"
 	"github.com/pkg/errors"
 	"sync"
+	"time"
 
 	"github.com/DMS-SMS/v1-health-check/domain"
 )
@@ -25,6 +25,20 @@ const (
 	swarmpitStatusUnhealthy                             // represent swarmpit check status is unhealthy
 )
 
+// String method return domain.CheckState const value matched with swarmpit check status
+func (s swarmpitCheckStatus) String() string {
+	switch s {
+	case swarmpitStatusHealthy:
+		return domain.CheckStateHealthy
+	case swarmpitStatusRecovering:
+		return domain.CheckStateRecovering
+	case swarmpitStatusUnhealthy:
+		return domain.CheckStateUnhealthy
+	default:
+		return "UNKNOWN"
+	}
+}
+
 // swarmpitCheckUsecase implement SwarmpitCheckUsecase interface in domain and used in delivery layer
 type swarmpitCheckUsecase struct {
 	// myCfg is used for getting swarmpit check usecase config
@@ -33,8 +47,20 @@ type swarmpitCheckUsecase struct {
 	// historyRepo is used for store swarmpit check history and injected from outside
 	historyRepo domain.SwarmpitCheckHistoryRepository
 
-	// slackChat is used for agent slack API about chatting
-	slackChatAgency slackChatAgency
+	// incidentRepo is used for store incident of swarmpit check and injected from outside
+	incidentRepo domain.IncidentRepository
+
+	// historyObserver is used for observing check history after check process (ex, recording metrics)
+	historyObserver historyObserver
+
+	// maintenanceAgency is used as agency about maintenance window pausing check or suppressing remediation
+	maintenanceAgency maintenanceAgency
+
+	// notifyAgency is used as agency about notifier backends sending alarm (Ex, slack, webhook, email)
+	notifyAgency notifyAgency
+
+	// clock is used for getting current time in configured timezone, which is set to timestamp of check history
+	clock clock
 
 	// dockerAgency is used as agency about docker engine API
 	dockerAgency dockerAgency
@@ -42,6 +68,15 @@ type swarmpitCheckUsecase struct {
 	// status represent current process status of swarmpit health check
 	status swarmpitCheckStatus
 
+	// executor is used for running check process with single flight policy & run timeout
+	executor checkExecutor
+
+	// thresholds represent thresholds snapshot taken at the start of latest check process, which is shown in alarm
+	thresholds domain.SwarmpitCheckThresholds
+
+	// record represent status transition & latest check process of swarmpit health check
+	record checkRecord
+
 	// mutex help to prevent race condition when set status field value
 	mutex sync.Mutex
 }
@@ -51,43 +86,65 @@ type swarmpitCheckUsecaseConfig interface {
 	// get common config method from embedding serviceCheckUsecaseComponentConfig
 	serviceCheckUsecaseComponentConfig
 
-	// SwarmpitAppServiceName method returns string represent swarmpit app service name
-	SwarmpitAppServiceName() string
-
-	// SwarmpitAppMaxMemoryUsage method returns bytesize represent swarmpit app maximum memory usage
-	SwarmpitAppMaxMemoryUsage() bytesize.ByteSize
+	// SwarmpitCheckThresholds method returns thresholds snapshot of swarmpit check, which is read at once in every check process
+	SwarmpitCheckThresholds() domain.SwarmpitCheckThresholds
 }
 
 // NewSwarmpitCheckUsecase function return swarmpitCheckUsecase ptr instance after initializing
 func NewSwarmpitCheckUsecase(
 	cfg swarmpitCheckUsecaseConfig,
 	shr domain.SwarmpitCheckHistoryRepository,
-	sca slackChatAgency,
+	ir domain.IncidentRepository,
+	ho historyObserver,
+	ma maintenanceAgency,
+	na notifyAgency,
+	c clock,
+	ce checkExecutor,
 	da dockerAgency,
 ) domain.SwarmpitCheckUseCase {
 	return &swarmpitCheckUsecase{
 		// initialize field with parameter received from caller
-		myCfg:           cfg,
-		historyRepo:     shr,
-		slackChatAgency: sca,
-		dockerAgency:    da,
+		myCfg:             cfg,
+		historyRepo:       shr,
+		incidentRepo:      ir,
+		historyObserver:   ho,
+		maintenanceAgency: ma,
+		notifyAgency:      na,
+		clock:             c,
+		executor:          ce,
+		dockerAgency:      da,
 
 		// initialize field with default value
-		status: swarmpitStatusHealthy,
-		mutex:  sync.Mutex{},
+		status:     swarmpitStatusHealthy,
+		thresholds: cfg.SwarmpitCheckThresholds(),
+		record:     newCheckRecord("srvcheck", "SwarmpitCheck", cfg.CheckType(), c),
+		mutex:      sync.Mutex{},
 	}
 }
 
-// CheckSwarmpit check swarmpit health with checkSwarmpit method & store check history in repository
+// CheckSwarmpit check swarmpit health with checkSwarmpit method in executor & store check history in repository
 // Implement CheckSwarmpit method of SwarmpitCheckUseCase interface
-func (scu *swarmpitCheckUsecase) CheckSwarmpit(ctx context.Context) (err error) {
-	history := scu.checkSwarmpit(ctx)
+func (scu *swarmpitCheckUsecase) CheckSwarmpit(ctx context.Context) error {
+	return scu.executor.Execute(ctx, func(ctx context.Context) domain.CheckHistory {
+		return scu.checkSwarmpit(ctx)
+	}, func(level, message string) domain.CheckHistory {
+		history := scu.newOperationHistory(level, "", "")
+		history.Message = message
+		return history
+	}, scu.handleHistory)
+}
+
+// handleHistory observe swarmpit check history with duration, set to record & store in repository
+func (scu *swarmpitCheckUsecase) handleHistory(history domain.CheckHistory, duration time.Duration) error {
+	incidentErr := scu.trackIncident(history.(*domain.SwarmpitCheckHistory))
+	scu.historyObserver.ObserveHistory(history, duration)
+	scu.setLastHistory(history)
 
-	if b, err := scu.historyRepo.Store(history); err != nil {
+	if b, err := scu.historyRepo.Store(history.(*domain.SwarmpitCheckHistory)); err != nil {
 		return errors.Wrapf(err, "failed to store swarmpit check history, response: %s", string(b))
 	}
 
-	return
+	return incidentErr
 }
 
 // method processed with below logic about swarmpit health check according to current check status
@@ -99,17 +156,26 @@ func (scu *swarmpitCheckUsecase) CheckSwarmpit(ctx context.Context) (err error)
 // 2 : 관리자가 직접 확인해야함 (상태 확인 수행 X)
 // 2 -> 0 : 관리자 직접 상태 회복 완료 (상태 회복 알림 발행)
 func (scu *swarmpitCheckUsecase) checkSwarmpit(ctx context.Context) (history *domain.SwarmpitCheckHistory) {
+	thresholds := scu.takeThresholds()
+
 	_uuid := uuid.New().String()
 	history = new(domain.SwarmpitCheckHistory)
-	history.FillPrivateComponent()
+	history.FillPrivateComponent(scu.clock.Now())
+	history.SetType(scu.record._type)
 	history.UUID = _uuid
 
-	ctn, err := scu.dockerAgency.GetContainerWithServiceName(scu.myCfg.SwarmpitAppServiceName())
+	if paused, reason := scu.maintenanceAgency.IsPaused(scu.record.domain, scu.record._type); paused {
+		history.ProcessLevel.Set(pausedLevel)
+		history.Message = "swarmpit check is paused by maintenance window, reason: " + reason
+		return
+	}
+
+	ctn, err := scu.dockerAgency.GetContainerWithServiceName(ctx, thresholds.AppServiceName)
 	if err != nil {
 		history.ProcessLevel.Set(errorLevel)
 		history.SetError(errors.Wrap(err, "failed to get swarmpit app docker container"))
 		msg := "!swarmpit check error occurred! unable to get swarmpit app container"
-		history.SetAlarmResult(scu.slackChatAgency.SendMessage("x", msg, _uuid))
+		history.AddAlarmResults(scu.notifyAgency.Notify(ctx, scu.alarm(history, errorLevel, "x", msg)))
 		return
 	}
 	history.SwarmpitAppMemoryUsage = ctn.MemoryUsage()
@@ -123,30 +189,51 @@ func (scu *swarmpitCheckUsecase) checkSwarmpit(ctx context.Context) (history *do
 		history.Message = "recovering swarmpit health is already on process"
 		return
 	case swarmpitStatusUnhealthy:
-		if memoryUsage.isLessThan(scu.myCfg.SwarmpitAppMaxMemoryUsage()) {
+		if memoryUsage.isLessThan(thresholds.AppMaxMemoryUsage) {
 			scu.setStatus(swarmpitStatusHealthy)
 			history.ProcessLevel.Set(recoveredLevel)
 			history.Message = "swarmpit check is recovered to be healthy"
 			msg := fmt.Sprintf("!swarmpit check recovered to health! memory usage - %s", memoryUsage.V)
-			_, _, _ = scu.slackChatAgency.SendMessage("heart", msg, _uuid)
+			history.AddAlarmResults(scu.notifyAgency.Notify(ctx, scu.alarm(history, recoveredLevel, "heart", msg)))
 		} else {
 			history.ProcessLevel.Set(unhealthyLevel)
 			history.Message = "swarmpit check is unhealthy now"
+			if alarm, ok := scu.reminder(history); ok {
+				history.ProcessLevel.Append(escalatedLevel)
+				history.AddAlarmResults(scu.notifyAgency.Notify(ctx, alarm))
+			}
 		}
 		return
 	}
 
-	if memoryUsage.isMoreThan(scu.myCfg.SwarmpitAppMaxMemoryUsage()) {
+	if memoryUsage.isMoreThan(thresholds.AppMaxMemoryUsage) {
+		if suppressed, reason := scu.maintenanceAgency.IsRemediationSuppressed(scu.record.domain, scu.record._type); suppressed {
+			history.ProcessLevel.Set(suppressedLevel)
+			history.Message = "swarmpit weak is detected, but remediation is suppressed by maintenance window, reason: " + reason
+			return
+		}
+
+		if isDryRun(ctx, scu.myCfg) {
+			history.SetSimulated()
+			history.ProcessLevel.Set(simulatedLevel)
+			history.Message = fmt.Sprintf("restarting swarmpit app is simulated in dry-run mode, container: %s", ctn.ID())
+			if scu.simulate() {
+				msg := "!swarmpit check dry-run! swarmpit app would be restarted, but not executed"
+				history.AddAlarmResults(scu.notifyAgency.Notify(ctx, scu.alarm(history, simulatedLevel, "test_tube", msg)))
+			}
+			return
+		}
+
 		scu.setStatus(swarmpitStatusRecovering)
 		history.ProcessLevel.Set(weakDetectedLevel)
 		msg := "!swarmpit check weak detected! start to restart swarmpit app"
-		history.SetAlarmResult(scu.slackChatAgency.SendMessage("pill", msg, _uuid))
+		history.AddAlarmResults(scu.notifyAgency.Notify(ctx, scu.alarm(history, weakDetectedLevel, "pill", msg)))
 
-		if err := scu.dockerAgency.RemoveContainer(ctn.ID(), types.ContainerRemoveOptions{Force: true}); err != nil {
+		if err := scu.dockerAgency.RemoveContainer(ctx, ctn.ID(), types.ContainerRemoveOptions{Force: true}); err != nil {
 			scu.setStatus(swarmpitStatusUnhealthy)
 			history.ProcessLevel.Append(errorLevel)
 			msg := "!swarmpit check error occurred! failed to remove swarmpit app, please check for yourself"
-			_, _, _ = scu.slackChatAgency.SendMessage("anger", msg, _uuid)
+			history.AddAlarmResults(scu.notifyAgency.Notify(ctx, scu.alarm(history, errorLevel, "anger", msg)))
 			history.SetError(errors.Wrap(err, "failed to remove swarmpit app"))
 			return
 		} else {
@@ -154,7 +241,7 @@ func (scu *swarmpitCheckUsecase) checkSwarmpit(ctx context.Context) (history *do
 			history.IfSwarmpitAppRestarted = true
 			history.Message = "restart swarmpit app as swarmpit app memory usage is more than the maximum"
 			msg := "!swarmpit check is recovered! succeed to restart swarmpit app"
-			_, _, _ = scu.slackChatAgency.SendMessage("heart", msg, _uuid)
+			history.AddAlarmResults(scu.notifyAgency.Notify(ctx, scu.alarm(history, recoveredLevel, "heart", msg)))
 		}
 	} else {
 		history.ProcessLevel.Set(healthyLevel)
@@ -168,5 +255,143 @@ func (scu *swarmpitCheckUsecase) checkSwarmpit(ctx context.Context) (history *do
 func (scu *swarmpitCheckUsecase) setStatus(status swarmpitCheckStatus) {
 	scu.mutex.Lock()
 	defer scu.mutex.Unlock()
+	if scu.status != status {
+		scu.record.statusChanged(status == swarmpitStatusHealthy)
+	}
 	scu.status = status
 }
+
+// setLastHistory set check history created in latest check process to record using mutex Lock & Unlock
+func (scu *swarmpitCheckUsecase) setLastHistory(history domain.CheckHistory) {
+	scu.mutex.Lock()
+	defer scu.mutex.Unlock()
+	scu.record.lastHistory = history
+}
+
+// Status return current status of swarmpit check with record field using mutex Lock & Unlock
+// Implement Status method of domain.CheckStatusReporter interface
+func (scu *swarmpitCheckUsecase) Status() domain.CheckStatus {
+	scu.mutex.Lock()
+	defer scu.mutex.Unlock()
+	return scu.record.status(scu.status.String())
+}
+
+// Acknowledge acknowledge unhealthy status of swarmpit check by operator & handle operation history
+// Implement Acknowledge method of domain.CheckController interface
+func (scu *swarmpitCheckUsecase) Acknowledge(ctx context.Context, operator, reason string) (err error) {
+	scu.mutex.Lock()
+	if scu.status != swarmpitStatusUnhealthy {
+		scu.mutex.Unlock()
+		return domain.ErrCheckNotUnhealthy
+	}
+	scu.record.acknowledgedBy = operator
+	scu.mutex.Unlock()
+
+	history := scu.newOperationHistory(acknowledgedLevel, operator, reason)
+	history.Message = "unhealthy swarmpit check status is acknowledged by operator"
+	msg := fmt.Sprintf("!swarmpit check acknowledged! unhealthy status is acknowledged by %s, reason: %s", operator, reason)
+	history.AddAlarmResults(scu.notifyAgency.Notify(ctx, scu.alarm(history, acknowledgedLevel, "eyes", msg)))
+
+	return scu.handleHistory(history, 0)
+}
+
+// Reset reset status of swarmpit check to healthy by operator & handle operation history
+// Implement Reset method of domain.CheckController interface
+func (scu *swarmpitCheckUsecase) Reset(ctx context.Context, operator, reason string) (err error) {
+	scu.mutex.Lock()
+	if scu.status == swarmpitStatusRecovering {
+		scu.mutex.Unlock()
+		return domain.ErrCheckRecovering
+	}
+	before := scu.status
+	if scu.status != swarmpitStatusHealthy {
+		scu.record.statusChanged(true)
+	}
+	scu.status = swarmpitStatusHealthy
+	scu.mutex.Unlock()
+
+	history := scu.newOperationHistory(resetLevel, operator, reason)
+	history.Message = fmt.Sprintf("swarmpit check status is reset from %s to healthy by operator", before.String())
+	msg := fmt.Sprintf("!swarmpit check reset! status is reset from %s to healthy by %s, reason: %s", before.String(), operator, reason)
+	history.AddAlarmResults(scu.notifyAgency.Notify(ctx, scu.alarm(history, resetLevel, "wrench", msg)))
+
+	return scu.handleHistory(history, 0)
+}
+
+// Rerun reset status of swarmpit check by operator & run swarmpit check process immediately
+// Implement Rerun method of domain.CheckController interface
+func (scu *swarmpitCheckUsecase) Rerun(ctx context.Context, operator, reason string) (err error) {
+	if err = scu.Reset(ctx, operator, reason); err != nil {
+		return
+	}
+	return scu.CheckSwarmpit(ctx)
+}
+
+// newOperationHistory return new swarmpit check history about operation by operator with process level
+func (scu *swarmpitCheckUsecase) newOperationHistory(level, operator, reason string) (history *domain.SwarmpitCheckHistory) {
+	history = new(domain.SwarmpitCheckHistory)
+	history.FillPrivateComponent(scu.clock.Now())
+	history.SetType(scu.record._type)
+	history.UUID = uuid.New().String()
+	history.ProcessLevel.Set(level)
+	history.SetOperation(operator, reason)
+	return
+}
+
+// alarmFields return fields of alarm about swarmpit check with memory usage of swarmpit app, maximum & service name
+func (scu *swarmpitCheckUsecase) alarmFields(history *domain.SwarmpitCheckHistory) (fields map[string]string) {
+	fields = map[string]string{
+		domain.AlarmFieldThreshold: "maximum " + scu.thresholds.AppMaxMemoryUsage.String(),
+		domain.AlarmFieldTarget:    scu.thresholds.AppServiceName,
+	}
+	if history.SwarmpitAppMemoryUsage > 0 {
+		fields[domain.AlarmFieldMeasured] = history.SwarmpitAppMemoryUsage.String()
+	}
+	return
+}
+
+// alarm return alarm about swarmpit check with fields from history, incident of record is updated using mutex Lock & Unlock
+func (scu *swarmpitCheckUsecase) alarm(history *domain.SwarmpitCheckHistory, level, emoji, text string) domain.Alarm {
+	scu.mutex.Lock()
+	defer scu.mutex.Unlock()
+	return scu.record.alarm(level, emoji, text, history.UUID, scu.alarmFields(history))
+}
+
+// reminder return reminder alarm about unhealthy swarmpit check by escalation policy, record is updated using mutex Lock & Unlock
+func (scu *swarmpitCheckUsecase) reminder(history *domain.SwarmpitCheckHistory) (domain.Alarm, bool) {
+	scu.mutex.Lock()
+	defer scu.mutex.Unlock()
+	return scu.record.reminder(scu.myCfg.EscalationPolicy(), history.UUID, scu.alarmFields(history))
+}
+
+// takeThresholds take thresholds snapshot of swarmpit check from config & set to thresholds field using mutex Lock & Unlock
+// every threshold compared in check process & shown in alarm is read from this snapshot, though config is reloaded meanwhile
+func (scu *swarmpitCheckUsecase) takeThresholds() domain.SwarmpitCheckThresholds {
+	scu.mutex.Lock()
+	defer scu.mutex.Unlock()
+	scu.thresholds = scu.myCfg.SwarmpitCheckThresholds()
+	return scu.thresholds
+}
+
+// simulate mark remediation of swarmpit check as simulated in record using mutex Lock & Unlock, and return if it should be alarmed
+func (scu *swarmpitCheckUsecase) simulate() bool {
+	scu.mutex.Lock()
+	defer scu.mutex.Unlock()
+	return scu.record.simulate()
+}
+
+// trackIncident track swarmpit check history into incident of record using mutex Lock & Unlock, and store updated incident
+func (scu *swarmpitCheckUsecase) trackIncident(history *domain.SwarmpitCheckHistory) error {
+	scu.mutex.Lock()
+	incident, ok := scu.record.track(history)
+	scu.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+
+	history.SetIncident(incident.ID)
+	if b, err := scu.incidentRepo.Store(incident); err != nil {
+		return errors.Wrapf(err, "failed to store incident of swarmpit check, response: %s", string(b))
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Create file in v.1.1.0
+// reload.go is file that define method of syscheckConfig reloading execution, threshold & delivery schedule from config file
+// every value read again is validated with Validate method first, and applied at once only if every value is valid
+
+package config
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// Reload method read execution, threshold, delivery ping cycle & schedule of syscheck domain again from v and validate them
+// it returns commit function applying every value at once & returning changed values, value not set in v is reset to default
+// if any of config value is invalid, ConfigErrors is returned and old values are kept because commit function is not returned
+func (sc *syscheckConfig) Reload(v *viper.Viper, sv specValidator) (commit func() []domain.ConfigChange, err error) {
+	if errs := sc.Validate(v, sv); len(errs) > 0 {
+		err = errors.Wrap(errs, "invalid syscheck config value to reload")
+		return
+	}
+
+	r := &reader{v: v}
+	overlapPolicy := r.oneOf("syscheck.execution.overlapPolicy", defaultOverlapPolicy, "skip", "queue", "wait")
+	runTimeout := r.duration("syscheck.execution.runTimeout", defaultRunTimeout, true)
+	dryRun := r.bool("syscheck.execution.dryRun", r.bool("dryRun", false))
+	escalationPolicy := defaultEscalationPolicy
+	r.unmarshal("syscheck.escalation", &escalationPolicy)
+	thresholds := readThresholds(r)
+	diskCycle := r.duration("syscheck.delivery.channel.pingCycle.diskcheck", defaultDiskCheckDeliveryPingCycle, false)
+	cpuCycle := r.duration("syscheck.delivery.channel.pingCycle.cpucheck", defaultCPUCheckDeliveryPingCycle, false)
+	memoryCycle := r.duration("syscheck.delivery.channel.pingCycle.memorycheck", defaultMemoryCheckDeliveryPingCycle, false)
+	diskSchedule := r.schedule("syscheck.delivery.channel.schedule.diskcheck", diskCycle, sv)
+	cpuSchedule := r.schedule("syscheck.delivery.channel.schedule.cpucheck", cpuCycle, sv)
+	memorySchedule := r.schedule("syscheck.delivery.channel.schedule.memorycheck", memoryCycle, sv)
+
+	commitInstances := sc.reloadCheckInstances(v)
+
+	commit = func() (changes []domain.ConfigChange) {
+		changes = appendChange(changes, "syscheck.execution.overlapPolicy", sc.OverlapPolicy(), overlapPolicy)
+		changes = appendChange(changes, "syscheck.execution.runTimeout", sc.RunTimeout(), runTimeout)
+		changes = appendChange(changes, "syscheck.execution.dryRun", sc.DryRun(), dryRun)
+		changes = appendChange(changes, "syscheck.escalation", fmt.Sprintf("%+v", sc.EscalationPolicy()), fmt.Sprintf("%+v", escalationPolicy))
+		changes = appendChange(changes, "syscheck.diskcheck.minCapacity", sc.DiskMinCapacity(), thresholds.diskMinCapacity)
+		changes = appendChange(changes, "syscheck.cpucheck.cpuWarningUsage", sc.CPUWarningUsage(), thresholds.cpuWarningUsage)
+		changes = appendChange(changes, "syscheck.cpucheck.cpuMaximumUsage", sc.CPUMaximumUsage(), thresholds.cpuMaximumUsage)
+		changes = appendChange(changes, "syscheck.cpucheck.cpuMinimumUsageToRemove", sc.CPUMinimumUsageToRemove(), thresholds.cpuMinimumUsageToRemove)
+		changes = appendChange(changes, "syscheck.memorycheck.memoryWarningUsage", sc.MemoryWarningUsage(), thresholds.memoryWarningUsage)
+		changes = appendChange(changes, "syscheck.memorycheck.memoryMaximumUsage", sc.MemoryMaximumUsage(), thresholds.memoryMaximumUsage)
+		changes = appendChange(changes, "syscheck.memorycheck.memoryMinimumUsageToRemove", sc.MemoryMinimumUsageToRemove(), thresholds.memoryMinimumUsageToRemove)
+		changes = appendChange(changes, "syscheck.delivery.channel.pingCycle.diskcheck", sc.DiskCheckDeliveryPingCycle(), diskCycle)
+		changes = appendChange(changes, "syscheck.delivery.channel.pingCycle.cpucheck", sc.CPUCheckDeliveryPingCycle(), cpuCycle)
+		changes = appendChange(changes, "syscheck.delivery.channel.pingCycle.memorycheck", sc.MemoryCheckDeliveryPingCycle(), memoryCycle)
+		changes = appendChange(changes, "syscheck.delivery.channel.schedule.diskcheck", sc.DiskCheckDeliverySchedule(), diskSchedule)
+		changes = appendChange(changes, "syscheck.delivery.channel.schedule.cpucheck", sc.CPUCheckDeliverySchedule(), cpuSchedule)
+		changes = appendChange(changes, "syscheck.delivery.channel.schedule.memorycheck", sc.MemoryCheckDeliverySchedule(), memorySchedule)
+
+		instanceChanges, applyInstances := commitInstances()
+		changes = append(changes, instanceChanges...)
+
+		// every value is applied in one lock, so that check process taking thresholds snapshot doesn't mix old & new values
+		sc.mutex.Lock()
+		defer sc.mutex.Unlock()
+
+		sc.overlapPolicy, sc.runTimeout, sc.dryRun, sc.escalationPolicy = &overlapPolicy, &runTimeout, &dryRun, &escalationPolicy
+		sc.thresholds = thresholds
+		sc.diskCheckDeliveryPingCycle, sc.cpuCheckDeliveryPingCycle, sc.memoryCheckDeliveryPingCycle = &diskCycle, &cpuCycle, &memoryCycle
+		sc.diskCheckDeliverySchedule, sc.cpuCheckDeliverySchedule, sc.memoryCheckDeliverySchedule = &diskSchedule, &cpuSchedule, &memorySchedule
+		applyInstances()
+		return
+	}
+	return
+}
+
+// readThresholds read every threshold of syscheck domain from r and return new thresholds snapshot
+// default value is used for threshold which is not set or invalid in config file
+func readThresholds(r *reader) *thresholds {
+	return &thresholds{
+		diskMinCapacity:            r.byteSize("syscheck.diskcheck.minCapacity", defaultDiskMinCapacity),
+		cpuWarningUsage:            r.float64("syscheck.cpucheck.cpuWarningUsage", defaultCPUWarningUsage),
+		cpuMaximumUsage:            r.float64("syscheck.cpucheck.cpuMaximumUsage", defaultCPUMaximumUsage),
+		cpuMinimumUsageToRemove:    r.float64("syscheck.cpucheck.cpuMinimumUsageToRemove", defaultCPUMinimumUsageToRemove),
+		memoryWarningUsage:         r.byteSize("syscheck.memorycheck.memoryWarningUsage", defaultMemoryWarningUsage),
+		memoryMaximumUsage:         r.byteSize("syscheck.memorycheck.memoryMaximumUsage", defaultMemoryMaximumUsage),
+		memoryMinimumUsageToRemove: r.byteSize("syscheck.memorycheck.memoryMinimumUsageToRemove", defaultMemoryMinimumUsageToRemove),
+	}
+}
+
+// appendChange append change of config value with key to changes if value is changed, and return that
+func appendChange(changes []domain.ConfigChange, key string, old, new interface{}) []domain.ConfigChange {
+	if o, n := fmt.Sprint(old), fmt.Sprint(new); o != n {
+		changes = append(changes, domain.ConfigChange{Key: key, Old: o, New: n})
+	}
+	return changes
+}
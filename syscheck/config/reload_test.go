@@ -0,0 +1,197 @@
+package config
+
+import (
+	"github.com/inhies/go-bytesize"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// testSpecValidator is specValidator used in test, which reject only spec starting with invalid
+type testSpecValidator struct{}
+
+func (testSpecValidator) ValidateSpec(spec string) error {
+	if strings.HasPrefix(spec, "invalid") {
+		return errors.New("unknown schedule spec")
+	}
+	return nil
+}
+
+// newTestViper return new viper instance having config value read from yaml
+func newTestViper(t *testing.T, yaml string) *viper.Viper {
+	t.Helper()
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(yaml)); err != nil {
+		t.Fatalf("failed to read yaml, err: %v", err)
+	}
+	return v
+}
+
+func assertErrorKeys(t *testing.T, errs domain.ConfigErrors, keys ...string) {
+	t.Helper()
+	if len(errs) != len(keys) {
+		t.Fatalf("config errors = %v, want errors of %v", errs, keys)
+	}
+	for i, key := range keys {
+		if errs[i].Key != key {
+			t.Fatalf("config errors = %v, want errors of %v", errs, keys)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	v := newTestViper(t, `
+syscheck:
+  execution:
+    overlapPolicy: drop
+    runTimeout: -1s
+  cpucheck:
+    cpuWarningUsage: 2.0
+    cpuMaximumUsage: 1.5
+  diskcheck:
+    minCapacity: lots
+  delivery:
+    channel:
+      pingCycle:
+        diskcheck: 0s
+      schedule:
+        cpucheck: invalid spec
+  checks:
+    - name: memory-strict
+      kind: memory
+      params:
+        memoryWarningUsage: 512MB
+        memoryMaximumUsage: 1GB
+    - name: cpu
+      schedule: invalid spec
+      params:
+        minCapacity: 1GB
+`)
+
+	// every problem is reported at once with key path, and cross-field condition is validated after params are merged
+	assertErrorKeys(t, App.Validate(v, testSpecValidator{}),
+		"syscheck.execution.overlapPolicy",
+		"syscheck.execution.runTimeout",
+		"syscheck.diskcheck.minCapacity",
+		"syscheck.cpucheck.cpuWarningUsage",
+		"syscheck.delivery.channel.pingCycle.diskcheck",
+		"syscheck.delivery.channel.schedule.cpucheck",
+		"syscheck.checks[0].params.memoryMinimumUsageToRemove",
+		"syscheck.checks[1].schedule",
+		"syscheck.checks[1].params.minCapacity",
+		"syscheck.checks[1].params.cpuWarningUsage",
+	)
+
+	// config value not set is valid
+	assertErrorKeys(t, App.Validate(viper.New(), testSpecValidator{}))
+}
+
+func TestReload(t *testing.T) {
+	sc := &syscheckConfig{}
+	if got := sc.CPUMaximumUsage(); got != defaultCPUMaximumUsage {
+		t.Fatalf("cpu maximum usage = %.02f, want default %.02f", got, defaultCPUMaximumUsage)
+	}
+
+	// old values are kept if any of config value to reload is invalid
+	commit, err := sc.Reload(newTestViper(t, `
+syscheck:
+  cpucheck:
+    cpuMaximumUsage: 2.0
+  execution:
+    overlapPolicy: drop
+`), testSpecValidator{})
+	if _, ok := errors.Cause(err).(domain.ConfigErrors); !ok || commit != nil {
+		t.Fatalf("reload error = %v, want ConfigErrors without commit", err)
+	}
+	if got := sc.CPUMaximumUsage(); got != defaultCPUMaximumUsage {
+		t.Fatalf("cpu maximum usage = %.02f, want default %.02f", got, defaultCPUMaximumUsage)
+	}
+
+	commit, err = sc.Reload(newTestViper(t, `
+syscheck:
+  execution:
+    overlapPolicy: queue
+    runTimeout: 1m
+  escalation:
+    afterRuns: 3
+  cpucheck:
+    cpuMaximumUsage: 2.0
+  delivery:
+    channel:
+      schedule:
+        memorycheck: "0 */10 * * * *"
+  checks:
+    - name: disk
+      params:
+        minCapacity: 5GB
+    - name: cpu
+    - name: memory
+`), testSpecValidator{})
+	if err != nil {
+		t.Fatalf("unexpected error, err: %v", err)
+	}
+
+	// values aren't applied until commit function is called
+	if got := sc.OverlapPolicy(); got != defaultOverlapPolicy {
+		t.Fatalf("overlap policy = %s, want default %s before commit", got, defaultOverlapPolicy)
+	}
+
+	changes := map[string]domain.ConfigChange{}
+	for _, change := range commit() {
+		changes[change.Key] = change
+	}
+	for _, key := range []string{
+		"syscheck.execution.overlapPolicy",
+		"syscheck.execution.runTimeout",
+		"syscheck.escalation",
+		"syscheck.cpucheck.cpuMaximumUsage",
+		"syscheck.delivery.channel.schedule.memorycheck",
+		"syscheck.checks[0].params.minCapacity",
+	} {
+		if _, ok := changes[key]; !ok {
+			t.Fatalf("config changes = %v, want change of %s", changes, key)
+		}
+	}
+	if len(changes) != 6 {
+		t.Fatalf("config changes = %v, want only 6 changes", changes)
+	}
+	if change := changes["syscheck.cpucheck.cpuMaximumUsage"]; change.Old != "1.5" || change.New != "2" {
+		t.Fatalf("cpu maximum usage change = %+v, want 1.5 -> 2", change)
+	}
+
+	// reloaded values are returned from getters & check instances
+	if sc.OverlapPolicy() != "queue" || sc.RunTimeout() != time.Minute || sc.CPUMaximumUsage() != 2.0 {
+		t.Fatalf("overlap policy, run timeout, cpu maximum usage = %s, %s, %.02f, want queue, 1m, 2.00",
+			sc.OverlapPolicy(), sc.RunTimeout(), sc.CPUMaximumUsage())
+	}
+	if policy := sc.EscalationPolicy(); policy.AfterRuns != 3 || policy.Interval != defaultEscalationPolicy.Interval {
+		t.Fatalf("escalation policy = %+v, want afterRuns 3 with default of other fields", policy)
+	}
+	if got := sc.MemoryCheckDeliverySchedule(); got != "0 */10 * * * *" {
+		t.Fatalf("memory check schedule = %s, want 0 */10 * * * *", got)
+	}
+	instances := sc.CheckInstances()
+	if got := instances[0].DiskCheckThresholds().MinCapacity; got != bytesize.GB*5 {
+		t.Fatalf("min capacity of disk instance = %s, want 5GB", got)
+	}
+	if got := instances[1].CPUCheckThresholds().MaximumUsage; got != 2.0 {
+		t.Fatalf("cpu maximum usage of cpu instance = %.02f, want 2.00", got)
+	}
+
+	// value not set is reset to default
+	if commit, err = sc.Reload(viper.New(), testSpecValidator{}); err != nil {
+		t.Fatalf("unexpected error, err: %v", err)
+	}
+	commit()
+	if sc.OverlapPolicy() != defaultOverlapPolicy || sc.CPUMaximumUsage() != defaultCPUMaximumUsage {
+		t.Fatalf("overlap policy, cpu maximum usage = %s, %.02f, want default", sc.OverlapPolicy(), sc.CPUMaximumUsage())
+	}
+	if got := instances[0].DiskCheckThresholds().MinCapacity; got != defaultDiskMinCapacity {
+		t.Fatalf("min capacity of disk instance = %s, want default %s", got, defaultDiskMinCapacity)
+	}
+}
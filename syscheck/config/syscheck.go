@@ -10,7 +10,10 @@ package config
 import (
 	"github.com/inhies/go-bytesize"
 	"github.com/spf13/viper"
+	"sync"
 	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
 )
 
 // App is the application config about syscheck domain
@@ -18,7 +21,7 @@ var App *syscheckConfig
 
 // syscheckConfig having config value and implement various interface about Config by declaring method
 type syscheckConfig struct {
-	// fields about index information in elasticsearch (implement esRepositoryComponentConfig)
+	// fields about index information in elasticsearch (implement config interface in esindex package)
 	// indexName represent name of elasticsearch index including syscheck history document
 	indexName *string
 
@@ -28,35 +31,35 @@ type syscheckConfig struct {
 	// indexReplicaNum represent replica number of elasticsearch index to replace index when node become unable
 	indexReplicaNum *int
 
-	// ---
+	// indexRollover represent how to roll over syscheck history index (none, daily, monthly, alias)
+	indexRollover *string
 
-	// fields using in disk health checking (implement diskCheckUsecaseConfig)
-	// diskMinCapacity represent minimum disk capacity and is standard to decide to if disk is healthy.
-	diskMinCapacity *bytesize.ByteSize
+	// indexRolloverMaxAge represent max age of write index before rolling over when index rollover is alias
+	indexRolloverMaxAge *time.Duration
 
-	// ---
+	// indexRetention represent retention period of syscheck history index, history index is never deleted if zero
+	indexRetention *time.Duration
 
-	// fields using in cpu health check (implement cpuCheckUsecaseConfig)
-	// cpuWarningUsage represent warning cpu usage.
-	cpuWarningUsage *float64
+	// ---
 
-	// cpuMaximumUsage represent cpu maximum usage and is standard to decide to if cpu is healthy.
-	cpuMaximumUsage *float64
+	// fields using in every syscheck usecase to execute check process (implement systemCheckUsecaseComponentConfig)
+	// overlapPolicy represent policy about check process overlapped with running one (skip, queue, wait)
+	overlapPolicy *string
 
-	// cpuMinimumUsageToRemove represent minimum cpu usage to decide whether remove container or not
-	cpuMinimumUsageToRemove *float64
+	// runTimeout represent max duration of check process run, check process is canceled if exceeded
+	runTimeout *time.Duration
 
-	// ---
+	// dryRun represent if remediation in check process is simulated without executing
+	dryRun *bool
 
-	// fields using in memory health check (implement memoryCheckUsecaseConfig)
-	// memoryWarningUsage represent warning memory usage.
-	memoryWarningUsage *bytesize.ByteSize
+	// escalationPolicy represent policy about sending reminder of check staying unhealthy without acknowledgement
+	escalationPolicy *domain.EscalationPolicy
 
-	// memoryMaximumUsage represent memory maximum usage and is standard to decide to if memory is healthy.
-	memoryMaximumUsage *bytesize.ByteSize
+	// ---
 
-	// memoryMinimumUsageToRemove represent minimum memory usage to decide whether remove container or not
-	memoryMinimumUsageToRemove *bytesize.ByteSize
+	// fields using in disk, cpu & memory health checking (implement diskCheckUsecaseConfig, cpuCheckUsecaseConfig, ...)
+	// thresholds represent immutable snapshot of threshold in syscheck domain, which is replaced at once by reload
+	thresholds *thresholds
 
 	// --
 
@@ -69,13 +72,63 @@ type syscheckConfig struct {
 
 	// memoryCheckDeliveryPingCycle represent memory check delivery ping cycle
 	memoryCheckDeliveryPingCycle *time.Duration
+
+	// diskCheckDeliverySchedule represent disk check delivery schedule spec (interval or cron expression with jitter)
+	diskCheckDeliverySchedule *string
+
+	// cpuCheckDeliverySchedule represent cpu check delivery schedule spec (interval or cron expression with jitter)
+	cpuCheckDeliverySchedule *string
+
+	// memoryCheckDeliverySchedule represent memory check delivery schedule spec (interval or cron expression with jitter)
+	memoryCheckDeliverySchedule *string
+
+	// deliveryInitialRun represent if every check in syscheck is run as soon as delivery is started
+	deliveryInitialRun *bool
+
+	// checkInstances represent every check instance declared in syscheck.checks, which is not reloaded at runtime
+	checkInstances *[]*CheckInstance
+
+	// ---
+
+	// mutex help to prevent race condition when access execution, threshold & delivery fields reloaded at runtime
+	mutex sync.Mutex
+}
+
+// thresholds is immutable snapshot of threshold in syscheck domain, new one is created instead of changing field by reload
+type thresholds struct {
+	// diskMinCapacity represent minimum disk capacity and is standard to decide to if disk is healthy.
+	diskMinCapacity bytesize.ByteSize
+
+	// cpuWarningUsage represent warning cpu usage.
+	cpuWarningUsage float64
+
+	// cpuMaximumUsage represent cpu maximum usage and is standard to decide to if cpu is healthy.
+	cpuMaximumUsage float64
+
+	// cpuMinimumUsageToRemove represent minimum cpu usage to decide whether remove container or not
+	cpuMinimumUsageToRemove float64
+
+	// memoryWarningUsage represent warning memory usage.
+	memoryWarningUsage bytesize.ByteSize
+
+	// memoryMaximumUsage represent memory maximum usage and is standard to decide to if memory is healthy.
+	memoryMaximumUsage bytesize.ByteSize
+
+	// memoryMinimumUsageToRemove represent minimum memory usage to decide whether remove container or not
+	memoryMinimumUsageToRemove bytesize.ByteSize
 }
 
 // default const value about syscheckConfig field
 const (
-	defaultIndexName       = "sms-system-check" // default const string for indexName
-	defaultIndexShardNum   = 2                  // default const int for indexShardNum
-	defaultIndexReplicaNum = 0                  // default const int for indexReplicaNum
+	defaultIndexName           = "sms-system-check"  // default const string for indexName
+	defaultIndexShardNum       = 2                   // default const int for indexShardNum
+	defaultIndexReplicaNum     = 0                   // default const int for indexReplicaNum
+	defaultIndexRollover       = "none"              // default const string for indexRollover
+	defaultIndexRolloverMaxAge = time.Hour * 24 * 30 // default const duration for indexRolloverMaxAge
+	defaultIndexRetention      = time.Duration(0)    // default const duration for indexRetention
+
+	defaultOverlapPolicy = "skip"          // default const string for overlapPolicy
+	defaultRunTimeout    = time.Minute * 5 // default const Duration for runTimeout
 
 	defaultDiskMinCapacity = bytesize.GB * 2 // default const byte size for diskMinCapacity
 
@@ -90,9 +143,18 @@ const (
 	defaultDiskCheckDeliveryPingCycle   = time.Minute * 5 // default const Duration for diskCheckDeliveryPingCycle
 	defaultCPUCheckDeliveryPingCycle    = time.Minute * 5 // default const Duration for diskCheckDeliveryPingCycle
 	defaultMemoryCheckDeliveryPingCycle = time.Minute * 5 // default const Duration for diskCheckDeliveryPingCycle
+	defaultDeliveryInitialRun           = false           // default const bool for deliveryInitialRun
 )
 
-// implement IndexName method of esRepositoryComponentConfig interface
+// default value about escalationPolicy field, reminder is sent after 30m & repeated at 30m, 1h, 2h, 4h, 4h, ...
+var defaultEscalationPolicy = domain.EscalationPolicy{
+	After:       time.Minute * 30,
+	Interval:    time.Minute * 30,
+	Multiplier:  2,
+	MaxInterval: time.Hour * 4,
+}
+
+// implement IndexName method of config interface in esindex package
 func (sc *syscheckConfig) IndexName() string {
 	var key = "syscheck.repository.elasticsearch.index.name"
 	if sc.indexName == nil {
@@ -104,7 +166,7 @@ func (sc *syscheckConfig) IndexName() string {
 	return *sc.indexName
 }
 
-// implement IndexShardNum method of esRepositoryComponentConfig interface
+// implement IndexShardNum method of config interface in esindex package
 func (sc *syscheckConfig) IndexShardNum() int {
 	var key = "syscheck.repository.elasticsearch.index.shardNum"
 	if sc.indexShardNum == nil {
@@ -116,7 +178,7 @@ func (sc *syscheckConfig) IndexShardNum() int {
 	return *sc.indexShardNum
 }
 
-// implement IndexReplicaNum method of esRepositoryComponentConfig interface
+// implement IndexReplicaNum method of config interface in esindex package
 func (sc *syscheckConfig) IndexReplicaNum() int {
 	var key = "syscheck.repository.elasticsearch.index.replicaNum"
 	if sc.indexReplicaNum == nil {
@@ -128,113 +190,187 @@ func (sc *syscheckConfig) IndexReplicaNum() int {
 	return *sc.indexReplicaNum
 }
 
-// implement DiskMinCapacity method of diskCheckUsecaseConfig interface
-func (sc *syscheckConfig) DiskMinCapacity() bytesize.ByteSize {
-	var key = "syscheck.diskcheck.minCapacity"
-	if sc.diskMinCapacity != nil {
-		return *sc.diskMinCapacity
+// implement IndexRollover method of config interface in esindex package
+func (sc *syscheckConfig) IndexRollover() string {
+	var key = "syscheck.repository.elasticsearch.index.rollover"
+	if sc.indexRollover == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, defaultIndexRollover)
+		}
+		sc.indexRollover = _string(viper.GetString(key))
+	}
+	return *sc.indexRollover
+}
+
+// implement IndexRolloverMaxAge method of config interface in esindex package
+func (sc *syscheckConfig) IndexRolloverMaxAge() time.Duration {
+	var key = "syscheck.repository.elasticsearch.index.rolloverMaxAge"
+	if sc.indexRolloverMaxAge != nil {
+		return *sc.indexRolloverMaxAge
 	}
 
-	size, err := bytesize.Parse(viper.GetString(key))
+	d, err := time.ParseDuration(viper.GetString(key))
 	if err != nil {
-		viper.Set(key, defaultDiskMinCapacity.String())
-		size = defaultDiskMinCapacity
+		viper.Set(key, defaultIndexRolloverMaxAge.String())
+		d = defaultIndexRolloverMaxAge
 	}
 
-	sc.diskMinCapacity = &size
-	return *sc.diskMinCapacity
+	sc.indexRolloverMaxAge = &d
+	return *sc.indexRolloverMaxAge
 }
 
-// implement CPUWarningUsage method of cpuCheckUsecaseConfig interface
-func (sc *syscheckConfig) CPUWarningUsage() float64 {
-	var key = "syscheck.cpucheck.cpuWarningUsage"
-	if sc.cpuWarningUsage == nil {
-		if _, ok := viper.Get(key).(float64); !ok {
-			viper.Set(key, defaultCPUWarningUsage)
-		}
-		sc.cpuWarningUsage = _float64(viper.GetFloat64(key))
+// implement IndexRetention method of config interface in esindex package
+func (sc *syscheckConfig) IndexRetention() time.Duration {
+	var key = "syscheck.repository.elasticsearch.index.retention"
+	if sc.indexRetention != nil {
+		return *sc.indexRetention
 	}
-	return *sc.cpuWarningUsage
-}
 
-// implement CPUMaximumUsage method of cpuCheckUsecaseConfig interface
-func (sc *syscheckConfig) CPUMaximumUsage() float64 {
-	var key = "syscheck.cpucheck.cpuMaximumUsage"
-	if sc.cpuMaximumUsage == nil {
-		if _, ok := viper.Get(key).(float64); !ok {
-			viper.Set(key, defaultCPUMaximumUsage)
-		}
-		sc.cpuMaximumUsage = _float64(viper.GetFloat64(key))
+	d, err := time.ParseDuration(viper.GetString(key))
+	if err != nil {
+		viper.Set(key, defaultIndexRetention.String())
+		d = defaultIndexRetention
 	}
-	return *sc.cpuMaximumUsage
+
+	sc.indexRetention = &d
+	return *sc.indexRetention
 }
 
-// CPUMinimumUsageToRemove method returns float64 represent cpu minimum usage to remove
-func (sc *syscheckConfig) CPUMinimumUsageToRemove() float64 {
-	var key = "syscheck.cpucheck.cpuMinimumUsageToRemove"
-	if sc.cpuMinimumUsageToRemove == nil {
-		if _, ok := viper.Get(key).(float64); !ok {
-			viper.Set(key, defaultCPUMinimumUsageToRemove)
+// implement OverlapPolicy method of config interface in executor package
+func (sc *syscheckConfig) OverlapPolicy() string {
+	var key = "syscheck.execution.overlapPolicy"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.overlapPolicy == nil {
+		switch viper.GetString(key) {
+		case "skip", "queue", "wait":
+			break
+		default:
+			viper.Set(key, defaultOverlapPolicy)
 		}
-		sc.cpuMinimumUsageToRemove = _float64(viper.GetFloat64(key))
+		sc.overlapPolicy = _string(viper.GetString(key))
 	}
-	return *sc.cpuMinimumUsageToRemove
+	return *sc.overlapPolicy
 }
 
-// implement MemoryWarningUsage method of memoryCheckUsecaseConfig interface
-func (sc *syscheckConfig) MemoryWarningUsage() bytesize.ByteSize {
-	var key = "syscheck.memorycheck.memoryWarningUsage"
-	if sc.memoryWarningUsage != nil {
-		return *sc.memoryWarningUsage
+// implement RunTimeout method of config interface in executor package
+func (sc *syscheckConfig) RunTimeout() time.Duration {
+	var key = "syscheck.execution.runTimeout"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.runTimeout != nil {
+		return *sc.runTimeout
 	}
 
-	size, err := bytesize.Parse(viper.GetString(key))
+	d, err := time.ParseDuration(viper.GetString(key))
 	if err != nil {
-		viper.Set(key, defaultMemoryWarningUsage.String())
-		size = defaultMemoryWarningUsage
+		viper.Set(key, defaultRunTimeout.String())
+		d = defaultRunTimeout
 	}
 
-	sc.memoryWarningUsage = &size
-	return *sc.memoryWarningUsage
+	sc.runTimeout = &d
+	return *sc.runTimeout
 }
 
-// implement MemoryMaximumUsage method of memoryCheckUsecaseConfig interface
-func (sc *syscheckConfig) MemoryMaximumUsage() bytesize.ByteSize {
-	var key = "syscheck.memorycheck.memoryMaximumUsage"
-	if sc.memoryMaximumUsage != nil {
-		return *sc.memoryMaximumUsage
+// implement DryRun method of systemCheckUsecaseComponentConfig interface
+// global dryRun value is used if dryRun is not set in execution of syscheck domain
+func (sc *syscheckConfig) DryRun() bool {
+	var key = "syscheck.execution.dryRun"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.dryRun != nil {
+		return *sc.dryRun
 	}
 
-	size, err := bytesize.Parse(viper.GetString(key))
-	if err != nil {
-		viper.Set(key, defaultMemoryMaximumUsage.String())
-		size = defaultMemoryMaximumUsage
+	if !viper.IsSet(key) {
+		viper.Set(key, viper.GetBool("dryRun"))
 	}
-
-	sc.memoryMaximumUsage = &size
-	return *sc.memoryMaximumUsage
+	sc.dryRun = _bool(viper.GetBool(key))
+	return *sc.dryRun
 }
 
-// implement MemoryMinimumUsageToRemove method of memoryCheckUsecaseConfig interface
-func (sc *syscheckConfig) MemoryMinimumUsageToRemove() bytesize.ByteSize {
-	var key = "syscheck.memorycheck.memoryMinimumUsageToRemove"
-	if sc.memoryMinimumUsageToRemove != nil {
-		return *sc.memoryMinimumUsageToRemove
+// implement EscalationPolicy method of systemCheckUsecaseComponentConfig interface
+// default value is used for each field of policy not set in config file, escalation is disabled if after & afterRuns are 0
+func (sc *syscheckConfig) EscalationPolicy() domain.EscalationPolicy {
+	var key = "syscheck.escalation"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.escalationPolicy != nil {
+		return *sc.escalationPolicy
 	}
 
-	size, err := bytesize.Parse(viper.GetString(key))
-	if err != nil {
-		viper.Set(key, defaultMemoryMinimumUsageToRemove.String())
-		size = defaultMemoryMinimumUsageToRemove
+	policy := defaultEscalationPolicy
+	if err := viper.UnmarshalKey(key, &policy); err != nil {
+		policy = defaultEscalationPolicy
 	}
 
-	sc.memoryMinimumUsageToRemove = &size
-	return *sc.memoryMinimumUsageToRemove
+	sc.escalationPolicy = &policy
+	return *sc.escalationPolicy
 }
 
-// not implement any interface, just using in main function for delivery layer injection
+// snapshot method return thresholds snapshot, which is read from config file at first call, mutex must be locked by caller
+// threshold not set or invalid in config file is replaced with default value like other getter
+func (sc *syscheckConfig) snapshot() *thresholds {
+	if sc.thresholds == nil {
+		sc.thresholds = readThresholds(&reader{v: viper.GetViper()})
+	}
+	return sc.thresholds
+}
+
+// DiskMinCapacity method returns byte size represent minimum disk capacity of thresholds snapshot
+func (sc *syscheckConfig) DiskMinCapacity() bytesize.ByteSize {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().diskMinCapacity
+}
+
+// CPUWarningUsage method returns float64 represent cpu warning usage of thresholds snapshot
+func (sc *syscheckConfig) CPUWarningUsage() float64 {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().cpuWarningUsage
+}
+
+// CPUMaximumUsage method returns float64 represent cpu maximum usage of thresholds snapshot
+func (sc *syscheckConfig) CPUMaximumUsage() float64 {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().cpuMaximumUsage
+}
+
+// CPUMinimumUsageToRemove method returns float64 represent cpu minimum usage to remove of thresholds snapshot
+func (sc *syscheckConfig) CPUMinimumUsageToRemove() float64 {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().cpuMinimumUsageToRemove
+}
+
+// MemoryWarningUsage method returns byte size represent memory warning usage of thresholds snapshot
+func (sc *syscheckConfig) MemoryWarningUsage() bytesize.ByteSize {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().memoryWarningUsage
+}
+
+// MemoryMaximumUsage method returns byte size represent memory maximum usage of thresholds snapshot
+func (sc *syscheckConfig) MemoryMaximumUsage() bytesize.ByteSize {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().memoryMaximumUsage
+}
+
+// MemoryMinimumUsageToRemove method returns byte size represent memory minimum usage to remove of thresholds snapshot
+func (sc *syscheckConfig) MemoryMinimumUsageToRemove() bytesize.ByteSize {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.snapshot().memoryMinimumUsageToRemove
+}
+
+// not implement any interface, just using as interval of delivery schedule getter when schedule is not set
 func (sc *syscheckConfig) DiskCheckDeliveryPingCycle() time.Duration {
 	var key = "syscheck.delivery.channel.pingCycle.diskcheck"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
 	if sc.diskCheckDeliveryPingCycle != nil {
 		return *sc.diskCheckDeliveryPingCycle
 	}
@@ -249,9 +385,11 @@ func (sc *syscheckConfig) DiskCheckDeliveryPingCycle() time.Duration {
 	return *sc.diskCheckDeliveryPingCycle
 }
 
-// not implement any interface, just using in main function for delivery layer injection
+// not implement any interface, just using as interval of delivery schedule getter when schedule is not set
 func (sc *syscheckConfig) CPUCheckDeliveryPingCycle() time.Duration {
 	var key = "syscheck.delivery.channel.pingCycle.cpucheck"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
 	if sc.cpuCheckDeliveryPingCycle != nil {
 		return *sc.cpuCheckDeliveryPingCycle
 	}
@@ -266,9 +404,11 @@ func (sc *syscheckConfig) CPUCheckDeliveryPingCycle() time.Duration {
 	return *sc.cpuCheckDeliveryPingCycle
 }
 
-// not implement any interface, just using in main function for delivery layer injection
+// not implement any interface, just using as interval of delivery schedule getter when schedule is not set
 func (sc *syscheckConfig) MemoryCheckDeliveryPingCycle() time.Duration {
 	var key = "syscheck.delivery.channel.pingCycle.memorycheck"
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
 	if sc.memoryCheckDeliveryPingCycle != nil {
 		return *sc.memoryCheckDeliveryPingCycle
 	}
@@ -283,6 +423,66 @@ func (sc *syscheckConfig) MemoryCheckDeliveryPingCycle() time.Duration {
 	return *sc.memoryCheckDeliveryPingCycle
 }
 
+// not implement any interface, just using in main function for delivery layer injection
+// schedule spec is interval or cron expression with optional jitter, ping cycle is used as interval if not set
+func (sc *syscheckConfig) DiskCheckDeliverySchedule() string {
+	var key = "syscheck.delivery.channel.schedule.diskcheck"
+	cycle := sc.DiskCheckDeliveryPingCycle()
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.diskCheckDeliverySchedule == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, cycle.String())
+		}
+		sc.diskCheckDeliverySchedule = _string(viper.GetString(key))
+	}
+	return *sc.diskCheckDeliverySchedule
+}
+
+// not implement any interface, just using in main function for delivery layer injection
+// schedule spec is interval or cron expression with optional jitter, ping cycle is used as interval if not set
+func (sc *syscheckConfig) CPUCheckDeliverySchedule() string {
+	var key = "syscheck.delivery.channel.schedule.cpucheck"
+	cycle := sc.CPUCheckDeliveryPingCycle()
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.cpuCheckDeliverySchedule == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, cycle.String())
+		}
+		sc.cpuCheckDeliverySchedule = _string(viper.GetString(key))
+	}
+	return *sc.cpuCheckDeliverySchedule
+}
+
+// not implement any interface, just using in main function for delivery layer injection
+// schedule spec is interval or cron expression with optional jitter, ping cycle is used as interval if not set
+func (sc *syscheckConfig) MemoryCheckDeliverySchedule() string {
+	var key = "syscheck.delivery.channel.schedule.memorycheck"
+	cycle := sc.MemoryCheckDeliveryPingCycle()
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.memoryCheckDeliverySchedule == nil {
+		if _, ok := viper.Get(key).(string); !ok {
+			viper.Set(key, cycle.String())
+		}
+		sc.memoryCheckDeliverySchedule = _string(viper.GetString(key))
+	}
+	return *sc.memoryCheckDeliverySchedule
+}
+
+// not implement any interface, just using in main function for delivery layer injection
+func (sc *syscheckConfig) DeliveryInitialRun() bool {
+	var key = "syscheck.delivery.channel.initialRun"
+	if sc.deliveryInitialRun == nil {
+		if _, ok := viper.Get(key).(bool); !ok {
+			viper.Set(key, defaultDeliveryInitialRun)
+		}
+		sc.deliveryInitialRun = _bool(viper.GetBool(key))
+	}
+	return *sc.deliveryInitialRun
+}
+
 // init function initialize App global variable
 func init() {
 	App = &syscheckConfig{}
@@ -290,5 +490,6 @@ func init() {
 
 // function returns pointer variable generated from parameter
 func _string(s string) *string    { return &s }
+func _bool(b bool) *bool          { return &b }
 func _int(i int) *int             { return &i }
 func _float64(f float64) *float64 { return &f }
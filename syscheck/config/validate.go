@@ -0,0 +1,231 @@
+// Create file in v.1.1.0
+// validate.go is file that define method of syscheckConfig validating every config value of syscheck domain
+// unlike getter of syscheckConfig, invalid value is not replaced with default value but reported with key path
+
+package config
+
+import (
+	"fmt"
+	"github.com/inhies/go-bytesize"
+	"github.com/spf13/viper"
+	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// specValidator is interface that validate schedule spec of check delivery before applying it
+// you can see implementation in scheduler package
+type specValidator interface {
+	// ValidateSpec method return error if schedule spec is invalid
+	ValidateSpec(spec string) error
+}
+
+// Validate method validate every config value of syscheck domain in v and return all of problems at once
+// cross-field condition is also validated (Ex, warning usage must not be more than maximum usage), value not set is valid
+func (sc *syscheckConfig) Validate(v *viper.Viper, sv specValidator) domain.ConfigErrors {
+	r := &reader{v: v}
+
+	r.string("syscheck.repository.elasticsearch.index.name", defaultIndexName)
+	r.int("syscheck.repository.elasticsearch.index.shardNum", defaultIndexShardNum, 1)
+	r.int("syscheck.repository.elasticsearch.index.replicaNum", defaultIndexReplicaNum, 0)
+	r.oneOf("syscheck.repository.elasticsearch.index.rollover", defaultIndexRollover, "none", "daily", "monthly", "alias")
+	r.duration("syscheck.repository.elasticsearch.index.rolloverMaxAge", defaultIndexRolloverMaxAge, false)
+	r.duration("syscheck.repository.elasticsearch.index.retention", defaultIndexRetention, true)
+
+	r.oneOf("syscheck.execution.overlapPolicy", defaultOverlapPolicy, "skip", "queue", "wait")
+	r.duration("syscheck.execution.runTimeout", defaultRunTimeout, true)
+	r.bool("syscheck.execution.dryRun", false)
+	r.unmarshal("syscheck.escalation", &domain.EscalationPolicy{})
+
+	validateDiskParams(r, "syscheck.diskcheck.")
+	validateCPUParams(r, "syscheck.cpucheck.")
+	validateMemoryParams(r, "syscheck.memorycheck.")
+
+	// ping cycle is only fallback interval of check whose schedule is not set, but still validated because it can be used
+	diskCycle := r.duration("syscheck.delivery.channel.pingCycle.diskcheck", defaultDiskCheckDeliveryPingCycle, false)
+	cpuCycle := r.duration("syscheck.delivery.channel.pingCycle.cpucheck", defaultCPUCheckDeliveryPingCycle, false)
+	memoryCycle := r.duration("syscheck.delivery.channel.pingCycle.memorycheck", defaultMemoryCheckDeliveryPingCycle, false)
+	r.schedule("syscheck.delivery.channel.schedule.diskcheck", diskCycle, sv)
+	r.schedule("syscheck.delivery.channel.schedule.cpucheck", cpuCycle, sv)
+	r.schedule("syscheck.delivery.channel.schedule.memorycheck", memoryCycle, sv)
+	r.bool("syscheck.delivery.channel.initialRun", defaultDeliveryInitialRun)
+
+	validateCheckInstances(r, sv)
+	return r.errs
+}
+
+// validateDiskParams validate config value about disk check whose key start with section
+func validateDiskParams(r *reader, section string) {
+	r.byteSize(section+"minCapacity", defaultDiskMinCapacity)
+}
+
+// validateCPUParams validate config value about cpu check whose key start with section
+func validateCPUParams(r *reader, section string) {
+	cpuWarningUsage := r.float64(section+"cpuWarningUsage", defaultCPUWarningUsage)
+	cpuMaximumUsage := r.float64(section+"cpuMaximumUsage", defaultCPUMaximumUsage)
+	cpuMinimumUsageToRemove := r.float64(section+"cpuMinimumUsageToRemove", defaultCPUMinimumUsageToRemove)
+	if cpuWarningUsage > cpuMaximumUsage {
+		r.fail(section+"cpuWarningUsage", "must not be more than cpuMaximumUsage (%.02f), value: %.02f", cpuMaximumUsage, cpuWarningUsage)
+	}
+	if cpuMinimumUsageToRemove >= cpuMaximumUsage {
+		r.fail(section+"cpuMinimumUsageToRemove", "must be less than cpuMaximumUsage (%.02f), value: %.02f", cpuMaximumUsage, cpuMinimumUsageToRemove)
+	}
+}
+
+// validateMemoryParams validate config value about memory check whose key start with section
+func validateMemoryParams(r *reader, section string) {
+	memoryWarningUsage := r.byteSize(section+"memoryWarningUsage", defaultMemoryWarningUsage)
+	memoryMaximumUsage := r.byteSize(section+"memoryMaximumUsage", defaultMemoryMaximumUsage)
+	memoryMinimumUsageToRemove := r.byteSize(section+"memoryMinimumUsageToRemove", defaultMemoryMinimumUsageToRemove)
+	if memoryWarningUsage > memoryMaximumUsage {
+		r.fail(section+"memoryWarningUsage", "must not be more than memoryMaximumUsage (%s), value: %s", memoryMaximumUsage, memoryWarningUsage)
+	}
+	if memoryMinimumUsageToRemove >= memoryMaximumUsage {
+		r.fail(section+"memoryMinimumUsageToRemove", "must be less than memoryMaximumUsage (%s), value: %s", memoryMaximumUsage, memoryMinimumUsageToRemove)
+	}
+}
+
+// reader read config value from viper with validation, every problem found while reading value is kept in errs
+// if value is invalid, default value received from parameter is returned so that reading can be continued
+type reader struct {
+	v    *viper.Viper
+	errs domain.ConfigErrors
+
+	// prefix is added to key of problem, it is used when v has only part of config (Ex, params of check instance)
+	prefix string
+}
+
+// fail method keep problem of config value with key, message is formatted with parameter
+func (r *reader) fail(key, format string, args ...interface{}) {
+	r.errs = append(r.errs, domain.ConfigError{Key: r.prefix + key, Message: fmt.Sprintf(format, args...)})
+}
+
+// string method return string value with key, or def if not set
+func (r *reader) string(key string, def string) string {
+	if !r.v.IsSet(key) {
+		return def
+	}
+
+	s, ok := r.v.Get(key).(string)
+	if !ok {
+		r.fail(key, "must be string, value: %v", r.v.Get(key))
+		return def
+	}
+	return s
+}
+
+// oneOf method return string value with key which must be one of values, or def if not set
+func (r *reader) oneOf(key string, def string, values ...string) string {
+	s := r.string(key, def)
+	for _, value := range values {
+		if s == value {
+			return s
+		}
+	}
+
+	r.fail(key, "must be one of %v, value: %s", values, s)
+	return def
+}
+
+// bool method return bool value with key, or def if not set
+func (r *reader) bool(key string, def bool) bool {
+	if !r.v.IsSet(key) {
+		return def
+	}
+
+	b, ok := r.v.Get(key).(bool)
+	if !ok {
+		r.fail(key, "must be true or false, value: %v", r.v.Get(key))
+		return def
+	}
+	return b
+}
+
+// int method return int value with key which must not be less than min, or def if not set
+func (r *reader) int(key string, def, min int) int {
+	if !r.v.IsSet(key) {
+		return def
+	}
+
+	i, ok := r.v.Get(key).(int)
+	if !ok || i < min {
+		r.fail(key, "must be integer not less than %d, value: %v", min, r.v.Get(key))
+		return def
+	}
+	return i
+}
+
+// float64 method return positive float64 value with key, or def if not set
+func (r *reader) float64(key string, def float64) (f float64) {
+	if !r.v.IsSet(key) {
+		return def
+	}
+
+	switch value := r.v.Get(key).(type) {
+	case float64:
+		f = value
+	case int:
+		f = float64(value)
+	default:
+		r.fail(key, "must be number, value: %v", value)
+		return def
+	}
+
+	if f <= 0 {
+		r.fail(key, "must be positive number, value: %v", f)
+		return def
+	}
+	return
+}
+
+// byteSize method return byte size value with key, or def if not set
+func (r *reader) byteSize(key string, def bytesize.ByteSize) bytesize.ByteSize {
+	if !r.v.IsSet(key) {
+		return def
+	}
+
+	size, err := bytesize.Parse(r.v.GetString(key))
+	if err != nil {
+		r.fail(key, "must be byte size (Ex, 2GB), value: %v", r.v.Get(key))
+		return def
+	}
+	return size
+}
+
+// duration method return positive duration value with key, or def if not set, zero is also valid if allowZero is true
+func (r *reader) duration(key string, def time.Duration, allowZero bool) time.Duration {
+	if !r.v.IsSet(key) {
+		return def
+	}
+
+	d, err := time.ParseDuration(r.v.GetString(key))
+	if err != nil || d < 0 || (d == 0 && !allowZero) {
+		if allowZero {
+			r.fail(key, "must be zero or positive duration (Ex, 0s, 5m), value: %v", r.v.Get(key))
+			return def
+		}
+		r.fail(key, "must be positive duration (Ex, 5m), value: %v", r.v.Get(key))
+		return def
+	}
+	return d
+}
+
+// schedule method return schedule spec with key validated with sv, or interval of cycle if not set
+func (r *reader) schedule(key string, cycle time.Duration, sv specValidator) string {
+	spec := cycle.String()
+	if r.v.IsSet(key) {
+		spec = r.v.GetString(key)
+	}
+
+	if err := sv.ValidateSpec(spec); err != nil {
+		r.fail(key, "must be valid schedule spec, %s", err.Error())
+	}
+	return spec
+}
+
+// unmarshal method decode value with key into out, and keep problem if value can't be decoded
+func (r *reader) unmarshal(key string, out interface{}) {
+	if err := r.v.UnmarshalKey(key, out); err != nil {
+		r.fail(key, "invalid format, %s", err.Error())
+	}
+}
@@ -22,13 +22,13 @@ type cpuCheckHandler struct {
 }
 
 // NewCPUCheckHandler define diskCheckHandler ptr instance & register handling channel msg to usecase
-func NewCPUCheckHandler(c <-chan time.Time, cu domain.CPUCheckUseCase) {
+func NewCPUCheckHandler(cs checkScheduler, cu domain.CPUCheckUseCase) {
 	handler := &cpuCheckHandler{
 		handlerCtx: globalContext,
 		cUsecase:   cu,
 	}
 
-	go handler.startListening(c)
+	go handler.startListening(cs.C())
 	log.Println("START TO LISTEN CHANNEL MSG ABOUT SYSTEM CPU CHECK")
 }
 
@@ -37,9 +37,9 @@ func (ch *cpuCheckHandler) startListening(c <-chan time.Time) {
 	ch.handlerCtx.startListening(c, ch.checkCPU)
 }
 
-// checkCPU method set context & call usecase CheckCPU method, handle error
+// checkCPU method set context derived from global context & call usecase CheckCPU method, handle error
 func (ch *cpuCheckHandler) checkCPU(t time.Time) {
-	ctx := context.Background()
+	ctx := ch.handlerCtx.chanCancelCtx
 	ctx = context.WithValue(ctx, "time", t)
 
 	if err := ch.cUsecase.CheckCPU(ctx); err != nil {
@@ -22,13 +22,13 @@ type diskCheckHandler struct {
 }
 
 // NewDiskCheckHandler define diskCheckHandler ptr instance & register handling channel msg to usecase
-func NewDiskCheckHandler(c <-chan time.Time, du domain.DiskCheckUseCase) {
+func NewDiskCheckHandler(cs checkScheduler, du domain.DiskCheckUseCase) {
 	handler := &diskCheckHandler{
 		handlerCtx: globalContext,
 		dUsecase:   du,
 	}
 
-	go handler.startListening(c)
+	go handler.startListening(cs.C())
 	log.Println("START TO LISTEN CHANNEL MSG ABOUT SYSTEM DISK CHECK")
 }
 
@@ -37,9 +37,9 @@ func (dh *diskCheckHandler) startListening(c <-chan time.Time) {
 	dh.handlerCtx.startListening(c, dh.checkDisk)
 }
 
-// checkDisk method set context & call usecase CheckDisk method, handle error
+// checkDisk method set context derived from global context & call usecase CheckDisk method, handle error
 func (dh *diskCheckHandler) checkDisk(t time.Time) {
-	ctx := context.Background()
+	ctx := dh.handlerCtx.chanCancelCtx
 	ctx = context.WithValue(ctx, "time", t)
 
 	if err := dh.dUsecase.CheckDisk(ctx); err != nil {
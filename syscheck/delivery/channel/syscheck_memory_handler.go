@@ -22,13 +22,13 @@ type memoryCheckHandler struct {
 }
 
 // NewMemoryCheckHandler define memoryCheckHandler ptr instance & register handling channel msg to usecase
-func NewMemoryCheckHandler(c <-chan time.Time, mu domain.MemoryCheckUseCase) {
+func NewMemoryCheckHandler(cs checkScheduler, mu domain.MemoryCheckUseCase) {
 	handler := &memoryCheckHandler{
 		handlerCtx: globalContext,
 		mUsecase:   mu,
 	}
 
-	go handler.startListening(c)
+	go handler.startListening(cs.C())
 	log.Println("START TO LISTEN CHANNEL MSG ABOUT SYSTEM MEMORY CHECK")
 }
 
@@ -37,9 +37,9 @@ func (mh *memoryCheckHandler) startListening(c <-chan time.Time) {
 	mh.handlerCtx.startListening(c, mh.checkMemory)
 }
 
-// checkMemory method set context & call usecase CheckMemory method, handle error
+// checkMemory method set context derived from global context & call usecase CheckMemory method, handle error
 func (mh *memoryCheckHandler) checkMemory(t time.Time) {
-	ctx := context.Background()
+	ctx := mh.handlerCtx.chanCancelCtx
 	ctx = context.WithValue(ctx, "time", t)
 
 	if err := mh.mUsecase.CheckMemory(ctx); err != nil {
@@ -1,68 +1,106 @@
 package http
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	"net/http"
+	"strings"
 
 	"github.com/DMS-SMS/v1-health-check/domain"
 )
 
 // syscheckHandler represent the http handler for syscheck
 type syscheckHandler struct {
-	dUsecase domain.DiskCheckUseCase
-	cUsecase domain.CPUCheckUseCase
-	mUsecase domain.MemoryCheckUseCase
+	dUsecases []domain.DiskCheckUseCase
+	cUsecases []domain.CPUCheckUseCase
+	mUsecases []domain.MemoryCheckUseCase
+}
+
+// triggerAuthenticator is interface that authenticate request to endpoint triggering check process
+// you can see implementation in auth package
+type triggerAuthenticator interface {
+	// AuthenticateTrigger return gin middleware which abort request if credential having trigger role is not authenticated
+	AuthenticateTrigger() gin.HandlerFunc
 }
 
 // NewSyscheckHandler initialize the resources of syscheck domain to HTTP API endpoint
-func NewSyscheckHandler(r *gin.Engine, du domain.DiskCheckUseCase, cu domain.CPUCheckUseCase, mu domain.MemoryCheckUseCase) {
+// every endpoint needs trigger role, because check process may run remediation such as removing container
+// endpoint is registered for every check instance with type of that (Ex, system-check/types/disk, system-check/types/disk-secondary)
+func NewSyscheckHandler(r *gin.Engine, ta triggerAuthenticator, dus []domain.DiskCheckUseCase, cus []domain.CPUCheckUseCase, mus []domain.MemoryCheckUseCase) {
 	h := &syscheckHandler{
-		dUsecase: du,
-		cUsecase: cu,
-		mUsecase: mu,
+		dUsecases: dus,
+		cUsecases: cus,
+		mUsecases: mus,
+	}
+
+	for _, du := range h.dUsecases {
+		r.POST("system-check/types/"+routeType(du), ta.AuthenticateTrigger(), h.CheckDisk(du))
+	}
+	for _, cu := range h.cUsecases {
+		r.POST("system-check/types/"+routeType(cu), ta.AuthenticateTrigger(), h.CheckCPU(cu))
 	}
+	for _, mu := range h.mUsecases {
+		r.POST("system-check/types/"+routeType(mu), ta.AuthenticateTrigger(), h.CheckMemory(mu))
+	}
+}
 
-	r.POST("system-check/types/disk", h.CheckDisk)
-	r.POST("system-check/types/cpu", h.CheckCPU)
-	r.POST("system-check/types/memory", h.CheckMemory)
+// CheckDisk method return handler delivering HTTP request to CheckDisk method of domain.DiskCheckUseCase received from parameter
+func (sh *syscheckHandler) CheckDisk(du domain.DiskCheckUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch err := du.CheckDisk(checkContext(c)); err {
+		case nil:
+			c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "finished to check disk status"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": http.StatusInternalServerError, "code": 0,
+				"message": errors.Wrap(err, "failed to check disk status").Error(),
+			})
+		}
+	}
 }
 
-// CheckDisk method deliver HTTP request to CheckDisk method of domain.DiskCheckUseCase
-func (sh *syscheckHandler) CheckDisk(c *gin.Context) {
-	switch err := sh.dUsecase.CheckDisk(c.Request.Context()); err {
-	case nil:
-		c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "finished to check disk status"})
-	default:
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": http.StatusInternalServerError, "code": 0,
-			"message": errors.Wrap(err, "failed to check disk status").Error(),
-		})
+// CheckCPU method return handler delivering HTTP request to CheckCPU method of domain.CPUCheckUseCase received from parameter
+func (sh *syscheckHandler) CheckCPU(cu domain.CPUCheckUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch err := cu.CheckCPU(checkContext(c)); err {
+		case nil:
+			c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "finished to check cpu status"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": http.StatusInternalServerError, "code": 0,
+				"message": errors.Wrap(err, "failed to check cpu status").Error(),
+			})
+		}
 	}
 }
 
-// CheckCPU method deliver HTTP request to CheckCPU method of domain.CPUCheckUseCase
-func (sh *syscheckHandler) CheckCPU(c *gin.Context) {
-	switch err := sh.cUsecase.CheckCPU(c.Request.Context()); err {
-	case nil:
-		c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "finished to check cpu status"})
-	default:
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": http.StatusInternalServerError, "code": 0,
-			"message": errors.Wrap(err, "failed to check cpu status").Error(),
-		})
+// CheckMemory method return handler delivering HTTP request to CheckMemory method of domain.MemoryCheckUseCase received from parameter
+func (sh *syscheckHandler) CheckMemory(mu domain.MemoryCheckUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch err := mu.CheckMemory(checkContext(c)); err {
+		case nil:
+			c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "finished to check memory status"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status": http.StatusInternalServerError, "code": 0,
+				"message": errors.Wrap(err, "failed to check memory status").Error(),
+			})
+		}
 	}
 }
 
-// CheckMemory method deliver HTTP request to CheckMemory method of domain.MemoryCheckUseCase
-func (sh *syscheckHandler) CheckMemory(c *gin.Context) {
-	switch err := sh.mUsecase.CheckMemory(c.Request.Context()); err {
-	case nil:
-		c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "finished to check memory status"})
-	default:
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": http.StatusInternalServerError, "code": 0,
-			"message": errors.Wrap(err, "failed to check memory status").Error(),
-		})
+// checkContext return context of request used in check process, which is derived with dry-run mode if ?dryRun=true in query
+// check process run with dry-run mode compute & record remediation without executing that
+func checkContext(c *gin.Context) context.Context {
+	if c.Query("dryRun") == "true" {
+		return domain.WithDryRun(c.Request.Context())
 	}
+	return c.Request.Context()
+}
+
+// routeType return type of check used in path of HTTP API, which is check type without Check suffix in lower case
+// (Ex, DiskCheck -> disk, swarmpit-sidecar -> swarmpit-sidecar)
+func routeType(reporter domain.CheckStatusReporter) string {
+	return strings.ToLower(strings.TrimSuffix(reporter.Status().Type, "Check"))
 }
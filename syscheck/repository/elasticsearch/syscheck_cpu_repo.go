@@ -20,11 +20,8 @@ import (
 
 // esCPUCheckHistoryRepository is to handle CPUCheckHistory model using elasticsearch as data store
 type esCPUCheckHistoryRepository struct {
-	// esMigrator is used for migrate elasticsearch repository in Migrate method
-	esMigrator esRepositoryMigrator
-
-	// myCfg is used for get cpu check history repository config about elasticsearch
-	myCfg esCPUCheckHistoryRepoConfig
+	// esIndexManager is used for migrating index & deciding index to write history, injected from the outside package
+	esIndexManager indexManager
 
 	// esCli is elasticsearch client connection injected from the outside package
 	esCli *elasticsearch.Client
@@ -33,18 +30,12 @@ type esCPUCheckHistoryRepository struct {
 	bodyWriter reqBodyWriter
 }
 
-// esCPUCheckHistoryRepoConfig is the config for cpu check history repository using elasticsearch
-type esCPUCheckHistoryRepoConfig interface {
-	// get common method from embedding esRepositoryComponentConfig
-	esRepositoryComponentConfig
-}
-
 // NewESCPUCheckHistoryRepository return new object that implement CPUCheckHistoryRepository interface
-func NewESCPUCheckHistoryRepository(cfg esCPUCheckHistoryRepoConfig, cli *elasticsearch.Client, w reqBodyWriter) domain.CPUCheckHistoryRepository {
+func NewESCPUCheckHistoryRepository(im indexManager, cli *elasticsearch.Client, w reqBodyWriter) domain.CPUCheckHistoryRepository {
 	repo := &esCPUCheckHistoryRepository{
-		myCfg:      cfg,
-		esCli:      cli,
-		bodyWriter: w,
+		esIndexManager: im,
+		esCli:          cli,
+		bodyWriter:     w,
 	}
 
 	if err := repo.Migrate(); err != nil {
@@ -56,7 +47,7 @@ func NewESCPUCheckHistoryRepository(cfg esCPUCheckHistoryRepoConfig, cli *elasti
 
 // Implement Migrate method of CPUCheckHistoryRepository interface
 func (esr *esCPUCheckHistoryRepository) Migrate() error {
-	return esr.esMigrator.Migrate(esr.myCfg, esr.esCli, esr.bodyWriter)
+	return esr.esIndexManager.Migrate()
 }
 
 // Implement Store method of CPUCheckHistoryRepository interface
@@ -74,7 +65,7 @@ func (esr *esCPUCheckHistoryRepository) Store(history *domain.CPUCheckHistory) (
 	}
 
 	resp, err := (esapi.IndexRequest{
-		Index:   esr.myCfg.IndexName(),
+		Index:   esr.esIndexManager.WriteIndex(history.Timestamp()),
 		Body:    bytes.NewReader(buf.Bytes()),
 		Timeout: time.Second * 5,
 	}).Do(context.Background(), esr.esCli)
@@ -90,5 +81,6 @@ func (esr *esCPUCheckHistoryRepository) Store(history *domain.CPUCheckHistory) (
 	result := map[string]interface{}{}
 	_ = json.NewDecoder(resp.Body).Decode(&result)
 	b, _ = json.Marshal(result)
+
 	return
 }
@@ -20,11 +20,8 @@ import (
 
 // esDiskCheckHistoryRepository is to handle DiskCheckHistory model using elasticsearch as data store
 type esDiskCheckHistoryRepository struct {
-	// esMigrator is used for migrate elasticsearch repository in Migrate method
-	esMigrator esRepositoryMigrator
-
-	// myCfg is used for get disk check history repository config about elasticsearch
-	myCfg esDiskCheckHistoryRepoConfig
+	// esIndexManager is used for migrating index & deciding index to write history, injected from the outside package
+	esIndexManager indexManager
 
 	// esCli is elasticsearch client connection injected from the outside package
 	esCli *elasticsearch.Client
@@ -33,18 +30,12 @@ type esDiskCheckHistoryRepository struct {
 	bodyWriter reqBodyWriter
 }
 
-// esDiskCheckHistoryRepoConfig is the config for disk check history repository using elasticsearch
-type esDiskCheckHistoryRepoConfig interface {
-	// get common method from embedding esRepositoryComponentConfig
-	esRepositoryComponentConfig
-}
-
 // NewESDiskCheckHistoryRepository return new object that implement DiskCheckHistory.Repository interface
-func NewESDiskCheckHistoryRepository(cfg esDiskCheckHistoryRepoConfig, cli *elasticsearch.Client, w reqBodyWriter) domain.DiskCheckHistoryRepository {
+func NewESDiskCheckHistoryRepository(im indexManager, cli *elasticsearch.Client, w reqBodyWriter) domain.DiskCheckHistoryRepository {
 	repo := &esDiskCheckHistoryRepository{
-		myCfg:      cfg,
-		esCli:      cli,
-		bodyWriter: w,
+		esIndexManager: im,
+		esCli:          cli,
+		bodyWriter:     w,
 	}
 
 	if err := repo.Migrate(); err != nil {
@@ -56,7 +47,7 @@ func NewESDiskCheckHistoryRepository(cfg esDiskCheckHistoryRepoConfig, cli *elas
 
 // Implement Migrate method of DiskCheckHistoryRepository interface
 func (edr *esDiskCheckHistoryRepository) Migrate() error {
-	return edr.esMigrator.Migrate(edr.myCfg, edr.esCli, edr.bodyWriter)
+	return edr.esIndexManager.Migrate()
 }
 
 // Implement Store method of DiskCheckHistoryRepository interface
@@ -74,7 +65,7 @@ func (edr *esDiskCheckHistoryRepository) Store(history *domain.DiskCheckHistory)
 	}
 
 	resp, err := (esapi.IndexRequest{
-		Index:   edr.myCfg.IndexName(),
+		Index:   edr.esIndexManager.WriteIndex(history.Timestamp()),
 		Body:    bytes.NewReader(buf.Bytes()),
 		Timeout: time.Second * 5,
 	}).Do(context.Background(), edr.esCli)
@@ -90,5 +81,6 @@ func (edr *esDiskCheckHistoryRepository) Store(history *domain.DiskCheckHistory)
 	result := map[string]interface{}{}
 	_ = json.NewDecoder(resp.Body).Decode(&result)
 	b, _ = json.Marshal(result)
+
 	return
 }
@@ -20,11 +20,8 @@ import (
 
 // esMemoryCheckHistoryRepository is to handle MemoryCheckHistory model using elasticsearch as data store
 type esMemoryCheckHistoryRepository struct {
-	// esMigrator is used for migrate elasticsearch repository in Migrate method
-	esMigrator esRepositoryMigrator
-
-	// myCfg is used for get memory check history repository config about elasticsearch
-	myCfg esMemoryCheckHistoryRepoConfig
+	// esIndexManager is used for migrating index & deciding index to write history, injected from the outside package
+	esIndexManager indexManager
 
 	// esCli is elasticsearch client connection injected from the outside package
 	esCli *elasticsearch.Client
@@ -33,18 +30,12 @@ type esMemoryCheckHistoryRepository struct {
 	bodyWriter reqBodyWriter
 }
 
-// esMemoryCheckHistoryRepoConfig is the config for memory check history repository using elasticsearch
-type esMemoryCheckHistoryRepoConfig interface {
-	// get common method from embedding esRepositoryComponentConfig
-	esRepositoryComponentConfig
-}
-
 // NewESMemoryCheckHistoryRepository return new object that implement MemoryCheckHistoryRepository interface
-func NewESMemoryCheckHistoryRepository(cfg esMemoryCheckHistoryRepoConfig, cli *elasticsearch.Client, w reqBodyWriter) domain.MemoryCheckHistoryRepository {
+func NewESMemoryCheckHistoryRepository(im indexManager, cli *elasticsearch.Client, w reqBodyWriter) domain.MemoryCheckHistoryRepository {
 	repo := &esMemoryCheckHistoryRepository{
-		myCfg:      cfg,
-		esCli:      cli,
-		bodyWriter: w,
+		esIndexManager: im,
+		esCli:          cli,
+		bodyWriter:     w,
 	}
 
 	if err := repo.Migrate(); err != nil {
@@ -56,7 +47,7 @@ func NewESMemoryCheckHistoryRepository(cfg esMemoryCheckHistoryRepoConfig, cli *
 
 // Implement Migrate method of MemoryCheckHistoryRepository interface
 func (emr *esMemoryCheckHistoryRepository) Migrate() error {
-	return emr.esMigrator.Migrate(emr.myCfg, emr.esCli, emr.bodyWriter)
+	return emr.esIndexManager.Migrate()
 }
 
 // Implement Store method of MemoryCheckHistoryRepository interface
@@ -74,7 +65,7 @@ func (emr *esMemoryCheckHistoryRepository) Store(history *domain.MemoryCheckHist
 	}
 
 	resp, err := (esapi.IndexRequest{
-		Index:   emr.myCfg.IndexName(),
+		Index:   emr.esIndexManager.WriteIndex(history.Timestamp()),
 		Body:    bytes.NewReader(buf.Bytes()),
 		Timeout: time.Second * 5,
 	}).Do(context.Background(), emr.esCli)
@@ -90,5 +81,6 @@ func (emr *esMemoryCheckHistoryRepository) Store(history *domain.MemoryCheckHist
 	result := map[string]interface{}{}
 	_ = json.NewDecoder(resp.Body).Decode(&result)
 	b, _ = json.Marshal(result)
+
 	return
 }
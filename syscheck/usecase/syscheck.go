@@ -9,10 +9,15 @@
 package usecase
 
 import (
+	"context"
+	"fmt"
 	"github.com/docker/docker/api/types"
 	"github.com/inhies/go-bytesize"
-	"github.com/slack-go/slack"
+	"os"
+	"strings"
 	"time"
+
+	"github.com/DMS-SMS/v1-health-check/domain"
 )
 
 // global variable used in usecase which is type of processLevel
@@ -24,6 +29,14 @@ const (
 	recoveredLevel    = "RECOVERED"     // represent that succeed to recover system status
 	unhealthyLevel    = "UNHEALTHY"     // represent that system status is unhealthy now (not recovered)
 	errorLevel        = "ERROR"         // represent that error occurs while checking system status
+	acknowledgedLevel = "ACKNOWLEDGED"  // represent that unhealthy system status is acknowledged by operator
+	resetLevel        = "RESET"         // represent that system check status is reset to healthy by operator
+	pausedLevel       = "PAUSED"        // represent that system check is paused by maintenance window
+	suppressedLevel   = "SUPPRESSED"    // represent that remediation of system weak is suppressed by maintenance window
+	skippedLevel      = "SKIPPED"       // represent that system check is skipped because previous one is running
+	timeoutLevel      = "TIMEOUT"       // represent that system check is stopped because of run timeout or cancel
+	simulatedLevel    = "SIMULATED"     // represent that remediation is simulated without executing in dry-run mode
+	escalatedLevel    = "ESCALATED"     // represent that reminder about unhealthy status is sent by escalation policy
 )
 
 // requiredContainers contain docker container names which must not stop or kill
@@ -39,19 +52,301 @@ var requiredContainers = []string{
 }
 
 // systemCheckUsecaseComponent contains required component to syscheck usecase implementation as field
-type systemCheckUsecaseComponentConfig interface{}
+type systemCheckUsecaseComponentConfig interface {
+	// DryRun method returns if remediation in check process is simulated without executing
+	DryRun() bool
+
+	// EscalationPolicy method returns policy about sending reminder of check staying unhealthy without acknowledgement
+	EscalationPolicy() domain.EscalationPolicy
+
+	// CheckType method returns type tag of check instance, which is used in history, status, schedule & alarm (Ex, DiskCheck)
+	CheckType() string
+}
+
+// isDryRun return if remediation should be simulated without executing, with config or context of check process
+// dry-run mode is enabled by config or by context derived with domain.WithDryRun function (Ex, ?dryRun=true in request)
+func isDryRun(ctx context.Context, cfg systemCheckUsecaseComponentConfig) bool {
+	return cfg.DryRun() || domain.IsDryRun(ctx)
+}
+
+// historyObserver is interface that observe check history after check process is finished
+// you can see implementation in prometheus, broker package
+type historyObserver interface {
+	// ObserveHistory observe check history with duration spent in check process
+	ObserveHistory(history domain.CheckHistory, duration time.Duration)
+}
+
+// clock is interface that return current time in configured timezone, so that time can be faked in test
+// you can see implementation in clock package
+type clock interface {
+	// Now method returns current time in configured timezone
+	Now() time.Time
+}
+
+// checkExecutor is interface that run check process with single flight policy & run timeout of execution config
+// you can see implementation in executor package
+type checkExecutor interface {
+	// Execute method run check function & handle history of that with handle function
+	// history created from fallback function with level is handled instead if check process is skipped or timed out
+	Execute(
+		ctx context.Context,
+		check func(ctx context.Context) domain.CheckHistory,
+		fallback func(level, message string) domain.CheckHistory,
+		handle func(history domain.CheckHistory, duration time.Duration) error,
+	) error
+}
+
+// maintenanceAgency is interface that agent maintenance window pausing check or suppressing remediation
+// you can see implementation in maintenance package
+type maintenanceAgency interface {
+	// IsPaused return if check with domain & type is paused now, and reason of active maintenance window
+	IsPaused(domain, _type string) (paused bool, reason string)
+
+	// IsRemediationSuppressed return if remediation of check with domain & type is suppressed now, and reason
+	IsRemediationSuppressed(domain, _type string) (suppressed bool, reason string)
+}
+
+// checkRecord is struct having information about status transition & latest check process of usecase
+// it is used in Status method of every usecase, and must be accessed while holding mutex of usecase
+type checkRecord struct {
+	// domain, _type specifies domain & check type of usecase having this record
+	domain, _type string
+
+	// kind specifies kind of check run in usecase, which is different from _type in named check instance (Ex, DiskCheck)
+	kind string
+
+	// statusSince specifies the time when status of usecase was changed lastly
+	statusSince time.Time
+
+	// lastHistory specifies check history created in latest check process
+	lastHistory domain.CheckHistory
+
+	// acknowledgedBy specifies operator who acknowledged current unhealthy status, cleared when status is changed
+	acknowledgedBy string
+
+	// node specifies host name of node which check process is run in, set to node field of alarm
+	node string
+
+	// incident specifies current incident of usecase, ID of that is set to incident field of alarm
+	// it's kept until check history resolving incident is tracked, so that alarm about recovery is grouped into it
+	incident *domain.Incident
+
+	// incidentOpen specifies if current incident is not resolved yet, it's open while status of usecase isn't healthy
+	incidentOpen bool
+
+	// lastHealthyAt specifies the time when check process was healthy lastly, used for time to detect of incident
+	lastHealthyAt time.Time
+
+	// unhealthyRuns specifies number of check runs in current unhealthy status, used for escalation policy
+	unhealthyRuns int
+
+	// escalations specifies number of reminders sent about current unhealthy status
+	escalations int
+
+	// escalatedAt specifies the time when reminder about current unhealthy status was sent lastly
+	escalatedAt time.Time
+
+	// simulated specifies if remediation was simulated in dry-run mode since check process was healthy lastly
+	// alarm about simulated remediation is sent once until check process is healthy again, not in every check run
+	simulated bool
+
+	// clock is used for getting current time in configured timezone, which is injected from usecase
+	clock clock
+}
+
+// newCheckRecord return new checkRecord instance with domain & check kind & check type & clock of usecase
+func newCheckRecord(domain, kind, _type string, c clock) checkRecord {
+	node, _ := os.Hostname()
+	return checkRecord{
+		domain:      domain,
+		_type:       _type,
+		kind:        kind,
+		statusSince: c.Now(),
+		node:        node,
+		clock:       c,
+	}
+}
+
+// statusChanged update record with status transition, it should be called when status of usecase is changed
+// current incident is resolved if status is changed to healthy, and new incident is opened if status leave healthy
+func (cr *checkRecord) statusChanged(healthy bool) {
+	cr.statusSince = cr.clock.Now()
+	cr.acknowledgedBy = ""
+	cr.unhealthyRuns, cr.escalations = 0, 0
+	if !healthy && !cr.incidentOpen {
+		cr.incident = nil
+	}
+	cr.incidentOpen = !healthy
+}
+
+// openIncident open new incident of usecase with uuid of check process if status left healthy & incident isn't opened yet
+func (cr *checkRecord) openIncident(uuid string, t time.Time) {
+	if !cr.incidentOpen || (cr.incident != nil && cr.incident.State() == domain.IncidentStateOpen) {
+		return
+	}
+	cr.incident = &domain.Incident{ID: uuid, Domain: cr.domain, Type: cr._type, OpenedAt: t, LastHealthyAt: cr.lastHealthyAt}
+}
+
+// track update current incident with check history of check process or operation, it should be called for every history
+// incident is closed with history tracked after status of usecase became healthy (Ex, recovered, reset by operator)
+// copy of updated incident is returned to be stored, and ok is false if history isn't related to any incident
+func (cr *checkRecord) track(history domain.CheckHistory) (incident domain.Incident, ok bool) {
+	m := history.DottedMapWithPrefix("")
+	_uuid, _ := m["uuid"].(string)
+	levels := history.ProcessLevels()
+	if contains(levels, healthyLevel) || contains(levels, recoveredLevel) {
+		cr.lastHealthyAt = history.Timestamp()
+		cr.simulated = false
+	}
+
+	cr.openIncident(_uuid, history.Timestamp())
+	if cr.incident == nil || cr.incident.State() != domain.IncidentStateOpen || contains(levels, skippedLevel) {
+		return
+	}
+
+	if cr.incident.ID == _uuid && len(levels) > 0 {
+		cr.incident.OpenedAt = history.Timestamp()
+		cr.incident.OpenedLevel = levels[0]
+	}
+	cr.incident.Runs = append(cr.incident.Runs, _uuid)
+	for _, action := range history.Remediations() {
+		cr.incident.Remediations = append(cr.incident.Remediations, domain.IncidentRemediation{
+			UUID: _uuid, Time: history.Timestamp(), Action: action, Simulated: contains(levels, simulatedLevel),
+		})
+	}
+
+	if !cr.incidentOpen {
+		cr.incident.ClosedAt = cr.clock.Now()
+		cr.incident.ClosedLevel = recoveredLevel
+		if contains(levels, resetLevel) {
+			cr.incident.ClosedLevel = resetLevel
+			cr.incident.ClosedBy, _ = m["operator"].(string)
+		}
+	}
+	return cr.incident.Copy(), true
+}
+
+// simulate mark remediation of check process as simulated in dry-run mode without changing status of usecase
+// it returns true only for the first simulation since check process was healthy, in which alarm should be sent
+func (cr *checkRecord) simulate() (first bool) {
+	first = !cr.simulated
+	cr.simulated = true
+	return
+}
+
+// alarm return domain.Alarm sent from check process of usecase having this record with level, emoji, text & uuid
+// fields is detail of alarm with domain.AlarmField key, and field with empty value is not set in alarm
+// alarm is grouped into current incident until incident is closed, and added to alarms of that incident
+// alarm sent while there is no incident (Ex, error alarm in healthy status) doesn't have incident
+func (cr *checkRecord) alarm(level, emoji, text, uuid string, fields map[string]string) domain.Alarm {
+	alarm := domain.Alarm{Domain: cr.domain, Type: cr._type, Kind: cr.kind, Level: level, Emoji: emoji, Text: text, UUID: uuid}
+	cr.openIncident(uuid, cr.clock.Now())
+	if cr.incident != nil && cr.incident.State() == domain.IncidentStateOpen {
+		alarm.Incident = cr.incident.ID
+		cr.incident.Alarms = append(cr.incident.Alarms, domain.IncidentAlarm{UUID: uuid, Time: cr.clock.Now(), Level: level, Text: text})
+	}
+	alarm.Resolved = !cr.incidentOpen
+
+	alarm.Fields = map[string]string{}
+	for key, value := range fields {
+		if value != "" {
+			alarm.Fields[key] = value
+		}
+	}
+	if cr.node != "" {
+		alarm.Fields[domain.AlarmFieldNode] = cr.node
+	}
+	return alarm
+}
+
+// reminder return reminder alarm about current unhealthy status if it should be sent now by escalation policy
+// it should be called in every check run in unhealthy status, and reminder is not sent after status is acknowledged
+// reminder is sent to channel with mention of escalation policy, and is grouped into current incident
+func (cr *checkRecord) reminder(policy domain.EscalationPolicy, uuid string, fields map[string]string) (alarm domain.Alarm, ok bool) {
+	cr.unhealthyRuns++
+	if !policy.Enabled() || cr.acknowledgedBy != "" {
+		return
+	}
+
+	now := cr.clock.Now()
+	unhealthyFor := now.Sub(cr.statusSince)
+	if cr.escalations == 0 {
+		ok = (policy.After > 0 && unhealthyFor >= policy.After) || (policy.AfterRuns > 0 && cr.unhealthyRuns >= policy.AfterRuns)
+	} else {
+		interval := policy.IntervalAfter(cr.escalations)
+		ok = interval > 0 && now.Sub(cr.escalatedAt) >= interval
+	}
+	if !ok {
+		return
+	}
+
+	cr.escalations++
+	cr.escalatedAt = now
+	check := strings.ToLower(strings.TrimSuffix(cr._type, "Check"))
+	text := fmt.Sprintf("!%s check is still unhealthy! unhealthy for %s since %s, please check for yourself (reminder #%d)",
+		check, unhealthyFor.Round(time.Second), cr.statusSince.Format("15:04:05"), cr.escalations)
+	alarm = cr.alarm(escalatedLevel, "rotating_light", text, uuid, fields)
+	alarm.Channel, alarm.Mention = policy.Channel, policy.Mention
+	return
+}
+
+// componentKeys is key list of dotted map which is common in every check history, so is excluded from last values
+var componentKeys = []string{
+	"version", "agent", "@timestamp", "domain", "type", "kind", "uuid", "process_level", "message", "error",
+	"alerted", "alarm_text", "alarm_time", "alarm_error", "operator", "operation_reason", "alarm_results", "incident",
+	"simulated", "alarm_suppress_reason",
+}
+
+// status return domain.CheckStatus with current state received from parameter & latest check history
+func (cr checkRecord) status(state string) (status domain.CheckStatus) {
+	status = domain.CheckStatus{
+		Domain:         cr.domain,
+		Type:           cr._type,
+		State:          state,
+		StateSince:     cr.statusSince,
+		LastValues:     map[string]interface{}{},
+		AcknowledgedBy: cr.acknowledgedBy,
+	}
+
+	if cr.lastHistory == nil {
+		return
+	}
+
+	m := cr.lastHistory.DottedMapWithPrefix("")
+	status.LastRunTime = cr.lastHistory.Timestamp()
+	status.LastUUID, _ = m["uuid"].(string)
+	status.LastProcessLevel, _ = m["process_level"].(string)
+
+	for k, v := range m {
+		status.LastValues[k] = v
+	}
+	for _, k := range componentKeys {
+		delete(status.LastValues, k)
+	}
+	return
+}
+
+// contains return if process levels contains level
+func contains(levels []string, level string) bool {
+	for _, l := range levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
 
-// slackChatAgency is interface that agent the slack api about chatting
-// you can see implementation in slack package
-type slackChatAgency interface {
-	// SendMessage send message with text & emoji using slack API and return send time & text & error
-	SendMessage(emoji, text, uuid string, opts ...slack.MsgOption) (t time.Time, _text string, err error)
+// notifyAgency is interface that agent notifier backends sending alarm of check process (Ex, slack, webhook, email)
+// you can see implementation in notify package
+type notifyAgency interface {
+	// Notify send alarm to every notifier backend whose filter is matched with alarm, and return result of each backend
+	Notify(ctx context.Context, alarm domain.Alarm) domain.AlarmResults
 }
 
 // dockerAgency is agency that agent various command about cpu system
 type dockerAgency interface {
 	// RemoveContainer remove container with id & option (auto created from docker swarm if exists)
-	RemoveContainer(containerID string, options types.ContainerRemoveOptions) error
+	RemoveContainer(ctx context.Context, containerID string, options types.ContainerRemoveOptions) error
 }
 
 // bytesizeComparator is struct type having bytesize.ByteSize type field which is used for compare with another bytesize.ByteSize
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"sync"
+	"time"
 
 	"github.com/DMS-SMS/v1-health-check/domain"
 )
@@ -25,6 +26,22 @@ const (
 	cpuStatusUnhealthy                        // represent cpu check status is unhealthy
 )
 
+// String method return domain.CheckState const value matched with cpu check status
+func (s cpuCheckStatus) String() string {
+	switch s {
+	case cpuStatusHealthy:
+		return domain.CheckStateHealthy
+	case cpuStatusWarning:
+		return domain.CheckStateWarning
+	case cpuStatusRecovering:
+		return domain.CheckStateRecovering
+	case cpuStatusUnhealthy:
+		return domain.CheckStateUnhealthy
+	default:
+		return "UNKNOWN"
+	}
+}
+
 // cpuCheckUsecase implement CPUCheckUsecase interface in domain and used in delivery layer
 type cpuCheckUsecase struct {
 	// myCfg is used for getting cpu check usecase config
@@ -33,8 +50,20 @@ type cpuCheckUsecase struct {
 	// historyRepo is used for store cpu check history and injected from outside
 	historyRepo domain.CPUCheckHistoryRepository
 
-	// slackChat is used for agent slack API about chatting
-	slackChatAgency slackChatAgency
+	// incidentRepo is used for store incident of cpu check and injected from outside
+	incidentRepo domain.IncidentRepository
+
+	// historyObserver is used for observing check history after check process (ex, recording metrics)
+	historyObserver historyObserver
+
+	// maintenanceAgency is used as agency about maintenance window pausing check or suppressing remediation
+	maintenanceAgency maintenanceAgency
+
+	// notifyAgency is used as agency about notifier backends sending alarm (Ex, slack, webhook, email)
+	notifyAgency notifyAgency
+
+	// clock is used for getting current time in configured timezone, which is set to timestamp of check history
+	clock clock
 
 	// cpuSysAgency is used as agency about cpu system command
 	cpuSysAgency cpuSysAgency
@@ -45,6 +74,15 @@ type cpuCheckUsecase struct {
 	// status represent current process status of cpu health check
 	status cpuCheckStatus
 
+	// executor is used for running check process with single flight policy & run timeout
+	executor checkExecutor
+
+	// thresholds represent thresholds snapshot taken at the start of latest check process, which is shown in alarm
+	thresholds domain.CPUCheckThresholds
+
+	// record represent status transition & latest check process of cpu health check
+	record checkRecord
+
 	// mutex help to prevent race condition when set status field value
 	mutex sync.Mutex
 }
@@ -54,23 +92,17 @@ type cpuCheckUsecaseConfig interface {
 	// get common config method from embedding systemCheckUsecaseComponentConfig
 	systemCheckUsecaseComponentConfig
 
-	// CPUWarningUsage method returns float64 represent cpu warning usage
-	CPUWarningUsage() float64
-
-	// CPUMaximumUsage method returns float64 represent cpu maximum usage
-	CPUMaximumUsage() float64
-
-	// CPUMinimumUsageToRemove method returns float64 represent cpu minimum usage to remove
-	CPUMinimumUsageToRemove() float64
+	// CPUCheckThresholds method returns thresholds snapshot of cpu check, which is read at once in every check process
+	CPUCheckThresholds() domain.CPUCheckThresholds
 }
 
 // cpuSysAgency is agency that agent various command about cpu system
 type cpuSysAgency interface {
 	// GetTotalSystemCPUUsage return total cpu usage as core count in system
-	GetTotalSystemCPUUsage() (usage float64, err error)
+	GetTotalSystemCPUUsage(ctx context.Context) (usage float64, err error)
 
 	// CalculateContainersCPUUsage calculate container cpu usage & return result interface implementation
-	CalculateContainersCPUUsage() (result interface {
+	CalculateContainersCPUUsage(ctx context.Context) (result interface {
 		// TotalCPUUsage return total cpu usage in docker containers
 		TotalCPUUsage() (usage float64)
 
@@ -83,34 +115,59 @@ type cpuSysAgency interface {
 func NewCPUCheckUsecase(
 	cfg cpuCheckUsecaseConfig,
 	chr domain.CPUCheckHistoryRepository,
-	sca slackChatAgency,
+	ir domain.IncidentRepository,
+	ho historyObserver,
+	ma maintenanceAgency,
+	na notifyAgency,
+	c clock,
+	ce checkExecutor,
 	csa cpuSysAgency,
 	da dockerAgency,
 ) domain.CPUCheckUseCase {
 	return &cpuCheckUsecase{
 		// initialize field with parameter received from caller
-		myCfg:           cfg,
-		historyRepo:     chr,
-		slackChatAgency: sca,
-		cpuSysAgency:    csa,
-		dockerAgency:    da,
+		myCfg:             cfg,
+		historyRepo:       chr,
+		incidentRepo:      ir,
+		historyObserver:   ho,
+		maintenanceAgency: ma,
+		notifyAgency:      na,
+		clock:             c,
+		executor:          ce,
+		cpuSysAgency:      csa,
+		dockerAgency:      da,
 
 		// initialize field with default value
-		status: cpuStatusHealthy,
-		mutex:  sync.Mutex{},
+		status:     cpuStatusHealthy,
+		thresholds: cfg.CPUCheckThresholds(),
+		record:     newCheckRecord("syscheck", "CPUCheck", cfg.CheckType(), c),
+		mutex:      sync.Mutex{},
 	}
 }
 
-// CheckCPU check cpu health with checkCPU method & store check history in repository
+// CheckCPU check cpu health with checkCPU method in executor & store check history in repository
 // Implement CheckCPU method of domain.CPUCheckUseCase interface
 func (cu *cpuCheckUsecase) CheckCPU(ctx context.Context) error {
-	history := cu.checkCPU(ctx)
+	return cu.executor.Execute(ctx, func(ctx context.Context) domain.CheckHistory {
+		return cu.checkCPU(ctx)
+	}, func(level, message string) domain.CheckHistory {
+		history := cu.newOperationHistory(level, "", "")
+		history.Message = message
+		return history
+	}, cu.handleHistory)
+}
+
+// handleHistory observe cpu check history with duration, set to record & store in repository
+func (cu *cpuCheckUsecase) handleHistory(history domain.CheckHistory, duration time.Duration) error {
+	incidentErr := cu.trackIncident(history.(*domain.CPUCheckHistory))
+	cu.historyObserver.ObserveHistory(history, duration)
+	cu.setLastHistory(history)
 
-	if b, err := cu.historyRepo.Store(history); err != nil {
+	if b, err := cu.historyRepo.Store(history.(*domain.CPUCheckHistory)); err != nil {
 		return errors.Wrapf(err, "failed to store cpu check history, response: %s", string(b))
 	}
 
-	return nil
+	return incidentErr
 }
 
 // method with below logic about handling health check process according to current cpu check status
@@ -124,17 +181,26 @@ func (cu *cpuCheckUsecase) CheckCPU(ctx context.Context) error {
 // 3 : 관리자가 직접 확인해야함 (상태 확인 수행 X)
 // 3 -> 0 : 관리자 직접 상태 회복 완료 (상태 회복 알림 발행)
 func (cu *cpuCheckUsecase) checkCPU(ctx context.Context) (history *domain.CPUCheckHistory) {
+	thresholds := cu.takeThresholds()
+
 	_uuid := uuid.New().String()
 	history = new(domain.CPUCheckHistory)
-	history.FillPrivateComponent()
+	history.FillPrivateComponent(cu.clock.Now())
+	history.SetType(cu.record._type)
 	history.UUID = _uuid
 
-	_totalUsage, err := cu.cpuSysAgency.GetTotalSystemCPUUsage()
+	if paused, reason := cu.maintenanceAgency.IsPaused(cu.record.domain, cu.record._type); paused {
+		history.ProcessLevel.Set(pausedLevel)
+		history.Message = "cpu check is paused by maintenance window, reason: " + reason
+		return
+	}
+
+	_totalUsage, err := cu.cpuSysAgency.GetTotalSystemCPUUsage(ctx)
 	if err != nil {
 		history.ProcessLevel.Set(errorLevel)
 		history.SetError(errors.Wrap(err, "failed to get total system cpu usage"))
 		msg := "!cpu check error occurred! unable to get total cpu usage"
-		history.SetAlarmResult(cu.slackChatAgency.SendMessage("x", msg, _uuid))
+		history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, errorLevel, "x", msg)))
 		return
 	}
 	history.TotalUsageCore = _totalUsage
@@ -144,7 +210,7 @@ func (cu *cpuCheckUsecase) checkCPU(ctx context.Context) (history *domain.CPUChe
 	case cpuStatusHealthy:
 		break
 	case cpuStatusWarning:
-		if totalUsage.isLessThan(cu.myCfg.CPUWarningUsage()) {
+		if totalUsage.isLessThan(thresholds.WarningUsage) {
 			cu.setStatus(cpuStatusHealthy)
 		}
 	case cpuStatusRecovering:
@@ -152,31 +218,46 @@ func (cu *cpuCheckUsecase) checkCPU(ctx context.Context) (history *domain.CPUChe
 		history.Message = "provisioning CPU is already on process using docker"
 		return
 	case cpuStatusUnhealthy:
-		if totalUsage.isLessThan(cu.myCfg.CPUMaximumUsage()) {
+		if totalUsage.isLessThan(thresholds.MaximumUsage) {
 			cu.setStatus(cpuStatusHealthy)
 			history.ProcessLevel.Set(recoveredLevel)
 			history.Message = "cpu check is recovered to be healthy"
 			msg := fmt.Sprintf("!cpu check recovered to health! current cpu usage - %.02f", totalUsage.V)
-			_, _, _ = cu.slackChatAgency.SendMessage("heart", msg, _uuid)
+			history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, recoveredLevel, "heart", msg)))
 		} else {
 			history.ProcessLevel.Set(unhealthyLevel)
 			history.Message = "cpu check is unhealthy now"
+			if alarm, ok := cu.reminder(history); ok {
+				history.ProcessLevel.Append(escalatedLevel)
+				history.AddAlarmResults(cu.notifyAgency.Notify(ctx, alarm))
+			}
 		}
 		return
 	}
 
-	if totalUsage.isMoreThan(cu.myCfg.CPUMaximumUsage()) {
+	if totalUsage.isMoreThan(thresholds.MaximumUsage) {
+		if suppressed, reason := cu.maintenanceAgency.IsRemediationSuppressed(cu.record.domain, cu.record._type); suppressed {
+			history.ProcessLevel.Set(suppressedLevel)
+			history.Message = "cpu weak is detected, but remediation is suppressed by maintenance window, reason: " + reason
+			return
+		}
+
+		if isDryRun(ctx, cu.myCfg) {
+			cu.simulateRemoval(ctx, history, thresholds)
+			return
+		}
+
 		cu.setStatus(cpuStatusRecovering)
 		history.ProcessLevel.Set(weakDetectedLevel)
 		msg := fmt.Sprintf("!cpu check weak detected! start to provision CPU (current cpu usage - %.02f)", totalUsage.V)
-		history.SetAlarmResult(cu.slackChatAgency.SendMessage("pill", msg, _uuid))
+		history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, weakDetectedLevel, "pill", msg)))
 
-		result, err := cu.cpuSysAgency.CalculateContainersCPUUsage()
+		result, err := cu.cpuSysAgency.CalculateContainersCPUUsage(ctx)
 		if err != nil {
 			cu.setStatus(cpuStatusUnhealthy)
 			history.ProcessLevel.Append(errorLevel)
 			msg := "!cpu check error occurred! failed to calculate container cpu, please check for yourself"
-			_, _, _ = cu.slackChatAgency.SendMessage("anger", msg, _uuid)
+			history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, errorLevel, "anger", msg)))
 			history.SetError(errors.Wrap(err, "failed to calculate containers cpu usage"))
 			return
 		}
@@ -186,19 +267,19 @@ func (cu *cpuCheckUsecase) checkCPU(ctx context.Context) (history *domain.CPUChe
 		history.MostCPUConsumeContainer = name
 		var usage = float64Comparator{V: _usage}
 
-		if usage.isLessThan(cu.myCfg.CPUMinimumUsageToRemove()) {
+		if usage.isLessThan(thresholds.MinimumUsageToRemove) {
 			cu.setStatus(cpuStatusUnhealthy)
 			msg := "!cpu check error occurred! cpu usage is too small to remove, please check for yourself"
-			_, _, _ = cu.slackChatAgency.SendMessage("anger", msg, _uuid)
+			history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, errorLevel, "anger", msg)))
 			history.SetError(errors.New("cpu usage is too small to remove"))
 			return
 		}
 
-		if err := cu.dockerAgency.RemoveContainer(id, types.ContainerRemoveOptions{Force: true}); err != nil {
+		if err := cu.dockerAgency.RemoveContainer(ctx, id, types.ContainerRemoveOptions{Force: true}); err != nil {
 			cu.setStatus(cpuStatusUnhealthy)
 			history.ProcessLevel.Append(errorLevel)
 			msg := "!cpu check error occurred! failed to remove container, please check for yourself"
-			_, _, _ = cu.slackChatAgency.SendMessage("anger", msg, _uuid)
+			history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, errorLevel, "anger", msg)))
 			history.SetError(errors.Wrap(err, "failed to remove container"))
 			return
 		} else {
@@ -206,33 +287,33 @@ func (cu *cpuCheckUsecase) checkCPU(ctx context.Context) (history *domain.CPUChe
 			history.Message = "removed most cpu consumed container as cpu usage is over than maximum"
 		}
 
-		_againTotalUsage, err := cu.cpuSysAgency.GetTotalSystemCPUUsage()
+		_againTotalUsage, err := cu.cpuSysAgency.GetTotalSystemCPUUsage(ctx)
 		if err != nil {
 			cu.setStatus(cpuStatusUnhealthy)
 			history.ProcessLevel.Append(errorLevel)
 			msg := "!cpu check error occurred! failed to again calculate container cpu, please check for yourself"
-			_, _, _ = cu.slackChatAgency.SendMessage("broken_heart", msg, _uuid)
+			history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, unhealthyLevel, "broken_heart", msg)))
 			history.SetError(errors.Wrap(err, "failed to again calculate containers cpu usage"))
 			return
 		}
 		var againTotalUsage = float64Comparator{V: _againTotalUsage}
 
-		if againTotalUsage.isLessThan(cu.myCfg.CPUMaximumUsage()) {
+		if againTotalUsage.isLessThan(thresholds.MaximumUsage) {
 			cu.setStatus(cpuStatusHealthy)
 			msg := fmt.Sprintf("!cpu check is healthy! current cpu usage - %.02f", againTotalUsage.V)
-			_, _, _ = cu.slackChatAgency.SendMessage("heart", msg, _uuid)
+			history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, recoveredLevel, "heart", msg)))
 		} else {
 			cu.setStatus(cpuStatusUnhealthy)
 			msg := "!cpu check has deteriorated! please check for yourself"
-			_, _, _ = cu.slackChatAgency.SendMessage("broken_heart", msg, _uuid)
+			history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, unhealthyLevel, "broken_heart", msg)))
 		}
-	} else if totalUsage.isMoreThan(cu.myCfg.CPUWarningUsage()) {
+	} else if totalUsage.isMoreThan(thresholds.WarningUsage) {
 		history.ProcessLevel.Set(warningLevel)
 		history.Message = "cpu check is warning now, but not weak yet"
 		if cu.status != cpuStatusWarning {
 			cu.setStatus(cpuStatusWarning)
 			msg := fmt.Sprintf("!cpu check warning! current cpu usage - %.02f", totalUsage.V)
-			history.SetAlarmResult(cu.slackChatAgency.SendMessage("warning", msg, _uuid))
+			history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, warningLevel, "warning", msg)))
 		}
 	} else {
 		history.ProcessLevel.Set(healthyLevel)
@@ -242,9 +323,178 @@ func (cu *cpuCheckUsecase) checkCPU(ctx context.Context) (history *domain.CPUChe
 	return
 }
 
+// simulateRemoval simulate removing most cpu consumed container in dry-run mode, without changing status of cpu check
+// simulation is recorded in history of every check run, but alarm about that is sent once until cpu check is healthy again
+func (cu *cpuCheckUsecase) simulateRemoval(ctx context.Context, history *domain.CPUCheckHistory, thresholds domain.CPUCheckThresholds) {
+	history.SetSimulated()
+	history.ProcessLevel.Set(simulatedLevel)
+
+	result, err := cu.cpuSysAgency.CalculateContainersCPUUsage(ctx)
+	if err != nil {
+		history.ProcessLevel.Append(errorLevel)
+		history.SetError(errors.Wrap(err, "failed to calculate containers cpu usage"))
+		return
+	}
+	history.DockerUsageCore = result.TotalCPUUsage()
+
+	_, name, _usage := result.MostConsumerExceptFor(requiredContainers)
+	history.MostCPUConsumeContainer = name
+	var usage = float64Comparator{V: _usage}
+
+	if usage.isLessThan(thresholds.MinimumUsageToRemove) {
+		history.ProcessLevel.Append(errorLevel)
+		history.SetError(errors.New("cpu usage is too small to remove"))
+		return
+	}
+
+	history.Message = fmt.Sprintf("removing most cpu consumed container is simulated in dry-run mode, container: %s", name)
+	if cu.simulate() {
+		msg := fmt.Sprintf("!cpu check dry-run! container %s would be removed, but not executed (cpu usage - %.02f)", name, usage.V)
+		history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, simulatedLevel, "test_tube", msg)))
+	}
+}
+
 // setStatus set status field value using mutex Lock & Unlock
 func (cu *cpuCheckUsecase) setStatus(status cpuCheckStatus) {
 	cu.mutex.Lock()
 	defer cu.mutex.Unlock()
+	if cu.status != status {
+		cu.record.statusChanged(status == cpuStatusHealthy)
+	}
 	cu.status = status
 }
+
+// setLastHistory set check history created in latest check process to record using mutex Lock & Unlock
+func (cu *cpuCheckUsecase) setLastHistory(history domain.CheckHistory) {
+	cu.mutex.Lock()
+	defer cu.mutex.Unlock()
+	cu.record.lastHistory = history
+}
+
+// Status return current status of cpu check with record field using mutex Lock & Unlock
+// Implement Status method of domain.CheckStatusReporter interface
+func (cu *cpuCheckUsecase) Status() domain.CheckStatus {
+	cu.mutex.Lock()
+	defer cu.mutex.Unlock()
+	return cu.record.status(cu.status.String())
+}
+
+// Acknowledge acknowledge unhealthy status of cpu check by operator & handle operation history
+// Implement Acknowledge method of domain.CheckController interface
+func (cu *cpuCheckUsecase) Acknowledge(ctx context.Context, operator, reason string) (err error) {
+	cu.mutex.Lock()
+	if cu.status != cpuStatusUnhealthy {
+		cu.mutex.Unlock()
+		return domain.ErrCheckNotUnhealthy
+	}
+	cu.record.acknowledgedBy = operator
+	cu.mutex.Unlock()
+
+	history := cu.newOperationHistory(acknowledgedLevel, operator, reason)
+	history.Message = "unhealthy cpu check status is acknowledged by operator"
+	msg := fmt.Sprintf("!cpu check acknowledged! unhealthy status is acknowledged by %s, reason: %s", operator, reason)
+	history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, acknowledgedLevel, "eyes", msg)))
+
+	return cu.handleHistory(history, 0)
+}
+
+// Reset reset status of cpu check to healthy by operator & handle operation history
+// Implement Reset method of domain.CheckController interface
+func (cu *cpuCheckUsecase) Reset(ctx context.Context, operator, reason string) (err error) {
+	cu.mutex.Lock()
+	if cu.status == cpuStatusRecovering {
+		cu.mutex.Unlock()
+		return domain.ErrCheckRecovering
+	}
+	before := cu.status
+	if cu.status != cpuStatusHealthy {
+		cu.record.statusChanged(true)
+	}
+	cu.status = cpuStatusHealthy
+	cu.mutex.Unlock()
+
+	history := cu.newOperationHistory(resetLevel, operator, reason)
+	history.Message = fmt.Sprintf("cpu check status is reset from %s to healthy by operator", before.String())
+	msg := fmt.Sprintf("!cpu check reset! status is reset from %s to healthy by %s, reason: %s", before.String(), operator, reason)
+	history.AddAlarmResults(cu.notifyAgency.Notify(ctx, cu.alarm(history, resetLevel, "wrench", msg)))
+
+	return cu.handleHistory(history, 0)
+}
+
+// Rerun reset status of cpu check by operator & run cpu check process immediately
+// Implement Rerun method of domain.CheckController interface
+func (cu *cpuCheckUsecase) Rerun(ctx context.Context, operator, reason string) (err error) {
+	if err = cu.Reset(ctx, operator, reason); err != nil {
+		return
+	}
+	return cu.CheckCPU(ctx)
+}
+
+// newOperationHistory return new cpu check history about operation by operator with process level
+func (cu *cpuCheckUsecase) newOperationHistory(level, operator, reason string) (history *domain.CPUCheckHistory) {
+	history = new(domain.CPUCheckHistory)
+	history.FillPrivateComponent(cu.clock.Now())
+	history.SetType(cu.record._type)
+	history.UUID = uuid.New().String()
+	history.ProcessLevel.Set(level)
+	history.SetOperation(operator, reason)
+	return
+}
+
+// alarmFields return fields of alarm about cpu check with total cpu usage, thresholds & most cpu consumed container
+func (cu *cpuCheckUsecase) alarmFields(history *domain.CPUCheckHistory) (fields map[string]string) {
+	fields = map[string]string{
+		domain.AlarmFieldThreshold: fmt.Sprintf("warning %.02f / maximum %.02f core", cu.thresholds.WarningUsage, cu.thresholds.MaximumUsage),
+		domain.AlarmFieldTarget:    history.MostCPUConsumeContainer,
+	}
+	if history.TotalUsageCore > 0 {
+		fields[domain.AlarmFieldMeasured] = fmt.Sprintf("%.02f core", history.TotalUsageCore)
+	}
+	return
+}
+
+// alarm return alarm about cpu check with fields from history, incident of record is updated using mutex Lock & Unlock
+func (cu *cpuCheckUsecase) alarm(history *domain.CPUCheckHistory, level, emoji, text string) domain.Alarm {
+	cu.mutex.Lock()
+	defer cu.mutex.Unlock()
+	return cu.record.alarm(level, emoji, text, history.UUID, cu.alarmFields(history))
+}
+
+// reminder return reminder alarm about unhealthy cpu check by escalation policy, record is updated using mutex Lock & Unlock
+func (cu *cpuCheckUsecase) reminder(history *domain.CPUCheckHistory) (domain.Alarm, bool) {
+	cu.mutex.Lock()
+	defer cu.mutex.Unlock()
+	return cu.record.reminder(cu.myCfg.EscalationPolicy(), history.UUID, cu.alarmFields(history))
+}
+
+// takeThresholds take thresholds snapshot of cpu check from config & set to thresholds field using mutex Lock & Unlock
+// every threshold compared in check process & shown in alarm is read from this snapshot, though config is reloaded meanwhile
+func (cu *cpuCheckUsecase) takeThresholds() domain.CPUCheckThresholds {
+	cu.mutex.Lock()
+	defer cu.mutex.Unlock()
+	cu.thresholds = cu.myCfg.CPUCheckThresholds()
+	return cu.thresholds
+}
+
+// simulate mark remediation of cpu check as simulated in record using mutex Lock & Unlock, and return if it should be alarmed
+func (cu *cpuCheckUsecase) simulate() bool {
+	cu.mutex.Lock()
+	defer cu.mutex.Unlock()
+	return cu.record.simulate()
+}
+
+// trackIncident track cpu check history into incident of record using mutex Lock & Unlock, and store updated incident
+func (cu *cpuCheckUsecase) trackIncident(history *domain.CPUCheckHistory) error {
+	cu.mutex.Lock()
+	incident, ok := cu.record.track(history)
+	cu.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+
+	history.SetIncident(incident.ID)
+	if b, err := cu.incidentRepo.Store(incident); err != nil {
+		return errors.Wrapf(err, "failed to store incident of cpu check, response: %s", string(b))
+	}
+	return nil
+}
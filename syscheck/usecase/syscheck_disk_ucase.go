@@ -11,6 +11,7 @@ import (
 	"github.com/inhies/go-bytesize"
 	"github.com/pkg/errors"
 	"sync"
+	"time"
 
 	"github.com/DMS-SMS/v1-health-check/domain"
 )
@@ -24,6 +25,20 @@ const (
 	diskStatusUnhealthy                         // represent disk check status is unhealthy
 )
 
+// String method return domain.CheckState const value matched with disk check status
+func (s diskCheckStatus) String() string {
+	switch s {
+	case diskStatusHealthy:
+		return domain.CheckStateHealthy
+	case diskStatusRecovering:
+		return domain.CheckStateRecovering
+	case diskStatusUnhealthy:
+		return domain.CheckStateUnhealthy
+	default:
+		return "UNKNOWN"
+	}
+}
+
 // diskCheckUsecase implement DiskCheckUsecase interface in domain and used in delivery layer
 type diskCheckUsecase struct {
 	// myCfg is used for getting disk check usecase config
@@ -32,8 +47,20 @@ type diskCheckUsecase struct {
 	// historyRepo is used for store disk check history and injected from outside
 	historyRepo domain.DiskCheckHistoryRepository
 
-	// slackChat is used for agent slack API about chatting
-	slackChatAgency slackChatAgency
+	// incidentRepo is used for store incident of disk check and injected from outside
+	incidentRepo domain.IncidentRepository
+
+	// historyObserver is used for observing check history after check process (ex, recording metrics)
+	historyObserver historyObserver
+
+	// maintenanceAgency is used as agency about maintenance window pausing check or suppressing remediation
+	maintenanceAgency maintenanceAgency
+
+	// notifyAgency is used as agency about notifier backends sending alarm (Ex, slack, webhook, email)
+	notifyAgency notifyAgency
+
+	// clock is used for getting current time in configured timezone, which is set to timestamp of check history
+	clock clock
 
 	// diskSysAgency is used as agency about disk system command
 	diskSysAgency diskSysAgency
@@ -41,6 +68,15 @@ type diskCheckUsecase struct {
 	// status represent current process status of disk health check
 	status diskCheckStatus
 
+	// executor is used for running check process with single flight policy & run timeout
+	executor checkExecutor
+
+	// thresholds represent thresholds snapshot taken at the start of latest check process, which is shown in alarm
+	thresholds domain.DiskCheckThresholds
+
+	// record represent status transition & latest check process of disk health check
+	record checkRecord
+
 	// mutex help to prevent race condition when set status field value
 	mutex sync.Mutex
 }
@@ -50,8 +86,8 @@ type diskCheckUsecaseConfig interface {
 	// get common config method from embedding systemCheckUsecaseComponentConfig
 	systemCheckUsecaseComponentConfig
 
-	// DiskMinCapacity method returns byte size represent disk minimum capacity
-	DiskMinCapacity() bytesize.ByteSize
+	// DiskCheckThresholds method returns thresholds snapshot of disk check, which is read at once in every check process
+	DiskCheckThresholds() domain.DiskCheckThresholds
 }
 
 // diskSysAgency is agency that agent various command about disk system
@@ -60,39 +96,64 @@ type diskSysAgency interface {
 	GetRemainDiskCapacity() (size bytesize.ByteSize, err error)
 
 	// PruneDockerSystem prune all about docker system and return reclaimed size
-	PruneDockerSystem() (reclaimed bytesize.ByteSize, err error)
+	PruneDockerSystem(ctx context.Context) (reclaimed bytesize.ByteSize, err error)
 }
 
 // NewDiskCheckUsecase function return diskCheckUsecase ptr instance with initializing
 func NewDiskCheckUsecase(
 	cfg diskCheckUsecaseConfig,
 	dhr domain.DiskCheckHistoryRepository,
-	sca slackChatAgency,
+	ir domain.IncidentRepository,
+	ho historyObserver,
+	ma maintenanceAgency,
+	na notifyAgency,
+	c clock,
+	ce checkExecutor,
 	dsa diskSysAgency,
 ) domain.DiskCheckUseCase {
 	return &diskCheckUsecase{
 		// initialize field with parameter received from caller
-		myCfg:           cfg,
-		historyRepo:     dhr,
-		slackChatAgency: sca,
-		diskSysAgency:   dsa,
+		myCfg:             cfg,
+		historyRepo:       dhr,
+		incidentRepo:      ir,
+		historyObserver:   ho,
+		maintenanceAgency: ma,
+		notifyAgency:      na,
+		clock:             c,
+		executor:          ce,
+		diskSysAgency:     dsa,
 
 		// initialize field with default value
-		status: diskStatusHealthy,
-		mutex:  sync.Mutex{},
+		status:     diskStatusHealthy,
+		thresholds: cfg.DiskCheckThresholds(),
+		record:     newCheckRecord("syscheck", "DiskCheck", cfg.CheckType(), c),
+		mutex:      sync.Mutex{},
 	}
 }
 
-// CheckDisk check disk health with checkDisk method & store check log in repository
+// CheckDisk check disk health with checkDisk method in executor & store check history in repository
 // Implement CheckDisk method of domain.DiskCheckUseCase interface
 func (du *diskCheckUsecase) CheckDisk(ctx context.Context) error {
-	history := du.checkDisk(ctx)
+	return du.executor.Execute(ctx, func(ctx context.Context) domain.CheckHistory {
+		return du.checkDisk(ctx)
+	}, func(level, message string) domain.CheckHistory {
+		history := du.newOperationHistory(level, "", "")
+		history.Message = message
+		return history
+	}, du.handleHistory)
+}
 
-	if b, err := du.historyRepo.Store(history); err != nil {
+// handleHistory observe disk check history with duration, set to record & store in repository
+func (du *diskCheckUsecase) handleHistory(history domain.CheckHistory, duration time.Duration) error {
+	incidentErr := du.trackIncident(history.(*domain.DiskCheckHistory))
+	du.historyObserver.ObserveHistory(history, duration)
+	du.setLastHistory(history)
+
+	if b, err := du.historyRepo.Store(history.(*domain.DiskCheckHistory)); err != nil {
 		return errors.Wrapf(err, "failed to store disk check history, response: %s", string(b))
 	}
 
-	return nil
+	return incidentErr
 }
 
 // method with below logic about handling health check process according to current disk check status
@@ -104,17 +165,26 @@ func (du *diskCheckUsecase) CheckDisk(ctx context.Context) error {
 // 2 : 관리자가 직접 확인해야함 (상태 확인 수행 X)
 // 2 -> 0 : 관리자 직접 상태 회복 완료 (상태 회복 알림 발행)
 func (du *diskCheckUsecase) checkDisk(ctx context.Context) (history *domain.DiskCheckHistory) {
+	thresholds := du.takeThresholds()
+
 	_uuid := uuid.New().String()
 	history = new(domain.DiskCheckHistory)
-	history.FillPrivateComponent()
+	history.FillPrivateComponent(du.clock.Now())
+	history.SetType(du.record._type)
 	history.UUID = _uuid
 
+	if paused, reason := du.maintenanceAgency.IsPaused(du.record.domain, du.record._type); paused {
+		history.ProcessLevel.Set(pausedLevel)
+		history.Message = "disk check is paused by maintenance window, reason: " + reason
+		return
+	}
+
 	_remainCap, err := du.diskSysAgency.GetRemainDiskCapacity()
 	if err != nil {
 		history.ProcessLevel.Set(errorLevel)
 		history.SetError(errors.Wrap(err, "failed to get disk capacity"))
 		msg := "!disk check error occurred! unable to get remain disk capacity"
-		history.SetAlarmResult(du.slackChatAgency.SendMessage("x", msg, _uuid))
+		history.AddAlarmResults(du.notifyAgency.Notify(ctx, du.alarm(history, errorLevel, "x", msg)))
 		return
 	}
 	history.RemainingCap = _remainCap
@@ -128,30 +198,51 @@ func (du *diskCheckUsecase) checkDisk(ctx context.Context) (history *domain.Disk
 		history.Message = "pruning docker system is already on process"
 		return
 	case diskStatusUnhealthy:
-		if remainCap.isMoreThan(du.myCfg.DiskMinCapacity()) {
+		if remainCap.isMoreThan(thresholds.MinCapacity) {
 			du.setStatus(diskStatusHealthy)
 			history.ProcessLevel.Set(recoveredLevel)
 			history.Message = "disk check is recovered to be healthy"
 			msg := fmt.Sprintf("!disk check recovered to health! remain capacity - %s", remainCap.V)
-			_, _, _ = du.slackChatAgency.SendMessage("heart", msg, _uuid)
+			history.AddAlarmResults(du.notifyAgency.Notify(ctx, du.alarm(history, recoveredLevel, "heart", msg)))
 		} else {
 			history.ProcessLevel.Set(unhealthyLevel)
 			history.Message = "disk check is unhealthy now"
+			if alarm, ok := du.reminder(history); ok {
+				history.ProcessLevel.Append(escalatedLevel)
+				history.AddAlarmResults(du.notifyAgency.Notify(ctx, alarm))
+			}
 		}
 		return
 	}
 
-	if remainCap.isLessThan(du.myCfg.DiskMinCapacity()) {
+	if remainCap.isLessThan(thresholds.MinCapacity) {
+		if suppressed, reason := du.maintenanceAgency.IsRemediationSuppressed(du.record.domain, du.record._type); suppressed {
+			history.ProcessLevel.Set(suppressedLevel)
+			history.Message = "disk weak is detected, but remediation is suppressed by maintenance window, reason: " + reason
+			return
+		}
+
+		if isDryRun(ctx, du.myCfg) {
+			history.SetSimulated()
+			history.ProcessLevel.Set(simulatedLevel)
+			history.Message = "docker system prune is simulated in dry-run mode as current disk capacity is less than the minimum"
+			if du.simulate() {
+				msg := fmt.Sprintf("!disk check dry-run! docker system would be pruned, but not executed (remain capacity - %s)", remainCap.V)
+				history.AddAlarmResults(du.notifyAgency.Notify(ctx, du.alarm(history, simulatedLevel, "test_tube", msg)))
+			}
+			return
+		}
+
 		du.setStatus(diskStatusRecovering)
 		history.ProcessLevel.Set(weakDetectedLevel)
 		msg := "!disk check weak detected! start to prune docker system"
-		history.SetAlarmResult(du.slackChatAgency.SendMessage("pill", msg, _uuid))
+		history.AddAlarmResults(du.notifyAgency.Notify(ctx, du.alarm(history, weakDetectedLevel, "pill", msg)))
 
-		if r, err := du.diskSysAgency.PruneDockerSystem(); err != nil {
+		if r, err := du.diskSysAgency.PruneDockerSystem(ctx); err != nil {
 			du.setStatus(diskStatusUnhealthy)
 			history.ProcessLevel.Append(warningLevel)
 			msg := "!disk check error occurred! failed to prune docker system"
-			_, _, _ = du.slackChatAgency.SendMessage("anger", msg, _uuid)
+			history.AddAlarmResults(du.notifyAgency.Notify(ctx, du.alarm(history, errorLevel, "anger", msg)))
 			history.SetError(errors.Wrap(err, "failed to prune docker system"))
 			return
 		} else {
@@ -164,20 +255,20 @@ func (du *diskCheckUsecase) checkDisk(ctx context.Context) (history *domain.Disk
 			du.setStatus(diskStatusUnhealthy)
 			history.ProcessLevel.Append(errorLevel)
 			msg := "!disk check error occurred! failed to again get disk capacity, please check for yourself"
-			_, _, _ = du.slackChatAgency.SendMessage("broken_heart", msg, _uuid)
+			history.AddAlarmResults(du.notifyAgency.Notify(ctx, du.alarm(history, unhealthyLevel, "broken_heart", msg)))
 			history.SetError(errors.Wrap(err, "failed to again get remain disk capacity"))
 			return
 		}
 		var againRemainCap = bytesizeComparator{V: _againRemainCap}
 
-		if againRemainCap.isMoreThan(du.myCfg.DiskMinCapacity()) {
+		if againRemainCap.isMoreThan(thresholds.MinCapacity) {
 			du.setStatus(diskStatusHealthy)
 			msg := fmt.Sprintf("!disk check is healthy by pruning! remain capacity - %s", againRemainCap.V)
-			_, _, _ = du.slackChatAgency.SendMessage("heart", msg, _uuid)
+			history.AddAlarmResults(du.notifyAgency.Notify(ctx, du.alarm(history, recoveredLevel, "heart", msg)))
 		} else {
 			du.setStatus(diskStatusUnhealthy)
 			msg := "!disk check has deteriorated! please check for yourself"
-			_, _, _ = du.slackChatAgency.SendMessage("broken_heart", msg, _uuid)
+			history.AddAlarmResults(du.notifyAgency.Notify(ctx, du.alarm(history, unhealthyLevel, "broken_heart", msg)))
 		}
 	} else {
 		history.ProcessLevel.Set(healthyLevel)
@@ -191,5 +282,142 @@ func (du *diskCheckUsecase) checkDisk(ctx context.Context) (history *domain.Disk
 func (du *diskCheckUsecase) setStatus(status diskCheckStatus) {
 	du.mutex.Lock()
 	defer du.mutex.Unlock()
+	if du.status != status {
+		du.record.statusChanged(status == diskStatusHealthy)
+	}
 	du.status = status
 }
+
+// setLastHistory set check history created in latest check process to record using mutex Lock & Unlock
+func (du *diskCheckUsecase) setLastHistory(history domain.CheckHistory) {
+	du.mutex.Lock()
+	defer du.mutex.Unlock()
+	du.record.lastHistory = history
+}
+
+// Status return current status of disk check with record field using mutex Lock & Unlock
+// Implement Status method of domain.CheckStatusReporter interface
+func (du *diskCheckUsecase) Status() domain.CheckStatus {
+	du.mutex.Lock()
+	defer du.mutex.Unlock()
+	return du.record.status(du.status.String())
+}
+
+// Acknowledge acknowledge unhealthy status of disk check by operator & handle operation history
+// Implement Acknowledge method of domain.CheckController interface
+func (du *diskCheckUsecase) Acknowledge(ctx context.Context, operator, reason string) (err error) {
+	du.mutex.Lock()
+	if du.status != diskStatusUnhealthy {
+		du.mutex.Unlock()
+		return domain.ErrCheckNotUnhealthy
+	}
+	du.record.acknowledgedBy = operator
+	du.mutex.Unlock()
+
+	history := du.newOperationHistory(acknowledgedLevel, operator, reason)
+	history.Message = "unhealthy disk check status is acknowledged by operator"
+	msg := fmt.Sprintf("!disk check acknowledged! unhealthy status is acknowledged by %s, reason: %s", operator, reason)
+	history.AddAlarmResults(du.notifyAgency.Notify(ctx, du.alarm(history, acknowledgedLevel, "eyes", msg)))
+
+	return du.handleHistory(history, 0)
+}
+
+// Reset reset status of disk check to healthy by operator & handle operation history
+// Implement Reset method of domain.CheckController interface
+func (du *diskCheckUsecase) Reset(ctx context.Context, operator, reason string) (err error) {
+	du.mutex.Lock()
+	if du.status == diskStatusRecovering {
+		du.mutex.Unlock()
+		return domain.ErrCheckRecovering
+	}
+	before := du.status
+	if du.status != diskStatusHealthy {
+		du.record.statusChanged(true)
+	}
+	du.status = diskStatusHealthy
+	du.mutex.Unlock()
+
+	history := du.newOperationHistory(resetLevel, operator, reason)
+	history.Message = fmt.Sprintf("disk check status is reset from %s to healthy by operator", before.String())
+	msg := fmt.Sprintf("!disk check reset! status is reset from %s to healthy by %s, reason: %s", before.String(), operator, reason)
+	history.AddAlarmResults(du.notifyAgency.Notify(ctx, du.alarm(history, resetLevel, "wrench", msg)))
+
+	return du.handleHistory(history, 0)
+}
+
+// Rerun reset status of disk check by operator & run disk check process immediately
+// Implement Rerun method of domain.CheckController interface
+func (du *diskCheckUsecase) Rerun(ctx context.Context, operator, reason string) (err error) {
+	if err = du.Reset(ctx, operator, reason); err != nil {
+		return
+	}
+	return du.CheckDisk(ctx)
+}
+
+// newOperationHistory return new disk check history about operation by operator with process level
+func (du *diskCheckUsecase) newOperationHistory(level, operator, reason string) (history *domain.DiskCheckHistory) {
+	history = new(domain.DiskCheckHistory)
+	history.FillPrivateComponent(du.clock.Now())
+	history.SetType(du.record._type)
+	history.UUID = uuid.New().String()
+	history.ProcessLevel.Set(level)
+	history.SetOperation(operator, reason)
+	return
+}
+
+// alarmFields return fields of alarm about disk check with remaining capacity & minimum capacity
+func (du *diskCheckUsecase) alarmFields(history *domain.DiskCheckHistory) (fields map[string]string) {
+	fields = map[string]string{
+		domain.AlarmFieldThreshold: "minimum " + du.thresholds.MinCapacity.String(),
+	}
+	if history.RemainingCap > 0 {
+		fields[domain.AlarmFieldMeasured] = history.RemainingCap.String()
+	}
+	return
+}
+
+// alarm return alarm about disk check with fields from history, incident of record is updated using mutex Lock & Unlock
+func (du *diskCheckUsecase) alarm(history *domain.DiskCheckHistory, level, emoji, text string) domain.Alarm {
+	du.mutex.Lock()
+	defer du.mutex.Unlock()
+	return du.record.alarm(level, emoji, text, history.UUID, du.alarmFields(history))
+}
+
+// reminder return reminder alarm about unhealthy disk check by escalation policy, record is updated using mutex Lock & Unlock
+func (du *diskCheckUsecase) reminder(history *domain.DiskCheckHistory) (domain.Alarm, bool) {
+	du.mutex.Lock()
+	defer du.mutex.Unlock()
+	return du.record.reminder(du.myCfg.EscalationPolicy(), history.UUID, du.alarmFields(history))
+}
+
+// takeThresholds take thresholds snapshot of disk check from config & set to thresholds field using mutex Lock & Unlock
+// every threshold compared in check process & shown in alarm is read from this snapshot, though config is reloaded meanwhile
+func (du *diskCheckUsecase) takeThresholds() domain.DiskCheckThresholds {
+	du.mutex.Lock()
+	defer du.mutex.Unlock()
+	du.thresholds = du.myCfg.DiskCheckThresholds()
+	return du.thresholds
+}
+
+// simulate mark remediation of disk check as simulated in record using mutex Lock & Unlock, and return if it should be alarmed
+func (du *diskCheckUsecase) simulate() bool {
+	du.mutex.Lock()
+	defer du.mutex.Unlock()
+	return du.record.simulate()
+}
+
+// trackIncident track disk check history into incident of record using mutex Lock & Unlock, and store updated incident
+func (du *diskCheckUsecase) trackIncident(history *domain.DiskCheckHistory) error {
+	du.mutex.Lock()
+	incident, ok := du.record.track(history)
+	du.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+
+	history.SetIncident(incident.ID)
+	if b, err := du.incidentRepo.Store(incident); err != nil {
+		return errors.Wrapf(err, "failed to store incident of disk check, response: %s", string(b))
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/inhies/go-bytesize"
 	"github.com/pkg/errors"
 	"sync"
+	"time"
 
 	"github.com/DMS-SMS/v1-health-check/domain"
 )
@@ -26,6 +27,22 @@ const (
 	memoryStatusUnhealthy                           // represent memory check status is unhealthy
 )
 
+// String method return domain.CheckState const value matched with memory check status
+func (s memoryCheckStatus) String() string {
+	switch s {
+	case memoryStatusHealthy:
+		return domain.CheckStateHealthy
+	case memoryStatusWarning:
+		return domain.CheckStateWarning
+	case memoryStatusRecovering:
+		return domain.CheckStateRecovering
+	case memoryStatusUnhealthy:
+		return domain.CheckStateUnhealthy
+	default:
+		return "UNKNOWN"
+	}
+}
+
 // memoryCheckUsecase implement MemoryCheckUsecase interface in domain and used in delivery layer
 type memoryCheckUsecase struct {
 	// myCfg is used for getting memory check usecase config
@@ -34,8 +51,20 @@ type memoryCheckUsecase struct {
 	// historyRepo is used for store memory check history and injected from outside
 	historyRepo domain.MemoryCheckHistoryRepository
 
-	// slackChat is used for agent slack API about chatting
-	slackChatAgency slackChatAgency
+	// incidentRepo is used for store incident of memory check and injected from outside
+	incidentRepo domain.IncidentRepository
+
+	// historyObserver is used for observing check history after check process (ex, recording metrics)
+	historyObserver historyObserver
+
+	// maintenanceAgency is used as agency about maintenance window pausing check or suppressing remediation
+	maintenanceAgency maintenanceAgency
+
+	// notifyAgency is used as agency about notifier backends sending alarm (Ex, slack, webhook, email)
+	notifyAgency notifyAgency
+
+	// clock is used for getting current time in configured timezone, which is set to timestamp of check history
+	clock clock
 
 	// memorySysAgency is used as agency about memory system command
 	memorySysAgency memorySysAgency
@@ -46,6 +75,15 @@ type memoryCheckUsecase struct {
 	// status represent current process status of memory health check
 	status memoryCheckStatus
 
+	// executor is used for running check process with single flight policy & run timeout
+	executor checkExecutor
+
+	// thresholds represent thresholds snapshot taken at the start of latest check process, which is shown in alarm
+	thresholds domain.MemoryCheckThresholds
+
+	// record represent status transition & latest check process of memory health check
+	record checkRecord
+
 	// mutex help to prevent race condition when set status field value
 	mutex sync.Mutex
 }
@@ -55,14 +93,8 @@ type memoryCheckUsecaseConfig interface {
 	// get common config method from embedding systemCheckUsecaseComponentConfig
 	systemCheckUsecaseComponentConfig
 
-	// MemoryWarningUsage method returns bytesize.ByteSize represent memory warning usage
-	MemoryWarningUsage() bytesize.ByteSize
-
-	// MemoryMaximumUsage method returns bytesize.ByteSize represent memory maximum usage
-	MemoryMaximumUsage() bytesize.ByteSize
-
-	// MemoryMinimumUsageToRemove method returns bytesize.ByteSize represent memory minimum usage to remove
-	MemoryMinimumUsageToRemove() bytesize.ByteSize
+	// MemoryCheckThresholds method returns thresholds snapshot of memory check, which is read at once in every check process
+	MemoryCheckThresholds() domain.MemoryCheckThresholds
 }
 
 // memorySysAgency is agency that agent various command about memory system
@@ -71,7 +103,7 @@ type memorySysAgency interface {
 	GetTotalSystemMemoryUsage() (usage bytesize.ByteSize, err error)
 
 	// CalculateContainersMemoryUsage calculate container memory usage & return result interface implementation
-	CalculateContainersMemoryUsage() (result interface {
+	CalculateContainersMemoryUsage(ctx context.Context) (result interface {
 		// TotalMemoryUsage return total memory usage in docker containers
 		TotalMemoryUsage() (usage bytesize.ByteSize)
 
@@ -84,34 +116,59 @@ type memorySysAgency interface {
 func NewMemoryCheckUsecase(
 	cfg memoryCheckUsecaseConfig,
 	mhr domain.MemoryCheckHistoryRepository,
-	sca slackChatAgency,
+	ir domain.IncidentRepository,
+	ho historyObserver,
+	ma maintenanceAgency,
+	na notifyAgency,
+	c clock,
+	ce checkExecutor,
 	msa memorySysAgency,
 	da dockerAgency,
 ) domain.MemoryCheckUseCase {
 	return &memoryCheckUsecase{
 		// initialize field with parameter received from caller
-		myCfg:           cfg,
-		historyRepo:     mhr,
-		slackChatAgency: sca,
-		memorySysAgency: msa,
-		dockerAgency:    da,
+		myCfg:             cfg,
+		historyRepo:       mhr,
+		incidentRepo:      ir,
+		historyObserver:   ho,
+		maintenanceAgency: ma,
+		notifyAgency:      na,
+		clock:             c,
+		executor:          ce,
+		memorySysAgency:   msa,
+		dockerAgency:      da,
 
 		// initialize field with default value
-		status: memoryStatusHealthy,
-		mutex:  sync.Mutex{},
+		status:     memoryStatusHealthy,
+		thresholds: cfg.MemoryCheckThresholds(),
+		record:     newCheckRecord("syscheck", "MemoryCheck", cfg.CheckType(), c),
+		mutex:      sync.Mutex{},
 	}
 }
 
-// CheckMemory check memory health with CheckMemory method & store check history in repository
+// CheckMemory check memory health with checkMemory method in executor & store check history in repository
 // Implement CheckMemory method of domain.MemoryCheckUseCase interface
 func (mu *memoryCheckUsecase) CheckMemory(ctx context.Context) error {
-	history := mu.checkMemory(ctx)
+	return mu.executor.Execute(ctx, func(ctx context.Context) domain.CheckHistory {
+		return mu.checkMemory(ctx)
+	}, func(level, message string) domain.CheckHistory {
+		history := mu.newOperationHistory(level, "", "")
+		history.Message = message
+		return history
+	}, mu.handleHistory)
+}
+
+// handleHistory observe memory check history with duration, set to record & store in repository
+func (mu *memoryCheckUsecase) handleHistory(history domain.CheckHistory, duration time.Duration) error {
+	incidentErr := mu.trackIncident(history.(*domain.MemoryCheckHistory))
+	mu.historyObserver.ObserveHistory(history, duration)
+	mu.setLastHistory(history)
 
-	if b, err := mu.historyRepo.Store(history); err != nil {
+	if b, err := mu.historyRepo.Store(history.(*domain.MemoryCheckHistory)); err != nil {
 		return errors.Wrapf(err, "failed to store memory check history, response: %s", string(b))
 	}
 
-	return nil
+	return incidentErr
 }
 
 // method with below logic about handling health check process according to current memory check status
@@ -125,17 +182,26 @@ func (mu *memoryCheckUsecase) CheckMemory(ctx context.Context) error {
 // 3 : 관리자가 직접 확인해야함 (상태 확인 수행 X)
 // 3 -> 0 : 관리자 직접 상태 회복 완료 (상태 회복 알림 발행)
 func (mu *memoryCheckUsecase) checkMemory(ctx context.Context) (history *domain.MemoryCheckHistory) {
+	thresholds := mu.takeThresholds()
+
 	_uuid := uuid.New().String()
 	history = new(domain.MemoryCheckHistory)
-	history.FillPrivateComponent()
+	history.FillPrivateComponent(mu.clock.Now())
+	history.SetType(mu.record._type)
 	history.UUID = _uuid
 
+	if paused, reason := mu.maintenanceAgency.IsPaused(mu.record.domain, mu.record._type); paused {
+		history.ProcessLevel.Set(pausedLevel)
+		history.Message = "memory check is paused by maintenance window, reason: " + reason
+		return
+	}
+
 	_totalUsage, err := mu.memorySysAgency.GetTotalSystemMemoryUsage()
 	if err != nil {
 		history.ProcessLevel.Set(errorLevel)
 		history.SetError(errors.Wrap(err, "failed to get total system memory usage"))
 		msg := "!memory check error occurred! unable to get total memory usage"
-		history.SetAlarmResult(mu.slackChatAgency.SendMessage("x", msg, _uuid))
+		history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, errorLevel, "x", msg)))
 		return
 	}
 	history.TotalUsageMemory = _totalUsage
@@ -145,7 +211,7 @@ func (mu *memoryCheckUsecase) checkMemory(ctx context.Context) (history *domain.
 	case memoryStatusHealthy:
 		break
 	case memoryStatusWarning:
-		if totalUsage.isLessThan(mu.myCfg.MemoryWarningUsage()) {
+		if totalUsage.isLessThan(thresholds.WarningUsage) {
 			mu.setStatus(memoryStatusHealthy)
 		}
 	case memoryStatusRecovering:
@@ -153,31 +219,46 @@ func (mu *memoryCheckUsecase) checkMemory(ctx context.Context) (history *domain.
 		history.Message = "provisioning memory is already on process using docker"
 		return
 	case memoryStatusUnhealthy:
-		if totalUsage.isLessThan(mu.myCfg.MemoryMaximumUsage()) {
+		if totalUsage.isLessThan(thresholds.MaximumUsage) {
 			mu.setStatus(memoryStatusHealthy)
 			history.ProcessLevel.Set(recoveredLevel)
 			history.Message = "memory check is recovered to be healthy"
 			msg := fmt.Sprintf("!memory check recovered to health! current memory usage - %s", totalUsage.V)
-			_, _, _ = mu.slackChatAgency.SendMessage("heart", msg, _uuid)
+			history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, recoveredLevel, "heart", msg)))
 		} else {
 			history.ProcessLevel.Set(unhealthyLevel)
 			history.Message = "memory check is unhealthy now"
+			if alarm, ok := mu.reminder(history); ok {
+				history.ProcessLevel.Append(escalatedLevel)
+				history.AddAlarmResults(mu.notifyAgency.Notify(ctx, alarm))
+			}
 		}
 		return
 	}
 
-	if totalUsage.isMoreThan(mu.myCfg.MemoryMaximumUsage()) {
+	if totalUsage.isMoreThan(thresholds.MaximumUsage) {
+		if suppressed, reason := mu.maintenanceAgency.IsRemediationSuppressed(mu.record.domain, mu.record._type); suppressed {
+			history.ProcessLevel.Set(suppressedLevel)
+			history.Message = "memory weak is detected, but remediation is suppressed by maintenance window, reason: " + reason
+			return
+		}
+
+		if isDryRun(ctx, mu.myCfg) {
+			mu.simulateRemoval(ctx, history, thresholds)
+			return
+		}
+
 		mu.setStatus(memoryStatusRecovering)
 		history.ProcessLevel.Set(weakDetectedLevel)
 		msg := fmt.Sprintf("!memory check weak detected! start to provision memory (current memory usage - %s)", totalUsage.V)
-		history.SetAlarmResult(mu.slackChatAgency.SendMessage("pill", msg, _uuid))
+		history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, weakDetectedLevel, "pill", msg)))
 
-		result, err := mu.memorySysAgency.CalculateContainersMemoryUsage()
+		result, err := mu.memorySysAgency.CalculateContainersMemoryUsage(ctx)
 		if err != nil {
 			mu.setStatus(memoryStatusUnhealthy)
 			history.ProcessLevel.Append(errorLevel)
 			msg := "!memory check error occurred! failed to calculate container memory, please check for yourself"
-			_, _, _ = mu.slackChatAgency.SendMessage("anger", msg, _uuid)
+			history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, errorLevel, "anger", msg)))
 			history.SetError(errors.Wrap(err, "failed to calculate containers memory usage"))
 			return
 		}
@@ -187,19 +268,19 @@ func (mu *memoryCheckUsecase) checkMemory(ctx context.Context) (history *domain.
 		history.MostMemoryConsumeContainer = name
 		usage := bytesizeComparator{V: _usage}
 
-		if usage.isLessThan(mu.myCfg.MemoryMinimumUsageToRemove()) {
+		if usage.isLessThan(thresholds.MinimumUsageToRemove) {
 			mu.setStatus(memoryStatusUnhealthy)
 			msg := "!memory check error occurred! memory usage is too small to remove, please check for yourself"
-			_, _, _ = mu.slackChatAgency.SendMessage("anger", msg, _uuid)
+			history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, errorLevel, "anger", msg)))
 			history.SetError(errors.New("memory usage is too small to remove"))
 			return
 		}
 
-		if err := mu.dockerAgency.RemoveContainer(id, types.ContainerRemoveOptions{Force: true}); err != nil {
+		if err := mu.dockerAgency.RemoveContainer(ctx, id, types.ContainerRemoveOptions{Force: true}); err != nil {
 			mu.setStatus(memoryStatusUnhealthy)
 			history.ProcessLevel.Append(errorLevel)
 			msg := "!memory check error occurred! failed to remove container, please check for yourself"
-			_, _, _ = mu.slackChatAgency.SendMessage("anger", msg, _uuid)
+			history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, errorLevel, "anger", msg)))
 			history.SetError(errors.Wrap(err, "failed to remove container"))
 			return
 		} else {
@@ -212,28 +293,28 @@ func (mu *memoryCheckUsecase) checkMemory(ctx context.Context) (history *domain.
 			mu.setStatus(memoryStatusUnhealthy)
 			history.ProcessLevel.Append(errorLevel)
 			msg := "!memory check error occurred! failed to again calculate container memory, please check for yourself"
-			_, _, _ = mu.slackChatAgency.SendMessage("broken_heart", msg, _uuid)
+			history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, unhealthyLevel, "broken_heart", msg)))
 			history.SetError(errors.Wrap(err, "failed to again calculate containers memory usage"))
 			return
 		}
 		var againTotalUsage = bytesizeComparator{V: _againTotalUsage}
 
-		if againTotalUsage.isLessThan(mu.myCfg.MemoryMaximumUsage()) {
+		if againTotalUsage.isLessThan(thresholds.MaximumUsage) {
 			mu.setStatus(memoryStatusHealthy)
 			msg := fmt.Sprintf("!memory check is healthy! current memory usage - %s", againTotalUsage.V)
-			_, _, _ = mu.slackChatAgency.SendMessage("heart", msg, _uuid)
+			history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, recoveredLevel, "heart", msg)))
 		} else {
 			mu.setStatus(memoryStatusUnhealthy)
 			msg := "!memory check has deteriorated! please check for yourself"
-			_, _, _ = mu.slackChatAgency.SendMessage("broken_heart", msg, _uuid)
+			history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, unhealthyLevel, "broken_heart", msg)))
 		}
-	} else if totalUsage.isMoreThan(mu.myCfg.MemoryWarningUsage()) {
+	} else if totalUsage.isMoreThan(thresholds.WarningUsage) {
 		history.ProcessLevel.Set(warningLevel)
 		history.Message = "memory check is warning now, but not weak yet"
 		if mu.status != memoryStatusWarning {
 			mu.setStatus(memoryStatusWarning)
 			msg := fmt.Sprintf("!memory check warning! current memory usage - %s", totalUsage.V)
-			history.SetAlarmResult(mu.slackChatAgency.SendMessage("warning", msg, _uuid))
+			history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, warningLevel, "warning", msg)))
 		}
 	} else {
 		history.ProcessLevel.Set(healthyLevel)
@@ -243,9 +324,178 @@ func (mu *memoryCheckUsecase) checkMemory(ctx context.Context) (history *domain.
 	return
 }
 
+// simulateRemoval simulate removing most memory consumed container in dry-run mode, without changing status of memory check
+// simulation is recorded in history of every check run, but alarm about that is sent once until memory check is healthy again
+func (mu *memoryCheckUsecase) simulateRemoval(ctx context.Context, history *domain.MemoryCheckHistory, thresholds domain.MemoryCheckThresholds) {
+	history.SetSimulated()
+	history.ProcessLevel.Set(simulatedLevel)
+
+	result, err := mu.memorySysAgency.CalculateContainersMemoryUsage(ctx)
+	if err != nil {
+		history.ProcessLevel.Append(errorLevel)
+		history.SetError(errors.Wrap(err, "failed to calculate containers memory usage"))
+		return
+	}
+	history.DockerUsageMemory = result.TotalMemoryUsage()
+
+	_, name, _usage := result.MostConsumerExceptFor(requiredContainers)
+	history.MostMemoryConsumeContainer = name
+	usage := bytesizeComparator{V: _usage}
+
+	if usage.isLessThan(thresholds.MinimumUsageToRemove) {
+		history.ProcessLevel.Append(errorLevel)
+		history.SetError(errors.New("memory usage is too small to remove"))
+		return
+	}
+
+	history.Message = fmt.Sprintf("removing most memory consumed container is simulated in dry-run mode, container: %s", name)
+	if mu.simulate() {
+		msg := fmt.Sprintf("!memory check dry-run! container %s would be removed, but not executed (memory usage - %s)", name, usage.V)
+		history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, simulatedLevel, "test_tube", msg)))
+	}
+}
+
 // setStatus set status field value using mutex Lock & Unlock
 func (mu *memoryCheckUsecase) setStatus(status memoryCheckStatus) {
 	mu.mutex.Lock()
 	defer mu.mutex.Unlock()
+	if mu.status != status {
+		mu.record.statusChanged(status == memoryStatusHealthy)
+	}
 	mu.status = status
 }
+
+// setLastHistory set check history created in latest check process to record using mutex Lock & Unlock
+func (mu *memoryCheckUsecase) setLastHistory(history domain.CheckHistory) {
+	mu.mutex.Lock()
+	defer mu.mutex.Unlock()
+	mu.record.lastHistory = history
+}
+
+// Status return current status of memory check with record field using mutex Lock & Unlock
+// Implement Status method of domain.CheckStatusReporter interface
+func (mu *memoryCheckUsecase) Status() domain.CheckStatus {
+	mu.mutex.Lock()
+	defer mu.mutex.Unlock()
+	return mu.record.status(mu.status.String())
+}
+
+// Acknowledge acknowledge unhealthy status of memory check by operator & handle operation history
+// Implement Acknowledge method of domain.CheckController interface
+func (mu *memoryCheckUsecase) Acknowledge(ctx context.Context, operator, reason string) (err error) {
+	mu.mutex.Lock()
+	if mu.status != memoryStatusUnhealthy {
+		mu.mutex.Unlock()
+		return domain.ErrCheckNotUnhealthy
+	}
+	mu.record.acknowledgedBy = operator
+	mu.mutex.Unlock()
+
+	history := mu.newOperationHistory(acknowledgedLevel, operator, reason)
+	history.Message = "unhealthy memory check status is acknowledged by operator"
+	msg := fmt.Sprintf("!memory check acknowledged! unhealthy status is acknowledged by %s, reason: %s", operator, reason)
+	history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, acknowledgedLevel, "eyes", msg)))
+
+	return mu.handleHistory(history, 0)
+}
+
+// Reset reset status of memory check to healthy by operator & handle operation history
+// Implement Reset method of domain.CheckController interface
+func (mu *memoryCheckUsecase) Reset(ctx context.Context, operator, reason string) (err error) {
+	mu.mutex.Lock()
+	if mu.status == memoryStatusRecovering {
+		mu.mutex.Unlock()
+		return domain.ErrCheckRecovering
+	}
+	before := mu.status
+	if mu.status != memoryStatusHealthy {
+		mu.record.statusChanged(true)
+	}
+	mu.status = memoryStatusHealthy
+	mu.mutex.Unlock()
+
+	history := mu.newOperationHistory(resetLevel, operator, reason)
+	history.Message = fmt.Sprintf("memory check status is reset from %s to healthy by operator", before.String())
+	msg := fmt.Sprintf("!memory check reset! status is reset from %s to healthy by %s, reason: %s", before.String(), operator, reason)
+	history.AddAlarmResults(mu.notifyAgency.Notify(ctx, mu.alarm(history, resetLevel, "wrench", msg)))
+
+	return mu.handleHistory(history, 0)
+}
+
+// Rerun reset status of memory check by operator & run memory check process immediately
+// Implement Rerun method of domain.CheckController interface
+func (mu *memoryCheckUsecase) Rerun(ctx context.Context, operator, reason string) (err error) {
+	if err = mu.Reset(ctx, operator, reason); err != nil {
+		return
+	}
+	return mu.CheckMemory(ctx)
+}
+
+// newOperationHistory return new memory check history about operation by operator with process level
+func (mu *memoryCheckUsecase) newOperationHistory(level, operator, reason string) (history *domain.MemoryCheckHistory) {
+	history = new(domain.MemoryCheckHistory)
+	history.FillPrivateComponent(mu.clock.Now())
+	history.SetType(mu.record._type)
+	history.UUID = uuid.New().String()
+	history.ProcessLevel.Set(level)
+	history.SetOperation(operator, reason)
+	return
+}
+
+// alarmFields return fields of alarm about memory check with total memory usage, thresholds & most memory consumed container
+func (mu *memoryCheckUsecase) alarmFields(history *domain.MemoryCheckHistory) (fields map[string]string) {
+	fields = map[string]string{
+		domain.AlarmFieldThreshold: fmt.Sprintf("warning %s / maximum %s", mu.thresholds.WarningUsage, mu.thresholds.MaximumUsage),
+		domain.AlarmFieldTarget:    history.MostMemoryConsumeContainer,
+	}
+	if history.TotalUsageMemory > 0 {
+		fields[domain.AlarmFieldMeasured] = history.TotalUsageMemory.String()
+	}
+	return
+}
+
+// alarm return alarm about memory check with fields from history, incident of record is updated using mutex Lock & Unlock
+func (mu *memoryCheckUsecase) alarm(history *domain.MemoryCheckHistory, level, emoji, text string) domain.Alarm {
+	mu.mutex.Lock()
+	defer mu.mutex.Unlock()
+	return mu.record.alarm(level, emoji, text, history.UUID, mu.alarmFields(history))
+}
+
+// reminder return reminder alarm about unhealthy memory check by escalation policy, record is updated using mutex Lock & Unlock
+func (mu *memoryCheckUsecase) reminder(history *domain.MemoryCheckHistory) (domain.Alarm, bool) {
+	mu.mutex.Lock()
+	defer mu.mutex.Unlock()
+	return mu.record.reminder(mu.myCfg.EscalationPolicy(), history.UUID, mu.alarmFields(history))
+}
+
+// takeThresholds take thresholds snapshot of memory check from config & set to thresholds field using mutex Lock & Unlock
+// every threshold compared in check process & shown in alarm is read from this snapshot, though config is reloaded meanwhile
+func (mu *memoryCheckUsecase) takeThresholds() domain.MemoryCheckThresholds {
+	mu.mutex.Lock()
+	defer mu.mutex.Unlock()
+	mu.thresholds = mu.myCfg.MemoryCheckThresholds()
+	return mu.thresholds
+}
+
+// simulate mark remediation of memory check as simulated in record using mutex Lock & Unlock, and return if it should be alarmed
+func (mu *memoryCheckUsecase) simulate() bool {
+	mu.mutex.Lock()
+	defer mu.mutex.Unlock()
+	return mu.record.simulate()
+}
+
+// trackIncident track memory check history into incident of record using mutex Lock & Unlock, and store updated incident
+func (mu *memoryCheckUsecase) trackIncident(history *domain.MemoryCheckHistory) error {
+	mu.mutex.Lock()
+	incident, ok := mu.record.track(history)
+	mu.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+
+	history.SetIncident(incident.ID)
+	if b, err := mu.incidentRepo.Store(incident); err != nil {
+		return errors.Wrapf(err, "failed to store incident of memory check, response: %s", string(b))
+	}
+	return nil
+}
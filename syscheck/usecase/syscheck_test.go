@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	fakeclock "github.com/DMS-SMS/v1-health-check/clock"
+	"github.com/DMS-SMS/v1-health-check/domain"
+)
+
+// testRecord is helper tracking check history of cpu check into checkRecord driven by fake clock
+type testRecord struct {
+	record checkRecord
+	clock  interface {
+		Now() time.Time
+		Advance(d time.Duration)
+	}
+}
+
+func newTestRecord() *testRecord {
+	fc := fakeclock.NewFake(time.Date(2021, 6, 1, 15, 0, 0, 0, time.UTC))
+	return &testRecord{record: newCheckRecord("syscheck", "CPUCheck", "CPUCheck", fc), clock: fc}
+}
+
+// newHistory return cpu check history created at current time of fake clock with uuid & process level
+func (tr *testRecord) newHistory(_uuid, level string) *domain.CPUCheckHistory {
+	history := new(domain.CPUCheckHistory)
+	history.FillPrivateComponent(tr.clock.Now())
+	history.UUID = _uuid
+	history.ProcessLevel.Set(level)
+	return history
+}
+
+func (tr *testRecord) mustTrack(t *testing.T, history domain.CheckHistory) domain.Incident {
+	t.Helper()
+	incident, ok := tr.record.track(history)
+	if !ok {
+		t.Fatalf("history %v isn't related to any incident", history.ProcessLevels())
+	}
+	return incident
+}
+
+func (tr *testRecord) mustNotTrack(t *testing.T, history domain.CheckHistory) {
+	t.Helper()
+	if incident, ok := tr.record.track(history); ok {
+		t.Fatalf("history %v is tracked into incident %s", history.ProcessLevels(), incident.ID)
+	}
+}
+
+func TestCheckRecordIncidentRecovered(t *testing.T) {
+	tr := newTestRecord()
+	tr.mustNotTrack(t, tr.newHistory("healthy-1", healthyLevel))
+
+	// incident is opened with check process in which status left healthy
+	tr.clock.Advance(time.Minute * 5)
+	tr.record.statusChanged(false)
+	if alarm := tr.record.alarm(weakDetectedLevel, "pill", "weak detected", "weak-1", nil); alarm.Incident != "weak-1" || alarm.Resolved {
+		t.Fatalf("alarm incident, resolved = %s, %t, want weak-1, false", alarm.Incident, alarm.Resolved)
+	}
+	incident := tr.mustTrack(t, tr.newHistory("weak-1", weakDetectedLevel))
+	if incident.ID != "weak-1" || incident.OpenedLevel != weakDetectedLevel || incident.State() != domain.IncidentStateOpen {
+		t.Fatalf("incident id, opened level, state = %s, %s, %s, want weak-1, %s, open", incident.ID, incident.OpenedLevel, incident.State(), weakDetectedLevel)
+	}
+	if got := incident.TimeToDetect(); got != time.Minute*5 {
+		t.Fatalf("time to detect = %s, want 5m", got)
+	}
+
+	// skipped check process isn't added to runs of incident
+	tr.clock.Advance(time.Minute * 5)
+	tr.mustNotTrack(t, tr.newHistory("skipped-1", skippedLevel))
+	incident = tr.mustTrack(t, tr.newHistory("unhealthy-1", unhealthyLevel))
+	if len(incident.Runs) != 2 || incident.Runs[1] != "unhealthy-1" {
+		t.Fatalf("incident runs = %v, want [weak-1 unhealthy-1]", incident.Runs)
+	}
+
+	// incident is closed with check process in which status became healthy, and recovery alarm is grouped into it
+	tr.clock.Advance(time.Minute * 20)
+	tr.record.statusChanged(true)
+	if alarm := tr.record.alarm(recoveredLevel, "heart", "recovered", "recovered-1", nil); alarm.Incident != "weak-1" || !alarm.Resolved {
+		t.Fatalf("alarm incident, resolved = %s, %t, want weak-1, true", alarm.Incident, alarm.Resolved)
+	}
+	incident = tr.mustTrack(t, tr.newHistory("recovered-1", recoveredLevel))
+	if incident.State() != domain.IncidentStateClosed || incident.ClosedLevel != recoveredLevel {
+		t.Fatalf("incident state, closed level = %s, %s, want closed, %s", incident.State(), incident.ClosedLevel, recoveredLevel)
+	}
+	if got := incident.TimeToRecover(); got != time.Minute*25 {
+		t.Fatalf("time to recover = %s, want 25m", got)
+	}
+	if len(incident.Alarms) != 2 {
+		t.Fatalf("incident alarms = %v, want weak detected & recovered alarm", incident.Alarms)
+	}
+
+	// history & alarm after incident is closed aren't related to incident
+	tr.clock.Advance(time.Minute * 5)
+	tr.mustNotTrack(t, tr.newHistory("healthy-2", healthyLevel))
+	if alarm := tr.record.alarm(errorLevel, "x", "error occurred", "error-1", nil); alarm.Incident != "" {
+		t.Fatalf("alarm incident = %s, want nothing", alarm.Incident)
+	}
+}
+
+func TestCheckRecordIncidentReset(t *testing.T) {
+	tr := newTestRecord()
+
+	// time to detect is zero if there was no healthy check process before incident
+	tr.record.statusChanged(false)
+	incident := tr.mustTrack(t, tr.newHistory("unhealthy-1", unhealthyLevel))
+	if got := incident.TimeToDetect(); got != 0 {
+		t.Fatalf("time to detect = %s, want zero", got)
+	}
+
+	// incident is closed by operator resetting status
+	tr.clock.Advance(time.Hour)
+	tr.record.statusChanged(true)
+	history := tr.newHistory("reset-1", resetLevel)
+	history.SetOperation("park", "restarted container manually")
+	incident = tr.mustTrack(t, history)
+	if incident.ClosedLevel != resetLevel || incident.ClosedBy != "park" {
+		t.Fatalf("incident closed level, closed by = %s, %s, want %s, park", incident.ClosedLevel, incident.ClosedBy, resetLevel)
+	}
+	if got := incident.TimeToRecover(); got != time.Hour {
+		t.Fatalf("time to recover = %s, want 1h", got)
+	}
+
+	// new incident is opened when status leave healthy again, with time of last healthy check process
+	tr.clock.Advance(time.Minute * 10)
+	tr.mustNotTrack(t, tr.newHistory("healthy-1", healthyLevel))
+	tr.clock.Advance(time.Minute * 10)
+	tr.record.statusChanged(false)
+	next := tr.mustTrack(t, tr.newHistory("weak-2", weakDetectedLevel))
+	if next.ID != "weak-2" || next.TimeToDetect() != time.Minute*10 {
+		t.Fatalf("incident id, time to detect = %s, %s, want weak-2, 10m", next.ID, next.TimeToDetect())
+	}
+
+	// incident without time to detect isn't counted in MTTD, and open incident isn't counted in MTTR
+	stats := domain.IncidentStatsOf([]domain.Incident{incident, next})
+	if stats.Total != 2 || stats.Open != 1 || stats.MTTD != time.Minute*10 || stats.MTTR != time.Hour {
+		t.Fatalf("incident stats = %+v, want total 2, open 1, MTTD 10m, MTTR 1h", stats)
+	}
+}
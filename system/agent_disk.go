@@ -35,9 +35,8 @@ func (sa *sysAgent) GetRemainDiskCapacity() (size bytesize.ByteSize, err error)
 }
 
 // PruneDockerSystem prune docker system(build cache, containers, images, networks) and return reclaimed space size
-func (sa *sysAgent) PruneDockerSystem() (reclaimed bytesize.ByteSize, err error) {
+func (sa *sysAgent) PruneDockerSystem(ctx context.Context) (reclaimed bytesize.ByteSize, err error) {
 	var (
-		ctx  = context.Background()
 		args = filters.Args{}
 	)
 
@@ -26,12 +26,11 @@ func (sa *sysAgent) GetTotalSystemMemoryUsage() (usage bytesize.ByteSize, err er
 }
 
 // CalculateContainersCPUUsage calculate memory usage & return calculateContainersMemoryUsageResult
-func (sa *sysAgent) CalculateContainersMemoryUsage() (interface {
+func (sa *sysAgent) CalculateContainersMemoryUsage(ctx context.Context) (interface {
 	TotalMemoryUsage() (usage bytesize.ByteSize)
 	MostConsumerExceptFor(names []string) (id, name string, usage bytesize.ByteSize)
 }, error) {
 	var (
-		ctx    = context.Background()
 		result = calculateContainersMemoryUsageResult{}
 	)
 